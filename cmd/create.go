@@ -1,13 +1,20 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/anmho/create-go-api/cmd/flags"
 	"github.com/anmho/create-go-api/internal/generator"
 	"github.com/anmho/create-go-api/internal/tui"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
@@ -17,8 +24,42 @@ var (
 	outputDir   string
 	driver      string
 	framework   string
+	layout      string
 	deploy      bool
 	interactive bool
+	dryRun      bool
+	printTree   bool
+	plan        bool
+	jsonOutput  bool
+	verify      bool
+	gitInit     bool
+	saveConfig  bool
+	yes         bool
+
+	overwritePolicy   string
+	force             bool
+	deployTargets     []string
+	tableProvisioning string
+	awsCredentials    string
+	indexes           []string
+	arch              string
+	requestValidation bool
+	withAuth          bool
+	withClient        bool
+	withGRPC          bool
+	splitConfig       bool
+	minimal           bool
+	apiPrefix         string
+	port              string
+	goVersion         string
+	archive           bool
+	templatesDir      string
+	flyRegion         string
+	configFile        string
+
+	// Set only from --config, which has no flags for them
+	awsRegion  string
+	awsProfile string
 )
 
 var createCmd = &cobra.Command{
@@ -28,14 +69,21 @@ var createCmd = &cobra.Command{
 
 The command supports two modes:
   - Interactive TUI mode: Run without flags or use --interactive flag
-  - Non-interactive CLI mode: Provide all required flags (--name, --driver, --framework, etc.)`,
+  - Non-interactive CLI mode: Provide all required flags (--name, --driver, --framework, etc.)
+    or a saved configuration with --config; flags override values from the file`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// If interactive flag is set, use TUI
 		if interactive {
-			app := tui.NewApp()
+			app := tui.NewApp(tui.Options{Verify: verify, GitInit: gitInit, Port: port})
 			return app.Run()
 		}
 
+		if configFile != "" {
+			if err := applyConfigFile(cmd, configFile); err != nil {
+				return err
+			}
+		}
+
 		// Check if any flags were provided
 		flagsProvided := projectName != "" || modulePath != "" || outputDir != "" ||
 			driver != "" || framework != ""
@@ -50,16 +98,141 @@ The command supports two modes:
 				ProjectName: projectName,
 				ModulePath:  modulePath,
 				OutputDir:   outputDir,
-				Database:    generator.DatabaseConfig{Type: generator.DatabaseType(driver)},
-				Framework:   generator.FrameworkType(framework),
-				Deploy:      deploy,
+				Database: generator.DatabaseConfig{
+					Type:              generator.DatabaseType(driver),
+					AWSRegion:         awsRegion,
+					AWSProfile:        awsProfile,
+					AWSCredentials:    generator.AWSCredentials(awsCredentials),
+					TableProvisioning: generator.TableProvisioning(tableProvisioning),
+					Indexes:           indexes,
+				},
+				Framework:         generator.FrameworkType(framework),
+				Layout:            generator.LayoutType(layout),
+				Deploy:            deploy,
+				Arch:              generator.Arch(arch),
+				RequestValidation: requestValidation,
+				Auth:              withAuth,
+				Client:            withClient,
+				GRPC:              withGRPC,
+				SplitConfig:       splitConfig,
+				Minimal:           minimal,
+				APIPrefix:         apiPrefix,
+				Port:              port,
+				GoVersion:         goVersion,
+				FlyRegion:         flyRegion,
+			}
+			// Static credentials come from the environment; there are no flags for the keys
+			if cfg.Database.AWSCredentials == generator.AWSCredentialsStatic {
+				cfg.Database.AWSAccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+				cfg.Database.AWSSecretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+			}
+			for _, target := range deployTargets {
+				cfg.DeployTargets = append(cfg.DeployTargets, generator.DeployTarget(target))
 			}
 
-			gen := generator.NewGenerator(cfg)
+			var opts []generator.GeneratorOption
+			if overwritePolicy != "" {
+				opts = append(opts, generator.WithOverwritePolicy(generator.OverwritePolicy(overwritePolicy)))
+			}
+
+			if templatesDir != "" {
+				opts = append(opts, generator.WithTemplateDir(templatesDir))
+			}
+
+			// Warnings go to stderr so they don't end up in --archive output
+			for _, warning := range generator.NewGenerator(cfg).Warnings() {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", warning)
+			}
+
+			if plan {
+				files, err := generator.NewGenerator(cfg, opts...).Plan()
+				if err != nil {
+					return fmt.Errorf("failed to plan project: %w", err)
+				}
+				if jsonOutput {
+					encoder := json.NewEncoder(cmd.OutOrStdout())
+					encoder.SetIndent("", "  ")
+					return encoder.Encode(files)
+				}
+				printPlan(cmd.OutOrStdout(), files)
+				return nil
+			}
+
+			if dryRun {
+				gen := generator.NewGenerator(cfg, append(opts, generator.WithDryRun())...)
+				if err := gen.Generate(); err != nil {
+					return fmt.Errorf("failed to generate project: %w", err)
+				}
+				if printTree {
+					fmt.Print(generator.FormatTree(outputDir, dryRunPaths(gen.DryRunFiles()), gen.DryRunDirs()))
+					return nil
+				}
+				printDryRun(outputDir, gen.DryRunFiles())
+				return nil
+			}
+
+			if archive {
+				archiveFS := generator.NewArchiveFileSystem()
+				gen := generator.NewGenerator(cfg, append(opts, generator.WithFileSystem(archiveFS))...)
+				if err := gen.Generate(); err != nil {
+					return fmt.Errorf("failed to generate project: %w", err)
+				}
+				return archiveFS.WriteTar(cmd.OutOrStdout())
+			}
+
+			// Confirm before writing when someone is at the terminal to answer
+			if !yes && isTerminal(cmd.InOrStdin()) {
+				printSummary(cmd.OutOrStdout(), cfg)
+				confirmed, err := confirm(cmd.InOrStdin(), cmd.OutOrStdout(), "Generate project?")
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					fmt.Fprintln(cmd.OutOrStdout(), "Aborted; nothing was written.")
+					return nil
+				}
+			}
+
+			gen := generator.NewGenerator(cfg, opts...)
 			if err := gen.Generate(); err != nil {
 				return fmt.Errorf("failed to generate project: %w", err)
 			}
 
+			// Saved before --git-init so the initial commit includes it
+			if saveConfig {
+				if err := generator.SaveConfigFile(filepath.Join(outputDir, generator.ConfigFileName), cfg); err != nil {
+					return err
+				}
+			}
+
+			if verify {
+				fmt.Println("Verifying generated project builds...")
+				if err := gen.Verify(cmd.Context()); err != nil {
+					return err
+				}
+			}
+
+			if gitInit {
+				if err := gen.GitInit(cmd.Context()); err != nil {
+					if !errors.Is(err, generator.ErrGitNotFound) && !errors.Is(err, generator.ErrGitRepoExists) {
+						return err
+					}
+					fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", err)
+				} else {
+					fmt.Println("Initialized a git repository with an initial commit")
+				}
+			}
+
+			if unchanged := gen.UnchangedFiles(); len(unchanged) > 0 {
+				fmt.Printf("Left %d existing files unchanged (already up to date)\n", len(unchanged))
+			}
+			if skipped := gen.SkippedFiles(); len(skipped) > 0 {
+				fmt.Printf("Skipped %d existing files that differ from the generated output (use --force to overwrite):\n", len(skipped))
+				for _, path := range skipped {
+					fmt.Printf("  %s\n", path)
+				}
+			}
+
 			fmt.Printf("✓ Project generated successfully at: %s\n", outputDir)
 			fmt.Printf("  Module:  %s\n", modulePath)
 			fmt.Printf("  Database: %s\n", driver)
@@ -68,24 +241,230 @@ The command supports two modes:
 		}
 
 		// Otherwise, use TUI
-		app := tui.NewApp()
+		app := tui.NewApp(tui.Options{Verify: verify, GitInit: gitInit, Port: port})
 		return app.Run()
 	},
 }
 
 func init() {
+	createCmd.Flags().StringVar(&configFile, "config", "", "Load the configuration from a file saved by the TUI (e.g. .create-go-api.yaml); flags override its values")
 	createCmd.Flags().StringVarP(&projectName, "name", "n", "", "Project name")
 	createCmd.Flags().StringVarP(&modulePath, "module-path", "m", "", "Go module path")
 	createCmd.Flags().StringVarP(&driver, "driver", "d", "", "Database driver (postgres, dynamodb)")
-	createCmd.Flags().StringVarP(&framework, "framework", "f", "", "API framework (chi, connectrpc)")
+	createCmd.Flags().StringVar(&tableProvisioning, "table-provisioning", "terraform", "How the DynamoDB table is created (terraform, runtime)")
+	createCmd.Flags().StringVar(&awsCredentials, "aws-credentials", "profile", "How .env.local supplies DynamoDB AWS credentials (profile: reference AWS_PROFILE, static: write AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY from the environment in plaintext)")
+	createCmd.Flags().StringArrayVar(&indexes, "pg-index", nil, "Additional Postgres index on the posts table as a column list, e.g. \"user_id, updated_at DESC\" (repeatable)")
+	createCmd.Flags().StringVarP(&framework, "framework", "f", "", "API framework (chi, connectrpc, gin, echo)")
+	createCmd.Flags().BoolVar(&requestValidation, "with-request-validation", false, "Validate REST request bodies against embedded JSON schemas, reporting every invalid field")
+	createCmd.Flags().BoolVar(&withAuth, "with-auth", false, "Require a JWT Bearer token (signed with JWT_SECRET) on posts requests")
+	createCmd.Flags().BoolVar(&withClient, "with-client", true, "Generate a typed Go client package for the API (ConnectRPC projects document the generated Connect client); --with-client=false leaves it out")
+	createCmd.Flags().BoolVar(&withGRPC, "with-grpc", false, "Also serve the posts API over ConnectRPC (Connect, gRPC and gRPC-Web) on the same port as the chi REST routes")
+	createCmd.Flags().BoolVar(&splitConfig, "split-config", false, "Generate the config package as per-concern files (config_server.go, config_auth.go, ...) instead of a single config.go")
+	createCmd.Flags().BoolVar(&minimal, "minimal", false, "Leave out metrics, Prometheus and Grafana, mockery mocks and the CI workflow; can't be combined with --deploy")
+	createCmd.Flags().StringVar(&apiPrefix, "api-prefix", "", "Path prefix the REST API routes are served under, e.g. /api/v1")
+	createCmd.Flags().StringVar(&port, "port", "", "Port the server listens on, written to every stage's config file (default \"8080\")")
+	createCmd.Flags().StringVar(&goVersion, "go-version", "", "Go version for go.mod, the Dockerfile and CI, e.g. 1.25.4 (defaults to the Go version create-go-api was built with)")
+	createCmd.Flags().StringVar(&templatesDir, "templates", "", "Directory of template overrides, matched by path against the built-in templates (e.g. <dir>/templates/Makefile.tmpl)")
+	createCmd.Flags().StringVar(&layout, "layout", "standard", "Package layout (standard, flat)")
 	createCmd.Flags().BoolVar(&deploy, "deploy", false, "Enable deployment setup")
-	createCmd.Flags().StringVarP(&outputDir, "output", "o", "", "Output directory (defaults to project name)")
+	createCmd.Flags().StringSliceVar(&deployTargets, "deploy-target", nil, "Deployment targets (fly, kubernetes, ecs, railway, render); implies --deploy, defaults to fly")
+	createCmd.Flags().StringVar(&flyRegion, "fly-region", "", "Fly.io primary region, e.g. fra (defaults to the region nearest the DynamoDB AWS region, or iad)")
+	createCmd.Flags().StringVar(&arch, "arch", "", "Container image architecture (amd64, arm64, both); defaults to the host architecture")
+	createCmd.Flags().StringVarP(&outputDir, "output", "o", "", "Output directory (defaults to project name); \"-\" writes a tarball to stdout (same as --archive)")
+	createCmd.Flags().BoolVar(&archive, "archive", false, "Write the project to stdout as a tarball instead of a directory")
 	createCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Use interactive TUI mode (default when no flags provided)")
+	createCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the files that would be generated without writing them")
+	createCmd.Flags().BoolVar(&printTree, "print-tree", false, "Print the files that would be generated as a directory tree (implies --dry-run)")
+	createCmd.Flags().BoolVar(&plan, "plan", false, "Print the files the configuration would generate and the templates they come from, without rendering them")
+	createCmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the --plan output as JSON")
+	createCmd.Flags().BoolVarP(&yes, "yes", "y", false, "Generate without showing the configuration summary and asking for confirmation")
+	createCmd.Flags().BoolVar(&verify, "verify", false, "Build the generated project after generation to verify it compiles")
+	createCmd.Flags().BoolVar(&saveConfig, "save-config", false, "Save the configuration to "+generator.ConfigFileName+" in the project, for create --config and update")
+	createCmd.Flags().BoolVar(&gitInit, "git-init", false, "Initialize a git repository in the output directory and commit the generated files")
+	createCmd.Flags().StringVar(&overwritePolicy, "overwrite-policy", "", "How to handle existing files that differ from the generated output (skip, overwrite, backup) (default \"skip\")")
+	createCmd.Flags().BoolVar(&force, "force", false, "Overwrite existing files that differ from the generated output (same as --overwrite-policy overwrite)")
+}
+
+// printDryRun prints the files that would be created by a dry run
+func printDryRun(outputDir string, files []generator.GeneratedFile) {
+	fmt.Printf("Dry run: the following files would be created in %s\n", outputDir)
+	total := 0
+	for _, file := range files {
+		fmt.Printf("  %s (%d bytes)\n", file.Path, file.Size)
+		total += file.Size
+	}
+	fmt.Printf("\n%d files, %d bytes total\n", len(files), total)
+}
+
+// applyConfigFile sets the create flags from a saved config file, keeping any flag that
+// was given explicitly on the command line
+func applyConfigFile(cmd *cobra.Command, path string) error {
+	cfg, err := generator.LoadConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	changed := cmd.Flags().Changed
+	setString := func(name string, target *string, value string) {
+		if !changed(name) && value != "" {
+			*target = value
+		}
+	}
+	setBool := func(name string, target *bool, value bool) {
+		if !changed(name) {
+			*target = value
+		}
+	}
+
+	setString("name", &projectName, cfg.ProjectName)
+	setString("module-path", &modulePath, cfg.ModulePath)
+	setString("output", &outputDir, cfg.OutputDir)
+	setString("driver", &driver, string(cfg.Database.Type))
+	setString("table-provisioning", &tableProvisioning, string(cfg.Database.TableProvisioning))
+	setString("aws-credentials", &awsCredentials, string(cfg.Database.AWSCredentials))
+	setString("framework", &framework, string(cfg.Framework))
+	setString("layout", &layout, string(cfg.Layout))
+	setString("arch", &arch, string(cfg.Arch))
+	setString("api-prefix", &apiPrefix, cfg.APIPrefix)
+	setString("port", &port, cfg.Port)
+	setString("go-version", &goVersion, cfg.GoVersion)
+	setString("fly-region", &flyRegion, cfg.FlyRegion)
+	setBool("deploy", &deploy, cfg.Deploy)
+	setBool("with-request-validation", &requestValidation, cfg.RequestValidation)
+	setBool("with-auth", &withAuth, cfg.Auth)
+	setBool("with-client", &withClient, cfg.Client)
+	setBool("with-grpc", &withGRPC, cfg.GRPC)
+	setBool("split-config", &splitConfig, cfg.SplitConfig)
+	setBool("minimal", &minimal, cfg.Minimal)
+	if !changed("pg-index") {
+		indexes = cfg.Database.Indexes
+	}
+	if !changed("deploy-target") {
+		deployTargets = nil
+		for _, target := range cfg.DeployTargets {
+			deployTargets = append(deployTargets, string(target))
+		}
+	}
+	awsRegion = cfg.Database.AWSRegion
+	awsProfile = cfg.Database.AWSProfile
+	return nil
+}
+
+// printSummary prints the resolved configuration, as the TUI's review step shows it
+func printSummary(w io.Writer, cfg generator.ProjectConfig) {
+	gen := generator.NewGenerator(cfg)
+	line := func(label, value string) {
+		fmt.Fprintf(w, "  %-15s%s\n", label+":", value)
+	}
+
+	fmt.Fprintln(w, "Review configuration:")
+	line("Project Name", cfg.ProjectName)
+	line("Module Path", cfg.ModulePath)
+	line("Output Dir", cfg.OutputDir)
+	line("Database", string(cfg.Database.Type))
+	if cfg.Database.AWSRegion != "" {
+		line("AWS Region", cfg.Database.AWSRegion)
+	}
+	if cfg.Database.AWSProfile != "" {
+		line("AWS Profile", cfg.Database.AWSProfile)
+	}
+	line("Framework", string(cfg.Framework))
+	line("Layout", string(cfg.Layout))
+	if cfg.Port != "" {
+		line("Port", cfg.Port)
+	}
+	line("Go Version", gen.GoVersion())
+
+	var options []string
+	if cfg.Auth {
+		options = append(options, "auth")
+	}
+	if cfg.Client {
+		options = append(options, "client")
+	}
+	if cfg.GRPC {
+		options = append(options, "gRPC")
+	}
+	if cfg.RequestValidation {
+		options = append(options, "request validation")
+	}
+	if cfg.SplitConfig {
+		options = append(options, "split config")
+	}
+	if cfg.Minimal {
+		options = append(options, "minimal")
+	}
+	if cfg.APIPrefix != "" {
+		options = append(options, "API prefix "+cfg.APIPrefix)
+	}
+	if len(options) > 0 {
+		line("Options", strings.Join(options, ", "))
+	}
+
+	if cfg.Deploy {
+		targets := make([]string, 0, len(cfg.DeployTargets))
+		for _, target := range cfg.DeployTargets {
+			targets = append(targets, string(target))
+		}
+		if len(targets) == 0 {
+			targets = append(targets, string(generator.DeployTargetFly))
+		}
+		line("Deploy", strings.Join(targets, ", "))
+		if slices.Contains(targets, string(generator.DeployTargetFly)) {
+			line("Fly.io Region", gen.FlyRegion())
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// confirm asks a yes/no question on out and reads the answer from in. Anything but
+// y or yes (including no input) is a no.
+func confirm(in io.Reader, out io.Writer, question string) (bool, error) {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// isTerminal reports whether r is an interactive terminal
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// printPlan prints each planned file followed by its sources, one per line
+func printPlan(w io.Writer, files []generator.PlannedFile) {
+	for _, file := range files {
+		fmt.Fprintf(w, "%s\t%s\n", file.Path, strings.Join(file.Sources, ","))
+	}
+}
+
+// dryRunPaths returns the paths of the files created by a dry run
+func dryRunPaths(files []generator.GeneratedFile) []string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	return paths
 }
 
 func validateFlags() error {
-	if projectName == "" {
-		return fmt.Errorf("project name is required")
+	if err := generator.ValidateProjectName(projectName); err != nil {
+		return err
+	}
+
+	if err := generator.ValidateModulePath(modulePath); err != nil {
+		return err
 	}
 
 	if !flags.IsValidDatabase(driver) {
@@ -96,17 +475,126 @@ func validateFlags() error {
 		return fmt.Errorf("invalid framework: %s (must be one of: %s)", framework, strings.Join(flags.AllowedFrameworks, ", "))
 	}
 
+	for _, index := range indexes {
+		if driver != "postgres" {
+			return fmt.Errorf("--pg-index requires the postgres driver")
+		}
+		if !flags.IsValidIndex(index) {
+			return fmt.Errorf("invalid index: %q (must be a comma-separated list of columns from: %s, each optionally followed by ASC or DESC)", index, strings.Join(flags.AllowedIndexColumns, ", "))
+		}
+	}
+
+	if requestValidation && framework == "connectrpc" {
+		return fmt.Errorf("--with-request-validation requires a REST framework (chi, gin, echo)")
+	}
+
+	if withGRPC && framework != "chi" {
+		return fmt.Errorf("--with-grpc requires the chi framework (connectrpc already serves gRPC)")
+	}
+
+	if err := generator.ValidateAPIPrefix(apiPrefix); err != nil {
+		return err
+	}
+
+	if err := generator.ValidatePort(port); err != nil {
+		return err
+	}
+
+	if err := generator.ValidateGoVersion(goVersion); err != nil {
+		return err
+	}
+	if apiPrefix != "" && framework == "connectrpc" {
+		return fmt.Errorf("--api-prefix requires a REST framework (chi, gin, echo); ConnectRPC procedures are versioned by the proto package (posts.v1)")
+	}
+
+	if !flags.IsValidAWSCredentials(awsCredentials) {
+		return fmt.Errorf("invalid AWS credentials: %s (must be one of: %s)", awsCredentials, strings.Join(flags.AllowedAWSCredentials, ", "))
+	}
+	if awsCredentials == string(generator.AWSCredentialsStatic) {
+		if driver != "dynamodb" {
+			return fmt.Errorf("--aws-credentials static requires the dynamodb driver")
+		}
+		if os.Getenv("AWS_ACCESS_KEY_ID") == "" || os.Getenv("AWS_SECRET_ACCESS_KEY") == "" {
+			return fmt.Errorf("--aws-credentials static requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY to be set")
+		}
+	}
+
+	if !flags.IsValidTableProvisioning(tableProvisioning) {
+		return fmt.Errorf("invalid table provisioning: %s (must be one of: %s)", tableProvisioning, strings.Join(flags.AllowedTableProvisioning, ", "))
+	}
+
+	if !flags.IsValidLayout(layout) {
+		return fmt.Errorf("invalid layout: %s (must be one of: %s)", layout, strings.Join(flags.AllowedLayouts, ", "))
+	}
+
+	for _, target := range deployTargets {
+		if !flags.IsValidDeployTarget(target) {
+			return fmt.Errorf("invalid deploy target: %s (must be one of: %s)", target, strings.Join(flags.AllowedDeployTargets, ", "))
+		}
+	}
+	if len(deployTargets) > 0 {
+		deploy = true
+	}
+
+	if err := generator.ValidateFlyRegion(flyRegion); err != nil {
+		return err
+	}
+
+	if arch != "" && !flags.IsValidArch(arch) {
+		return fmt.Errorf("invalid arch: %s (must be one of: %s)", arch, strings.Join(flags.AllowedArchs, ", "))
+	}
+
+	if overwritePolicy != "" && !flags.IsValidOverwritePolicy(overwritePolicy) {
+		return fmt.Errorf("invalid overwrite policy: %s (must be one of: %s)", overwritePolicy, strings.Join(flags.AllowedOverwritePolicies, ", "))
+	}
+	if force {
+		if overwritePolicy != "" && overwritePolicy != "overwrite" {
+			return fmt.Errorf("--force conflicts with --overwrite-policy %s", overwritePolicy)
+		}
+		overwritePolicy = "overwrite"
+	}
+
+	if templatesDir != "" {
+		info, err := os.Stat(templatesDir)
+		if err != nil {
+			return fmt.Errorf("invalid templates directory: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("invalid templates directory: %s is not a directory", templatesDir)
+		}
+	}
+
+	if outputDir == "-" {
+		archive = true
+		outputDir = ""
+	}
 	if outputDir == "" {
 		outputDir = projectName
 	}
 
-	// Check if directory exists and is not empty
-	if info, err := os.Stat(outputDir); err == nil {
-		if info.IsDir() {
-			entries, err := os.ReadDir(outputDir)
-			if err == nil && len(entries) > 0 {
-				return fmt.Errorf("directory %s already exists and is not empty", outputDir)
-			}
+	if printTree {
+		dryRun = true
+	}
+	if archive && (dryRun || verify || gitInit) {
+		return fmt.Errorf("--archive cannot be combined with --dry-run, --print-tree, --verify or --git-init")
+	}
+	if saveConfig && (archive || dryRun || plan) {
+		return fmt.Errorf("--save-config cannot be combined with --archive, --dry-run, --print-tree or --plan")
+	}
+	if dryRun && gitInit {
+		return fmt.Errorf("--git-init cannot be combined with --dry-run or --print-tree")
+	}
+	if jsonOutput && !plan {
+		return fmt.Errorf("--json requires --plan")
+	}
+	if plan && (archive || dryRun || verify || gitInit) {
+		return fmt.Errorf("--plan cannot be combined with --archive, --dry-run, --print-tree, --verify or --git-init")
+	}
+
+	// Only a real run writes to the output directory
+	if !archive && !dryRun && !plan {
+		if err := generator.ValidateOutputDir(outputDir); err != nil {
+			return err
 		}
 	}
 
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/anmho/create-go-api/cmd/flags"
+	"github.com/anmho/create-go-api/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorDriver    string
+	doctorFramework string
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the tools generated projects need are installed",
+	Long: `Check PATH for the tools generated projects use (go, docker, flyctl, and buf and atlas
+where the framework and database need them) and report their versions.
+Pass --framework and --driver to check only what a specific project needs.
+Exits with an error if any tool is missing.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if doctorDriver != "" && !flags.IsValidDatabase(doctorDriver) {
+			return fmt.Errorf("invalid database driver: %s (must be one of: %s)", doctorDriver, strings.Join(flags.AllowedDatabases, ", "))
+		}
+		if doctorFramework != "" && !flags.IsValidFramework(doctorFramework) {
+			return fmt.Errorf("invalid framework: %s (must be one of: %s)", doctorFramework, strings.Join(flags.AllowedFrameworks, ", "))
+		}
+
+		var results []generator.PrerequisiteResult
+		for _, prerequisite := range generator.Prerequisites(generator.FrameworkType(doctorFramework), generator.DatabaseType(doctorDriver)) {
+			results = append(results, generator.CheckPrerequisite(cmd.Context(), prerequisite))
+		}
+
+		out := cmd.OutOrStdout()
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TOOL\tSTATUS\tVERSION\tNEEDED FOR")
+		var missing []generator.PrerequisiteResult
+		for _, result := range results {
+			status, version := "pass", result.Version
+			if !result.Found {
+				status, version = "FAIL", "not found"
+				missing = append(missing, result)
+			} else if version == "" {
+				version = "unknown"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Name, status, version, result.Purpose)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if len(missing) == 0 {
+			fmt.Fprintln(out, "\nAll prerequisites found")
+			return nil
+		}
+		fmt.Fprintln(out, "\nInstall the missing tools:")
+		names := make([]string, len(missing))
+		for i, result := range missing {
+			fmt.Fprintf(out, "  %s: %s\n", result.Name, result.InstallURL)
+			names[i] = result.Name
+		}
+		// The table already explains the failure, so only main's one-line error follows it
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		return fmt.Errorf("missing prerequisites: %s", strings.Join(names, ", "))
+	},
+}
+
+func init() {
+	doctorCmd.Flags().StringVarP(&doctorDriver, "driver", "d", "", "Only check tools for this database driver (postgres, dynamodb)")
+	doctorCmd.Flags().StringVarP(&doctorFramework, "framework", "f", "", "Only check tools for this API framework (chi, connectrpc, gin, echo)")
+}
+
@@ -0,0 +1,13 @@
+package flags
+
+var AllowedArchs = []string{"amd64", "arm64", "both"}
+
+func IsValidArch(arch string) bool {
+	for _, allowed := range AllowedArchs {
+		if arch == allowed {
+			return true
+		}
+	}
+	return false
+}
+
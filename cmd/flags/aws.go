@@ -0,0 +1,12 @@
+package flags
+
+var AllowedAWSCredentials = []string{"profile", "static"}
+
+func IsValidAWSCredentials(credentials string) bool {
+	for _, allowed := range AllowedAWSCredentials {
+		if credentials == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package flags
+
+var AllowedDeployTargets = []string{"fly", "kubernetes", "ecs", "railway", "render"}
+
+func IsValidDeployTarget(target string) bool {
+	for _, allowed := range AllowedDeployTargets {
+		if target == allowed {
+			return true
+		}
+	}
+	return false
+}
+
@@ -1,6 +1,6 @@
 package flags
 
-var AllowedFrameworks = []string{"chi", "connectrpc"}
+var AllowedFrameworks = []string{"chi", "connectrpc", "gin", "echo"}
 
 func IsValidFramework(fw string) bool {
 	for _, allowed := range AllowedFrameworks {
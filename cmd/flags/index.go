@@ -0,0 +1,33 @@
+package flags
+
+import "strings"
+
+var AllowedIndexColumns = []string{"id", "user_id", "title", "content", "created_at", "updated_at"}
+
+// IsValidIndex reports whether spec is a comma-separated list of posts columns,
+// each optionally followed by ASC or DESC
+func IsValidIndex(spec string) bool {
+	columns := strings.Split(spec, ",")
+	for _, column := range columns {
+		fields := strings.Fields(column)
+		if len(fields) == 0 || len(fields) > 2 {
+			return false
+		}
+		if !isAllowedIndexColumn(fields[0]) {
+			return false
+		}
+		if len(fields) == 2 && !strings.EqualFold(fields[1], "ASC") && !strings.EqualFold(fields[1], "DESC") {
+			return false
+		}
+	}
+	return true
+}
+
+func isAllowedIndexColumn(column string) bool {
+	for _, allowed := range AllowedIndexColumns {
+		if column == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package flags
+
+var AllowedLayouts = []string{"standard", "flat"}
+
+func IsValidLayout(layout string) bool {
+	for _, allowed := range AllowedLayouts {
+		if layout == allowed {
+			return true
+		}
+	}
+	return false
+}
+
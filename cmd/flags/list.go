@@ -0,0 +1,26 @@
+package flags
+
+// Category is a named set of allowed values for a create flag, printed by the list command
+type Category struct {
+	Name   string
+	Flag   string
+	Values []string
+}
+
+// Categories are the value sets the list command can print, in display order
+var Categories = []Category{
+	{Name: "databases", Flag: "driver", Values: AllowedDatabases},
+	{Name: "frameworks", Flag: "framework", Values: AllowedFrameworks},
+	{Name: "deploy-targets", Flag: "deploy-target", Values: AllowedDeployTargets},
+}
+
+// FindCategory returns the category with the given name
+func FindCategory(name string) (Category, bool) {
+	for _, category := range Categories {
+		if category.Name == name {
+			return category, true
+		}
+	}
+	return Category{}, false
+}
+
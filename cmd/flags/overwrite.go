@@ -0,0 +1,12 @@
+package flags
+
+var AllowedOverwritePolicies = []string{"skip", "overwrite", "backup"}
+
+func IsValidOverwritePolicy(policy string) bool {
+	for _, allowed := range AllowedOverwritePolicies {
+		if policy == allowed {
+			return true
+		}
+	}
+	return false
+}
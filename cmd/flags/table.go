@@ -0,0 +1,12 @@
+package flags
+
+var AllowedTableProvisioning = []string{"terraform", "runtime"}
+
+func IsValidTableProvisioning(provisioning string) bool {
+	for _, allowed := range AllowedTableProvisioning {
+		if provisioning == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/anmho/create-go-api/cmd/flags"
+	"github.com/spf13/cobra"
+)
+
+var listJSON bool
+
+var listCmd = &cobra.Command{
+	Use:   "list [databases|frameworks|deploy-targets]",
+	Short: "List supported databases, frameworks and deploy targets",
+	Long: `List the values accepted by the create command's --driver, --framework and --deploy-target flags.
+With no argument, every category is listed.`,
+	ValidArgs: categoryNames(),
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		categories := flags.Categories
+		if len(args) == 1 {
+			category, _ := flags.FindCategory(args[0])
+			categories = []flags.Category{category}
+		}
+
+		out := cmd.OutOrStdout()
+		if listJSON {
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+			// A single category is printed as a plain array, all categories as an object keyed by name
+			if len(args) == 1 {
+				return encoder.Encode(categories[0].Values)
+			}
+			values := make(map[string][]string, len(categories))
+			for _, category := range categories {
+				values[category.Name] = category.Values
+			}
+			return encoder.Encode(values)
+		}
+
+		if len(args) == 1 {
+			for _, value := range categories[0].Values {
+				fmt.Fprintln(out, value)
+			}
+			return nil
+		}
+		for _, category := range categories {
+			fmt.Fprintf(out, "%s (--%s): %s\n", category.Name, category.Flag, strings.Join(category.Values, ", "))
+		}
+		return nil
+	},
+}
+
+// categoryNames returns the names accepted as the list command's argument
+func categoryNames() []string {
+	names := make([]string, 0, len(flags.Categories))
+	for _, category := range flags.Categories {
+		names = append(names, category.Name)
+	}
+	return names
+}
+
+func init() {
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "Print the values as JSON")
+}
+
@@ -7,7 +7,7 @@ import (
 var rootCmd = &cobra.Command{
 	Use:   "create-go-api",
 	Short: "A CLI tool to scaffold production-ready Go API services",
-	Long:  `create-go-api is a CLI tool that generates Go API service boilerplate with database support (DynamoDB, Postgres), framework support (Chi, ConnectRPC), and one-click deployment.`,
+	Long:  `create-go-api is a CLI tool that generates Go API service boilerplate with database support (DynamoDB, Postgres), framework support (Chi, Gin, Echo, ConnectRPC), and one-click deployment.`,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -17,5 +17,9 @@ func Execute() error {
 
 func init() {
 	rootCmd.AddCommand(createCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(updateCmd)
+	rootCmd.AddCommand(versionCmd)
 }
 
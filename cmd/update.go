@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/anmho/create-go-api/cmd/flags"
+	"github.com/anmho/create-go-api/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateOnly            []string
+	updateOverwritePolicy string
+	updateTemplatesDir    string
+	updatePlan            bool
+)
+
+var updateCmd = &cobra.Command{
+	Use:     "update [project-dir]",
+	Aliases: []string{"upgrade"},
+	Short:   "Re-scaffold selected files of an existing project",
+	Long: `Regenerate selected files of an existing project (the current directory by default) with
+this version of create-go-api, using the configuration saved in its ` + generator.ConfigFileName + `.
+--only names the files or directories to regenerate, relative to the project, e.g.
+--only Makefile,.github,Dockerfile. Existing files that differ are renamed to .bak
+before being replaced, so local changes can be merged back (see --overwrite-policy).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir := "."
+		if len(args) == 1 {
+			projectDir = args[0]
+		}
+		if len(updateOnly) == 0 {
+			return fmt.Errorf("--only is required: name the files or directories to regenerate, e.g. --only Makefile,.github")
+		}
+		if !flags.IsValidOverwritePolicy(updateOverwritePolicy) {
+			return fmt.Errorf("invalid overwrite policy: %s (must be one of: %s)", updateOverwritePolicy, strings.Join(flags.AllowedOverwritePolicies, ", "))
+		}
+
+		configPath := filepath.Join(projectDir, generator.ConfigFileName)
+		cfg, err := generator.LoadConfigFile(configPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("no %s in %s; save one with create --save-config (or from the TUI), or write it by hand (see create --config)", generator.ConfigFileName, projectDir)
+		}
+		if err != nil {
+			return err
+		}
+		cfg.OutputDir = projectDir
+
+		opts := []generator.GeneratorOption{
+			generator.WithOnly(updateOnly...),
+			generator.WithOverwritePolicy(generator.OverwritePolicy(updateOverwritePolicy)),
+		}
+		if updateTemplatesDir != "" {
+			opts = append(opts, generator.WithTemplateDir(updateTemplatesDir))
+		}
+
+		if updatePlan {
+			files, err := generator.NewGenerator(cfg, opts...).Plan()
+			if err != nil {
+				return fmt.Errorf("failed to plan update: %w", err)
+			}
+			printPlan(cmd.OutOrStdout(), files)
+			return nil
+		}
+
+		gen := generator.NewGenerator(cfg, opts...)
+		if err := gen.Generate(); err != nil {
+			return fmt.Errorf("failed to update project: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		if unchanged := gen.UnchangedFiles(); len(unchanged) > 0 {
+			fmt.Fprintf(out, "Left %d files unchanged (already up to date)\n", len(unchanged))
+		}
+		if backedUp := gen.BackedUpFiles(); len(backedUp) > 0 {
+			fmt.Fprintf(out, "Replaced %d files that differed; the previous versions were saved as .bak:\n", len(backedUp))
+			for _, path := range backedUp {
+				fmt.Fprintf(out, "  %s\n", path)
+			}
+		}
+		if skipped := gen.SkippedFiles(); len(skipped) > 0 {
+			fmt.Fprintf(out, "Skipped %d files that differ from the generated output (use --overwrite-policy backup or overwrite to replace them):\n", len(skipped))
+			for _, path := range skipped {
+				fmt.Fprintf(out, "  %s\n", path)
+			}
+		}
+		fmt.Fprintf(out, "✓ Updated %s\n", strings.Join(updateOnly, ", "))
+		return nil
+	},
+}
+
+func init() {
+	updateCmd.Flags().StringSliceVar(&updateOnly, "only", nil, "Files or directories to regenerate, relative to the project (e.g. Makefile,.github)")
+	updateCmd.Flags().StringVar(&updateOverwritePolicy, "overwrite-policy", "backup", "How to handle existing files that differ from the generated output (skip, overwrite, backup)")
+	updateCmd.Flags().StringVar(&updateTemplatesDir, "templates", "", "Directory of template overrides, matched by path against the built-in templates")
+	updateCmd.Flags().BoolVar(&updatePlan, "plan", false, "Print the files that would be regenerated and the templates they come from, without writing them")
+}
@@ -7,16 +7,22 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var shortVersion bool
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version number",
 	Long:  `Print the version number and build information for create-go-api`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if shortVersion {
+			fmt.Println(version.Short())
+			return
+		}
 		fmt.Println(version.Info())
 	},
 }
 
 func init() {
-	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().BoolVar(&shortVersion, "short", false, "Print only the version number")
 }
 
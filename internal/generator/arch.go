@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// hostArch returns the architecture of the machine running the generator
+func hostArch() Arch {
+	if runtime.GOARCH == "arm64" {
+		return ArchARM64
+	}
+	return ArchAMD64
+}
+
+// resolvedArch returns the configured architecture, defaulting to the host's.
+// Fly.io, Railway and Render run amd64, so an arm64 host deploying to one of them builds both.
+func (g *Generator) resolvedArch() Arch {
+	if g.config.Arch != "" {
+		return g.config.Arch
+	}
+	if _, ok := g.amd64OnlyTarget(); ok && hostArch() == ArchARM64 {
+		return ArchBoth
+	}
+	return hostArch()
+}
+
+// amd64OnlyTargets are the deploy targets that only run amd64 images, with display names
+var amd64OnlyTargets = []struct {
+	target DeployTarget
+	name   string
+}{
+	{DeployTargetFly, "Fly.io"},
+	{DeployTargetRailway, "Railway"},
+	{DeployTargetRender, "Render"},
+}
+
+// amd64OnlyTarget returns the display name of the first selected deploy target that
+// only runs amd64 images, if any
+func (g *Generator) amd64OnlyTarget() (string, bool) {
+	for _, t := range amd64OnlyTargets {
+		if g.hasDeployTarget(t.target) {
+			return t.name, true
+		}
+	}
+	return "", false
+}
+
+// imagePlatforms returns the Docker platforms to build (e.g. "linux/amd64,linux/arm64")
+func (g *Generator) imagePlatforms() string {
+	arch := g.resolvedArch()
+	if arch == ArchBoth {
+		return strings.Join([]string{"linux/" + string(ArchAMD64), "linux/" + string(ArchARM64)}, ",")
+	}
+	return "linux/" + string(arch)
+}
+
+// ecsCPUArchitecture returns the Fargate runtime platform architecture
+// A multi-arch image runs on X86_64, the Fargate default
+func (g *Generator) ecsCPUArchitecture() string {
+	if g.resolvedArch() == ArchARM64 {
+		return "ARM64"
+	}
+	return "X86_64"
+}
+
+// validateArch rejects architectures the selected deploy targets can't run
+func (g *Generator) validateArch() error {
+	if name, ok := g.amd64OnlyTarget(); ok && g.config.Arch == ArchARM64 {
+		return fmt.Errorf("arch %s is not supported for %s deployments, which run amd64 (use %s or %s)", ArchARM64, name, ArchAMD64, ArchBoth)
+	}
+	return nil
+}
+
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ArchiveFileSystem implements FileSystem by collecting generated files in memory
+// so they can be written out as a single tarball (e.g. to stdout) with WriteTar.
+type ArchiveFileSystem struct {
+	*MemFileSystem
+	modTime time.Time
+}
+
+// NewArchiveFileSystem creates an empty archive filesystem. Entries are stamped
+// with the creation time.
+func NewArchiveFileSystem() *ArchiveFileSystem {
+	return &ArchiveFileSystem{
+		MemFileSystem: NewMemFileSystem(),
+		modTime:       time.Now(),
+	}
+}
+
+// WriteTar writes every directory and file collected so far to w as a tar stream.
+// Entry names are the generated paths made relative (e.g. myservice/go.mod), so
+// extracting the archive recreates the output directory.
+func (f *ArchiveFileSystem) WriteTar(w io.Writer) error {
+	tw := tar.NewWriter(w)
+
+	for _, dir := range f.archiveDirs() {
+		name := archiveEntryName(dir)
+		header := &tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     name + "/",
+			Mode:     0755,
+			ModTime:  f.modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write archive entry %s: %w", dir, err)
+		}
+	}
+
+	for _, path := range f.Paths() {
+		file := f.files[path]
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     archiveEntryName(path),
+			Mode:     int64(file.perm.Perm()),
+			Size:     int64(len(file.data)),
+			ModTime:  f.modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write archive entry %s: %w", path, err)
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return fmt.Errorf("failed to write archive entry %s: %w", path, err)
+		}
+	}
+
+	return tw.Close()
+}
+
+// archiveDirs returns the created directories plus every parent of a directory or
+// file, in sorted order, so parents precede their contents in the archive
+func (f *ArchiveFileSystem) archiveDirs() []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	addParents := func(path string) {
+		for dir := path; archiveEntryName(dir) != "" && !seen[dir]; dir = filepath.Dir(dir) {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	for _, dir := range f.Dirs() {
+		addParents(dir)
+	}
+	for _, path := range f.Paths() {
+		addParents(filepath.Dir(path))
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// archiveEntryName converts a generated path to a relative, slash-separated tar entry name
+func archiveEntryName(path string) string {
+	name := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "/")
+	if name == "." {
+		return ""
+	}
+	return name
+}
+
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveFileSystem_WriteTar(t *testing.T) {
+	t.Parallel()
+
+	archiveFS := NewArchiveFileSystem()
+	gen := NewGenerator(testProjectConfig(), WithFileSystem(archiveFS))
+	require.NoError(t, gen.Generate())
+
+	var buf bytes.Buffer
+	require.NoError(t, archiveFS.WriteTar(&buf))
+
+	files := make(map[string]*tar.Header)
+	contents := make(map[string][]byte)
+	reader := tar.NewReader(&buf)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		files[header.Name] = header
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		contents[header.Name] = data
+	}
+
+	for _, path := range archiveFS.Paths() {
+		name := archiveEntryName(path)
+		require.Contains(t, files, name)
+		expected, err := archiveFS.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, contents[name], name)
+	}
+
+	assert.Contains(t, files, "out/go.mod")
+	assert.Contains(t, files, "out/internal/")
+	assert.Equal(t, byte(tar.TypeDir), files["out/internal/"].Typeflag)
+	assert.Equal(t, int64(filePermRegular), files["out/go.mod"].Mode)
+}
+
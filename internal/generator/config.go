@@ -14,23 +14,123 @@ type FrameworkType string
 const (
 	FrameworkTypeChi        FrameworkType = "chi"
 	FrameworkTypeConnectRPC FrameworkType = "connectrpc"
+	FrameworkTypeGin        FrameworkType = "gin"
+	FrameworkTypeEcho       FrameworkType = "echo"
 )
 
-// ProjectConfig holds all project configuration
+// LayoutType represents the package layout of the generated project
+type LayoutType string
+
+const (
+	LayoutTypeStandard LayoutType = "standard" // Per-resource packages (internal/posts, internal/database, internal/api)
+	LayoutTypeFlat     LayoutType = "flat"     // A single internal/app package
+)
+
+// DeployTarget represents a deployment platform
+type DeployTarget string
+
+const (
+	DeployTargetFly        DeployTarget = "fly"
+	DeployTargetKubernetes DeployTarget = "kubernetes"
+	DeployTargetECS        DeployTarget = "ecs" // AWS ECS Fargate via Terraform
+	DeployTargetRailway    DeployTarget = "railway"
+	DeployTargetRender     DeployTarget = "render"
+)
+
+// Arch is the CPU architecture container images are built for
+type Arch string
+
+const (
+	ArchAMD64 Arch = "amd64"
+	ArchARM64 Arch = "arm64"
+	ArchBoth  Arch = "both" // Multi-arch image for amd64 and arm64
+)
+
+// TableProvisioning controls how the DynamoDB table is created
+type TableProvisioning string
+
+const (
+	TableProvisioningTerraform TableProvisioning = "terraform" // terraform/dynamodb.tf defines the table; the app only describes it
+	TableProvisioningRuntime   TableProvisioning = "runtime"   // The app creates the table on startup if it doesn't exist
+)
+
+// AWSCredentials controls how the generated .env.local supplies AWS credentials
+type AWSCredentials string
+
+const (
+	AWSCredentialsProfile AWSCredentials = "profile" // AWS_PROFILE names a shared config profile; no keys are written (default)
+	AWSCredentialsStatic  AWSCredentials = "static"  // The access key and secret key are written to .env.local in plaintext
+)
+
+// OverwritePolicy controls how generation handles files that already exist
+type OverwritePolicy string
+
+const (
+	OverwritePolicySkip      OverwritePolicy = "skip"      // Leave existing files untouched (default)
+	OverwritePolicyOverwrite OverwritePolicy = "overwrite" // Replace existing files
+	OverwritePolicyBackup    OverwritePolicy = "backup"    // Rename existing files to .bak before writing
+)
+
+// ProjectConfig holds all project configuration. The yaml tags define the config file
+// format (see SaveConfigFile); AWS credentials are never written to it.
 type ProjectConfig struct {
-	ProjectName string
-	ModulePath  string
-	OutputDir   string
-	Database    DatabaseConfig
-	Framework   FrameworkType
-	Deploy      bool
+	ProjectName string         `yaml:"project_name"`
+	ModulePath  string         `yaml:"module_path"`
+	OutputDir   string         `yaml:"output_dir,omitempty"`
+	Database    DatabaseConfig `yaml:"database"`
+	Framework   FrameworkType  `yaml:"framework"`
+	Layout      LayoutType     `yaml:"layout,omitempty"` // Defaults to LayoutTypeStandard
+	Deploy      bool           `yaml:"deploy,omitempty"`
+	// DeployTargets selects the platforms to generate deployment files for
+	// Defaults to Fly.io when Deploy is set
+	DeployTargets []DeployTarget `yaml:"deploy_targets,omitempty"`
+	// Arch selects the image architecture for Docker builds, CI and deploy targets
+	// Defaults to the host architecture (see resolvedArch)
+	Arch Arch `yaml:"arch,omitempty"`
+	// RequestValidation validates REST request bodies against embedded JSON schemas
+	RequestValidation bool `yaml:"request_validation,omitempty"`
+	// Auth requires a JWT Bearer token on posts requests instead of trusting the X-User-ID header
+	Auth bool `yaml:"auth,omitempty"`
+	// Client generates a typed Go client package for the REST API
+	// (ConnectRPC projects document the generated Connect client instead)
+	Client bool `yaml:"client,omitempty"`
+	// SplitConfig generates the config package as per-concern files (config_server.go,
+	// config_auth.go, ...) composed by config.go, instead of a single config.go
+	SplitConfig bool `yaml:"split_config,omitempty"`
+	// Minimal leaves out the metrics package, Prometheus and Grafana, the mockery config and
+	// mock-based service tests, and the CI workflow. Can't be combined with Deploy
+	Minimal bool `yaml:"minimal,omitempty"`
+	// FlyRegion is the Fly.io primary region (e.g. fra). Defaults to the region nearest
+	// the DynamoDB table's AWS region, or iad
+	FlyRegion string `yaml:"fly_region,omitempty"`
+	// GoVersion is the Go version written to go.mod and used by the Dockerfile and CI
+	// (e.g. 1.25.4). Defaults to the version of the toolchain the generator was built with
+	GoVersion string `yaml:"go_version,omitempty"`
+	// Port is the port the server listens on in every stage's config file (e.g. 8081).
+	// Defaults to DefaultPort
+	Port string `yaml:"port,omitempty"`
+	// APIPrefix mounts the REST API routes under a path prefix such as /api/v1 (empty for none)
+	APIPrefix string `yaml:"api_prefix,omitempty"`
+	// GRPC also serves the posts API over ConnectRPC (Connect, gRPC and gRPC-Web) from the
+	// same server as the REST routes. Requires FrameworkTypeChi
+	GRPC bool `yaml:"grpc,omitempty"`
 }
 
 // DatabaseConfig holds database-related configuration
 type DatabaseConfig struct {
-	Type            DatabaseType
-	AWSAccessKeyID  string // For DynamoDB
-	AWSSecretKey    string // For DynamoDB
-	AWSRegion       string // For DynamoDB
+	Type           DatabaseType `yaml:"type"`
+	AWSAccessKeyID string       `yaml:"-"`                     // For DynamoDB
+	AWSSecretKey   string       `yaml:"-"`                     // For DynamoDB
+	AWSRegion      string       `yaml:"aws_region,omitempty"`  // For DynamoDB
+	AWSProfile     string       `yaml:"aws_profile,omitempty"` // For DynamoDB: the profile the credentials come from
+	// AWSCredentials selects how .env.local supplies AWS credentials
+	// Defaults to AWSCredentialsProfile, so no long-lived keys are written
+	AWSCredentials AWSCredentials `yaml:"aws_credentials,omitempty"`
+	// TableProvisioning selects how the DynamoDB table is created
+	// Defaults to TableProvisioningTerraform
+	TableProvisioning TableProvisioning `yaml:"table_provisioning,omitempty"`
+	// Indexes are additional Postgres indexes on the posts table, each a comma-separated
+	// column list with optional sort order (e.g. "title" or "user_id, updated_at DESC")
+	Indexes []string `yaml:"indexes,omitempty"`
 }
 
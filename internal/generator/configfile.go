@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the file the TUI saves its selections to, for reuse with create --config
+const ConfigFileName = ".create-go-api.yaml"
+
+// configFileHeader is written above the saved configuration
+const configFileHeader = `# create-go-api project configuration
+# Regenerate with: create-go-api create --config .create-go-api.yaml
+# AWS credentials are not stored here; they come from aws_profile or the default credentials chain.
+`
+
+// MarshalConfig encodes cfg in the config file format. AWS access keys and secret keys
+// are omitted, so the result is safe to commit or share.
+func MarshalConfig(cfg ProjectConfig) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(configFileHeader)
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalConfig decodes a config file written by MarshalConfig. Unknown keys are
+// rejected so typos don't silently fall back to defaults.
+func UnmarshalConfig(data []byte) (ProjectConfig, error) {
+	var cfg ProjectConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return ProjectConfig{}, fmt.Errorf("invalid config file: %w", err)
+	}
+	return cfg, nil
+}
+
+// SaveConfigFile writes cfg to path in the config file format (see MarshalConfig)
+func SaveConfigFile(path string, cfg ProjectConfig) error {
+	data, err := MarshalConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, filePermRegular); err != nil {
+		return fmt.Errorf("failed to save config file: %w", err)
+	}
+	return nil
+}
+
+// LoadConfigFile reads a config file saved by SaveConfigFile
+func LoadConfigFile(path string) (ProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ProjectConfig{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+	cfg, err := UnmarshalConfig(data)
+	if err != nil {
+		return ProjectConfig{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
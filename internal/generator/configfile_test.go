@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFile_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  ProjectConfig
+	}{
+		{name: "defaults", cfg: testProjectConfig()},
+		{
+			name: "every option",
+			cfg: ProjectConfig{
+				ProjectName: "svc",
+				ModulePath:  "github.com/acme/svc",
+				OutputDir:   "./svc",
+				Database: DatabaseConfig{
+					Type:              DatabaseTypeDynamoDB,
+					AWSRegion:         "eu-west-1",
+					AWSProfile:        "dev",
+					AWSCredentials:    AWSCredentialsStatic,
+					TableProvisioning: TableProvisioningRuntime,
+				},
+				Framework:         FrameworkTypeGin,
+				Layout:            LayoutTypeFlat,
+				Deploy:            true,
+				DeployTargets:     []DeployTarget{DeployTargetFly, DeployTargetKubernetes},
+				Arch:              ArchBoth,
+				RequestValidation: true,
+				Auth:              true,
+				Client:            true,
+				SplitConfig:       true,
+				FlyRegion:         "fra",
+				APIPrefix:         "/api/v1",
+			},
+		},
+		{
+			name: "postgres indexes",
+			cfg: ProjectConfig{
+				ProjectName: "svc",
+				ModulePath:  "github.com/acme/svc",
+				Database:    DatabaseConfig{Type: DatabaseTypePostgres, Indexes: []string{"title", "user_id, updated_at DESC"}},
+				Framework:   FrameworkTypeChi,
+			},
+		},
+		{
+			name: "minimal",
+			cfg: ProjectConfig{
+				ProjectName: "svc",
+				ModulePath:  "github.com/acme/svc",
+				Database:    DatabaseConfig{Type: DatabaseTypePostgres},
+				Framework:   FrameworkTypeEcho,
+				Minimal:     true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), ConfigFileName)
+			require.NoError(t, SaveConfigFile(path, tt.cfg))
+
+			loaded, err := LoadConfigFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.cfg, loaded)
+		})
+	}
+}
+
+func TestMarshalConfig_OmitsAWSCredentials(t *testing.T) {
+	t.Parallel()
+
+	cfg := testProjectConfig()
+	cfg.Database = DatabaseConfig{
+		Type:           DatabaseTypeDynamoDB,
+		AWSAccessKeyID: "AKIAEXAMPLEKEYID",
+		AWSSecretKey:   "example-secret-key",
+		AWSRegion:      "us-east-1",
+		AWSProfile:     "dev",
+	}
+
+	data, err := MarshalConfig(cfg)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "AKIAEXAMPLEKEYID")
+	assert.NotContains(t, string(data), "example-secret-key")
+	assert.Contains(t, string(data), "aws_profile: dev")
+
+	loaded, err := UnmarshalConfig(data)
+	require.NoError(t, err)
+	assert.Empty(t, loaded.Database.AWSAccessKeyID)
+	assert.Empty(t, loaded.Database.AWSSecretKey)
+	assert.Equal(t, "dev", loaded.Database.AWSProfile)
+}
+
+func TestUnmarshalConfig_RejectsUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	_, err := UnmarshalConfig([]byte("project_name: svc\nframwork: chi\n"))
+	assert.ErrorContains(t, err, "framwork")
+}
+
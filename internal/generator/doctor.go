@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// Prerequisite is a command line tool generated projects use during development or deployment
+type Prerequisite struct {
+	Name string
+	// Commands are the executable names tried in order (e.g. flyctl is also installed as fly)
+	Commands    []string
+	VersionArgs []string
+	// Purpose describes what the tool is needed for
+	Purpose    string
+	InstallURL string
+}
+
+// PrerequisiteResult is the outcome of looking up a prerequisite on PATH
+type PrerequisiteResult struct {
+	Prerequisite
+	Found bool
+	Path  string
+	// Version is the first line the tool prints for its version command, empty if it failed
+	Version string
+}
+
+// Prerequisites returns the tools a project with the given framework and database needs.
+// An empty framework or database includes the tools of every framework or database.
+func Prerequisites(framework FrameworkType, database DatabaseType) []Prerequisite {
+	prerequisites := []Prerequisite{
+		{Name: "go", Commands: []string{"go"}, VersionArgs: []string{"version"}, Purpose: "building and running the service", InstallURL: "https://go.dev/doc/install"},
+		{Name: "docker", Commands: []string{"docker"}, VersionArgs: []string{"--version"}, Purpose: "local services (docker-compose) and container builds", InstallURL: "https://docs.docker.com/get-docker/"},
+		{Name: "flyctl", Commands: []string{"flyctl", "fly"}, VersionArgs: []string{"version"}, Purpose: "deploying to Fly.io", InstallURL: "https://fly.io/docs/getting-started/installing-flyctl/"},
+	}
+	if framework == "" || framework == FrameworkTypeConnectRPC {
+		prerequisites = append(prerequisites, Prerequisite{Name: "buf", Commands: []string{"buf"}, VersionArgs: []string{"--version"}, Purpose: "generating ConnectRPC code", InstallURL: "https://buf.build/docs/installation"})
+	}
+	if database == "" || database == DatabaseTypePostgres {
+		prerequisites = append(prerequisites, Prerequisite{Name: "atlas", Commands: []string{"atlas"}, VersionArgs: []string{"version"}, Purpose: "Postgres schema migrations", InstallURL: "https://atlasgo.io/getting-started#installation"})
+	}
+	return prerequisites
+}
+
+// CheckPrerequisite looks the prerequisite up on PATH and, when found, records its version
+func CheckPrerequisite(ctx context.Context, prerequisite Prerequisite) PrerequisiteResult {
+	result := PrerequisiteResult{Prerequisite: prerequisite}
+	for _, command := range prerequisite.Commands {
+		path, err := exec.LookPath(command)
+		if err != nil {
+			continue
+		}
+		result.Found, result.Path = true, path
+
+		output, err := exec.CommandContext(ctx, path, prerequisite.VersionArgs...).Output()
+		if err == nil {
+			result.Version, _, _ = strings.Cut(strings.TrimSpace(string(output)), "\n")
+		}
+		break
+	}
+	return result
+}
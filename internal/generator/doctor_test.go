@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrerequisites(t *testing.T) {
+	t.Parallel()
+
+	names := func(prerequisites []Prerequisite) []string {
+		var names []string
+		for _, prerequisite := range prerequisites {
+			names = append(names, prerequisite.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name      string
+		framework FrameworkType
+		database  DatabaseType
+		expected  []string
+	}{
+		{name: "all", expected: []string{"go", "docker", "flyctl", "buf", "atlas"}},
+		{name: "chi dynamodb", framework: FrameworkTypeChi, database: DatabaseTypeDynamoDB, expected: []string{"go", "docker", "flyctl"}},
+		{name: "connectrpc", framework: FrameworkTypeConnectRPC, database: DatabaseTypeDynamoDB, expected: []string{"go", "docker", "flyctl", "buf"}},
+		{name: "postgres", framework: FrameworkTypeGin, database: DatabaseTypePostgres, expected: []string{"go", "docker", "flyctl", "atlas"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, names(Prerequisites(tt.framework, tt.database)))
+		})
+	}
+}
+
+func TestCheckPrerequisite(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+		result := CheckPrerequisite(context.Background(), Prerequisite{Name: "missing", Commands: []string{"create-go-api-missing-tool"}})
+		assert.False(t, result.Found)
+		assert.Empty(t, result.Version)
+	})
+
+	t.Run("found with fallback command", func(t *testing.T) {
+		t.Parallel()
+		if _, err := exec.LookPath("go"); err != nil {
+			t.Skip("go toolchain not found in PATH")
+		}
+		result := CheckPrerequisite(context.Background(), Prerequisite{Name: "go", Commands: []string{"create-go-api-missing-tool", "go"}, VersionArgs: []string{"version"}})
+		require.True(t, result.Found)
+		assert.NotEmpty(t, result.Path)
+		assert.Contains(t, result.Version, "go version")
+		assert.NotContains(t, result.Version, "\n")
+	})
+}
@@ -2,19 +2,22 @@ package generator
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"go/format"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
-	"syscall"
 )
 
 const (
-	// File permissions using syscall constants
+	// File permissions as portable os.FileMode bits (the syscall constants don't exist on Windows)
 	// Regular file: rw-r--r-- (owner: read+write, group: read, other: read)
-	filePermRegular = syscall.S_IRUSR | syscall.S_IWUSR | syscall.S_IRGRP | syscall.S_IROTH
+	filePermRegular os.FileMode = 0644
 	// Executable file: rwxr-xr-x (owner: read+write+execute, group: read+execute, other: read+execute)
-	filePermExecutable = syscall.S_IRUSR | syscall.S_IWUSR | syscall.S_IXUSR | syscall.S_IRGRP | syscall.S_IXGRP | syscall.S_IROTH | syscall.S_IXOTH
+	filePermExecutable os.FileMode = 0755
 )
 
 // PlaceholderModulePath is a placeholder used in template files
@@ -33,6 +36,10 @@ const PlaceholderProjectName = "postservice"
 type FileSystem interface {
 	MkdirAll(path string, perm os.FileMode) error
 	WriteFile(name string, data []byte, perm os.FileMode) error
+	Stat(name string) (os.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	Rename(oldpath, newpath string) error
+	Remove(name string) error
 }
 
 // OSFileSystem implements FileSystem using the OS
@@ -46,6 +53,50 @@ func (f *OSFileSystem) WriteFile(name string, data []byte, perm os.FileMode) err
 	return os.WriteFile(name, data, perm)
 }
 
+func (f *OSFileSystem) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (f *OSFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (f *OSFileSystem) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+func (f *OSFileSystem) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// writeCheckFile is created and removed in the output directory to check it is writable
+const writeCheckFile = ".create-go-api-write-check"
+
+// checkWritable creates and removes a file in the output directory so permission problems
+// are reported before any generated file is written
+func (g *Generator) checkWritable() error {
+	name := filepath.Join(g.config.OutputDir, writeCheckFile)
+	if err := g.fs.WriteFile(name, nil, filePermRegular); err != nil {
+		return fmt.Errorf("output directory %s is not writable: %w", g.config.OutputDir, err)
+	}
+	if err := g.fs.Remove(name); err != nil {
+		return fmt.Errorf("output directory %s is not writable: %w", g.config.OutputDir, err)
+	}
+	return nil
+}
+
+// fileExists reports whether name exists in the given filesystem
+func fileExists(fsys FileSystem, name string) (bool, error) {
+	_, err := fsys.Stat(name)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
 // replaceModulePath replaces the placeholder module path with the actual module path
 func replaceModulePath(content, modulePath string) string {
 	// Replace the static module path used for type checking
@@ -67,18 +118,60 @@ func replaceProjectName(content, projectName string) string {
 
 // copyFile copies a static file and replaces placeholders
 func (g *Generator) copyFile(outputPath, sourcePath string) error {
+	content, err := g.readStaticFile(sourcePath)
+	if err != nil {
+		return err
+	}
+	return g.writeOutputFile(outputPath, content)
+}
+
+// copyMergedFile merges static Go files of one package into a single output file
+func (g *Generator) copyMergedFile(outputPath string, sourcePaths []string) error {
+	sources := make([][]byte, 0, len(sourcePaths))
+	for _, sourcePath := range sourcePaths {
+		content, err := g.readStaticFile(sourcePath)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, content)
+	}
+
+	content, err := mergeGoSources(sources)
+	if err != nil {
+		return fmt.Errorf("failed to merge %s: %w", outputPath, err)
+	}
+	return g.writeOutputFile(outputPath, content)
+}
+
+// metricsSectionPattern matches the metrics section of a stage config file
+var metricsSectionPattern = regexp.MustCompile(`(?m)\n^metrics:\n(?:  .*\n)*`)
+
+// readStaticFile reads a static file and replaces placeholders
+func (g *Generator) readStaticFile(sourcePath string) ([]byte, error) {
 	// Read from embedded filesystem
 	staticFS := GetStaticFS()
 	// sourcePath already includes "static/" prefix from project.go
 	content, err := staticFS.ReadFile(sourcePath)
 	if err != nil {
-		return fmt.Errorf("failed to read source file %s: %w", sourcePath, err)
+		return nil, fmt.Errorf("failed to read source file %s: %w", sourcePath, err)
 	}
 
 	// Replace placeholders
 	contentStr := string(content)
 	contentStr = replaceModulePath(contentStr, g.config.ModulePath)
 	contentStr = replaceProjectName(contentStr, g.config.ProjectName)
+	// The stage config files listen on DefaultPort
+	if strings.HasSuffix(sourcePath, ".yaml") {
+		contentStr = strings.ReplaceAll(contentStr, "port: '"+DefaultPort+"'", "port: '"+g.Port()+"'")
+		// Minimal projects have no metrics endpoint to configure
+		if !g.metrics() {
+			contentStr = metricsSectionPattern.ReplaceAllString(contentStr, "")
+		}
+	}
+	// Minimal projects have no mockery config for go generate to use
+	if g.config.Minimal {
+		contentStr = strings.ReplaceAll(contentStr, "//go:generate mockery\n\n", "")
+	}
 	
 	// Remove build tags from generated files (they're only needed in templates directory)
 	if strings.Contains(sourcePath, ".go") {
@@ -88,29 +181,8 @@ func (g *Generator) copyFile(outputPath, sourcePath string) error {
 		// Remove extra newlines
 		contentStr = strings.TrimPrefix(contentStr, "\n")
 	}
-	
-	content = []byte(contentStr)
-
-	// Create full output path
-	outputFullPath := filepath.Join(g.config.OutputDir, outputPath)
-
-	// Create directory if it doesn't exist
-	dir := filepath.Dir(outputFullPath)
-	if err := g.fs.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	// Set executable permissions for shell scripts
-	perm := os.FileMode(filePermRegular)
-	if strings.HasSuffix(outputPath, ".sh") {
-		perm = os.FileMode(filePermExecutable)
-	}
 
-	if err := g.fs.WriteFile(outputFullPath, content, perm); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
-
-	return nil
+	return []byte(contentStr), nil
 }
 
 // generateFile generates a file from a template and replaces placeholders
@@ -132,25 +204,80 @@ func (g *Generator) generateFile(outputPath, templatePath string, data interface
 	contentStr = replaceProjectName(contentStr, g.config.ProjectName)
 	content = []byte(contentStr)
 
+	return g.writeOutputFile(outputPath, content)
+}
+
+// writeOutputFile writes content to outputPath (relative to the output directory),
+// applying the generator's overwrite policy if a different file already exists
+func (g *Generator) writeOutputFile(outputPath string, content []byte) error {
+	outputPath, content, err := g.applyLayout(outputPath, content)
+	if err != nil {
+		return err
+	}
+
+	// Format Go files so template output is always gofmt-clean
+	if strings.HasSuffix(outputPath, ".go") {
+		formatted, err := format.Source(content)
+		if err != nil {
+			return fmt.Errorf("generated Go file %s is not valid Go source: %w", outputPath, err)
+		}
+		content = formatted
+	}
+
 	// Create full output path
 	outputFullPath := filepath.Join(g.config.OutputDir, outputPath)
 
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(outputFullPath)
-	if err := g.fs.MkdirAll(dir, 0755); err != nil {
+	if err := g.mkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
+	// Resolve conflicts with existing files
+	info, err := g.fs.Stat(outputFullPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to check existing file %s: %w", outputPath, err)
+	}
+	// What the file is restored to if generation fails
+	existed := err == nil
+	var existing []byte
+	var existingPerm os.FileMode
+	if existed {
+		// Files that already match the generated output aren't conflicts
+		existing, err = g.fs.ReadFile(outputFullPath)
+		if err != nil {
+			return fmt.Errorf("failed to read existing file %s: %w", outputPath, err)
+		}
+		existingPerm = info.Mode().Perm()
+		if bytes.Equal(existing, content) {
+			g.unchangedFiles = append(g.unchangedFiles, filepath.ToSlash(outputPath))
+			return nil
+		}
+
+		switch g.overwritePolicy {
+		case OverwritePolicySkip:
+			g.skippedFiles = append(g.skippedFiles, filepath.ToSlash(outputPath))
+			return nil
+		case OverwritePolicyBackup:
+			if err := g.fs.Rename(outputFullPath, outputFullPath+".bak"); err != nil {
+				return fmt.Errorf("failed to back up existing file %s: %w", outputPath, err)
+			}
+			g.recordRename(outputFullPath, outputFullPath+".bak")
+			g.backedUpFiles = append(g.backedUpFiles, filepath.ToSlash(outputPath))
+			existed = false
+		}
+	}
+
 	// Set executable permissions for shell scripts
-	perm := os.FileMode(filePermRegular)
+	perm := filePermRegular
 	if strings.HasSuffix(outputPath, ".sh") {
-		perm = os.FileMode(filePermExecutable)
+		perm = filePermExecutable
 	}
 
 	if err := g.fs.WriteFile(outputFullPath, content, perm); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
+	g.recordFileWrite(outputFullPath, existed, existing, existingPerm)
 
 	return nil
 }
-
@@ -0,0 +1,393 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProjectConfig() ProjectConfig {
+	return ProjectConfig{
+		ProjectName: "testservice",
+		ModulePath:  "github.com/test/testservice",
+		OutputDir:   "out",
+		Database:    DatabaseConfig{Type: DatabaseTypePostgres},
+		Framework:   FrameworkTypeChi,
+	}
+}
+
+func TestFileExists(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	osFS := &OSFileSystem{}
+	require.NoError(t, osFS.WriteFile(filepath.Join(tempDir, "existing.txt"), []byte("data"), filePermRegular))
+
+	memFS := NewMemFileSystem()
+	require.NoError(t, memFS.MkdirAll("project/internal", 0755))
+	require.NoError(t, memFS.WriteFile("project/existing.txt", []byte("data"), filePermRegular))
+
+	tests := []struct {
+		name     string
+		fs       FileSystem
+		path     string
+		expected bool
+	}{
+		{name: "os: existing file", fs: osFS, path: filepath.Join(tempDir, "existing.txt"), expected: true},
+		{name: "os: existing directory", fs: osFS, path: tempDir, expected: true},
+		{name: "os: missing file", fs: osFS, path: filepath.Join(tempDir, "missing.txt"), expected: false},
+		{name: "os: missing parent directory", fs: osFS, path: filepath.Join(tempDir, "missing", "file.txt"), expected: false},
+		{name: "mem: existing file", fs: memFS, path: "project/existing.txt", expected: true},
+		{name: "mem: existing directory", fs: memFS, path: "project/internal", expected: true},
+		{name: "mem: missing file", fs: memFS, path: "project/missing.txt", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists, err := fileExists(tt.fs, tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, exists)
+		})
+	}
+}
+
+// readOnlyFileSystem rejects every write, like a read-only mount
+type readOnlyFileSystem struct {
+	*MemFileSystem
+}
+
+func (f readOnlyFileSystem) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+}
+
+func TestGenerator_UnwritableOutputDir(t *testing.T) {
+	t.Parallel()
+
+	memFS := NewMemFileSystem()
+	err := NewGenerator(testProjectConfig(), WithFileSystem(readOnlyFileSystem{memFS})).Generate()
+	require.ErrorIs(t, err, fs.ErrPermission)
+	assert.ErrorContains(t, err, "output directory out is not writable")
+	assert.Empty(t, memFS.Dirs(), "no directories should be left behind")
+}
+
+// failingFileSystem fails the failAt'th write, like a disk filling up mid-generation
+type failingFileSystem struct {
+	FileSystem
+	failAt int
+	writes int
+}
+
+func (f *failingFileSystem) WriteFile(name string, data []byte, perm os.FileMode) error {
+	f.writes++
+	if f.writes == f.failAt {
+		return &fs.PathError{Op: "write", Path: name, Err: errors.New("disk full")}
+	}
+	return f.FileSystem.WriteFile(name, data, perm)
+}
+
+func TestGenerator_RollbackOnFailure(t *testing.T) {
+	t.Parallel()
+
+	makefilePath := filepath.Join("out", "Makefile")
+	userFile := filepath.Join("out", "notes.txt")
+
+	tests := []struct {
+		name   string
+		policy OverwritePolicy
+		setup  func(t *testing.T, memFS *MemFileSystem)
+		// Files expected afterwards, with their content
+		expected map[string]string
+	}{
+		{
+			name:     "new output directory is removed",
+			setup:    func(t *testing.T, memFS *MemFileSystem) {},
+			expected: map[string]string{},
+		},
+		{
+			name:   "existing user files are kept",
+			policy: OverwritePolicyOverwrite,
+			setup: func(t *testing.T, memFS *MemFileSystem) {
+				require.NoError(t, memFS.MkdirAll("out", 0755))
+				require.NoError(t, memFS.WriteFile(userFile, []byte("my notes"), filePermRegular))
+				require.NoError(t, memFS.WriteFile(makefilePath, []byte("my makefile"), filePermRegular))
+			},
+			expected: map[string]string{userFile: "my notes", makefilePath: "my makefile"},
+		},
+		{
+			name:   "backed up files are restored",
+			policy: OverwritePolicyBackup,
+			setup: func(t *testing.T, memFS *MemFileSystem) {
+				require.NoError(t, memFS.MkdirAll("out", 0755))
+				require.NoError(t, memFS.WriteFile(makefilePath, []byte("my makefile"), filePermRegular))
+			},
+			expected: map[string]string{makefilePath: "my makefile"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			memFS := NewMemFileSystem()
+			tt.setup(t, memFS)
+			preexistingDirs := memFS.Dirs()
+
+			// Fail well after the Makefile and some Go files have been written
+			failing := &failingFileSystem{FileSystem: memFS, failAt: 30}
+			err := NewGenerator(testProjectConfig(), WithFileSystem(failing), WithOverwritePolicy(tt.policy)).Generate()
+			require.ErrorContains(t, err, "disk full")
+			assert.NotContains(t, err.Error(), "roll back")
+
+			files := map[string]string{}
+			for _, path := range memFS.Paths() {
+				data, err := memFS.ReadFile(path)
+				require.NoError(t, err)
+				files[path] = string(data)
+			}
+			assert.Equal(t, tt.expected, files)
+			assert.Equal(t, preexistingDirs, memFS.Dirs())
+		})
+	}
+
+	t.Run("os", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.OutputDir = filepath.Join(t.TempDir(), "out")
+		failing := &failingFileSystem{FileSystem: &OSFileSystem{}, failAt: 30}
+		err := NewGenerator(cfg, WithFileSystem(failing)).Generate()
+		require.ErrorContains(t, err, "disk full")
+
+		_, err = os.Stat(cfg.OutputDir)
+		assert.ErrorIs(t, err, fs.ErrNotExist, "output directory should be removed")
+	})
+}
+
+// cancelingFileSystem cancels generation after the cancelAt'th write, like a user quitting mid-generation
+type cancelingFileSystem struct {
+	FileSystem
+	cancel   context.CancelFunc
+	cancelAt int
+	writes   int
+}
+
+func (f *cancelingFileSystem) WriteFile(name string, data []byte, perm os.FileMode) error {
+	f.writes++
+	if f.writes == f.cancelAt {
+		f.cancel()
+	}
+	return f.FileSystem.WriteFile(name, data, perm)
+}
+
+func TestGenerator_GenerateContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	memFS := NewMemFileSystem()
+	canceling := &cancelingFileSystem{FileSystem: memFS, cancel: cancel, cancelAt: 30}
+
+	err := NewGenerator(testProjectConfig(), WithFileSystem(canceling)).GenerateContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 30, canceling.writes, "generation should stop before the next file")
+	assert.Empty(t, memFS.Paths(), "written files should be rolled back")
+	assert.Empty(t, memFS.Dirs(), "created directories should be rolled back")
+}
+
+func TestGenerator_OverwritePolicy(t *testing.T) {
+	t.Parallel()
+
+	existing := []byte("existing content")
+	makefilePath := filepath.Join("out", "Makefile")
+
+	tests := []struct {
+		name            string
+		policy          OverwritePolicy
+		expectedSkipped []string
+		assertFS        func(t *testing.T, fs *MemFileSystem)
+	}{
+		{
+			name:            "skip leaves existing files untouched",
+			policy:          OverwritePolicySkip,
+			expectedSkipped: []string{"Makefile"},
+			assertFS: func(t *testing.T, fs *MemFileSystem) {
+				assert.Equal(t, existing, fs.files[makefilePath].data)
+				assert.NotContains(t, fs.files, makefilePath+".bak")
+			},
+		},
+		{
+			name:   "overwrite replaces existing files",
+			policy: OverwritePolicyOverwrite,
+			assertFS: func(t *testing.T, fs *MemFileSystem) {
+				assert.NotEqual(t, existing, fs.files[makefilePath].data)
+				assert.NotContains(t, fs.files, makefilePath+".bak")
+			},
+		},
+		{
+			name:   "backup renames existing files before writing",
+			policy: OverwritePolicyBackup,
+			assertFS: func(t *testing.T, fs *MemFileSystem) {
+				assert.NotEqual(t, existing, fs.files[makefilePath].data)
+				require.Contains(t, fs.files, makefilePath+".bak")
+				assert.Equal(t, existing, fs.files[makefilePath+".bak"].data)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGenerator(testProjectConfig(), WithDryRun(), WithOverwritePolicy(tt.policy))
+			require.NoError(t, gen.memFS.WriteFile(makefilePath, existing, filePermRegular))
+
+			require.NoError(t, gen.Generate())
+
+			assert.Equal(t, tt.expectedSkipped, gen.SkippedFiles())
+			tt.assertFS(t, gen.memFS)
+		})
+	}
+}
+
+func TestGenerator_ConflictDetection(t *testing.T) {
+	t.Parallel()
+
+	fs := NewMemFileSystem()
+	require.NoError(t, NewGenerator(testProjectConfig(), WithFileSystem(fs)).Generate())
+
+	makefilePath := filepath.Join("out", "Makefile")
+	edited := []byte("edited content")
+	require.NoError(t, fs.WriteFile(makefilePath, edited, filePermRegular))
+
+	// Regenerating with the default policy only skips files that differ
+	gen := NewGenerator(testProjectConfig(), WithFileSystem(fs))
+	require.NoError(t, gen.Generate())
+
+	assert.Equal(t, []string{"Makefile"}, gen.SkippedFiles())
+	assert.Equal(t, edited, fs.files[makefilePath].data)
+	assert.NotEmpty(t, gen.UnchangedFiles())
+	assert.NotContains(t, gen.UnchangedFiles(), "Makefile")
+	assert.Len(t, gen.UnchangedFiles(), len(fs.Paths())-1)
+}
+
+func TestGenerator_Only(t *testing.T) {
+	t.Parallel()
+
+	t.Run("regenerates only the selected files", func(t *testing.T) {
+		t.Parallel()
+
+		fs := NewMemFileSystem()
+		require.NoError(t, NewGenerator(testProjectConfig(), WithFileSystem(fs)).Generate())
+
+		edited := []byte("edited content")
+		makefilePath, readmePath := filepath.Join("out", "Makefile"), filepath.Join("out", "README.md")
+		require.NoError(t, fs.WriteFile(makefilePath, edited, filePermRegular))
+		require.NoError(t, fs.WriteFile(readmePath, edited, filePermRegular))
+
+		gen := NewGenerator(testProjectConfig(), WithFileSystem(fs), WithOnly("Makefile", ".github/"), WithOverwritePolicy(OverwritePolicyBackup))
+		require.NoError(t, gen.Generate())
+
+		assert.Equal(t, []string{"Makefile"}, gen.BackedUpFiles())
+		assert.Equal(t, []string{".github/workflows/ci.yml"}, gen.UnchangedFiles())
+		assert.Equal(t, edited, fs.files[makefilePath+".bak"].data)
+		assert.NotEqual(t, edited, fs.files[makefilePath].data)
+		assert.Equal(t, edited, fs.files[readmePath].data, "unselected files should be left alone")
+	})
+
+	t.Run("plans paths after the layout is applied", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Layout = LayoutTypeFlat
+		files, err := NewGenerator(cfg, WithOnly("internal/app/service.go", "internal/config")).Plan()
+		require.NoError(t, err)
+
+		var paths []string
+		for _, file := range files {
+			paths = append(paths, file.Path)
+		}
+		assert.Contains(t, paths, "internal/app/service.go")
+		assert.Contains(t, paths, "internal/config/config.go")
+		for _, path := range paths {
+			assert.True(t, path == "internal/app/service.go" || strings.HasPrefix(path, "internal/config/"), "unexpected path %s", path)
+		}
+	})
+
+	t.Run("path matching no generated file", func(t *testing.T) {
+		t.Parallel()
+
+		gen := NewGenerator(testProjectConfig(), WithDryRun(), WithOnly("Makefile", "fly.toml"))
+		assert.ErrorContains(t, gen.Generate(), "fly.toml matches no file generated for this configuration")
+	})
+}
+
+// stubTemplateLoader returns the same template source for every path
+type stubTemplateLoader struct {
+	source string
+}
+
+func (l stubTemplateLoader) LoadTemplate(path string) (*template.Template, error) {
+	return template.New(path).Parse(l.source)
+}
+
+func TestGenerator_GenerateFileFormatsGoSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		outputPath  string
+		source      string
+		expectedErr bool
+		expected    string
+	}{
+		{
+			name:       "misformatted Go output is gofmt-clean",
+			outputPath: "cmd/api/main.go",
+			source:     "package main\nimport (\n\"os\"\n  \"fmt\"\n)\nfunc  main( ) {\nfmt.Println( \"{{.ProjectName}}\" )\n   os.Exit(0)}\n",
+			expected:   "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc main() {\n\tfmt.Println(\"testservice\")\n\tos.Exit(0)\n}\n",
+		},
+		{
+			name:        "invalid Go output fails generation",
+			outputPath:  "cmd/api/main.go",
+			source:      "package main\nfunc main() {\n",
+			expectedErr: true,
+		},
+		{
+			name:       "non-Go output is written as rendered",
+			outputPath: "README.md",
+			source:     "#  {{.ProjectName}}\n",
+			expected:   "#  testservice\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGenerator(testProjectConfig(), WithDryRun())
+			gen.templateLoader = stubTemplateLoader{source: tt.source}
+
+			err := gen.generateFile(tt.outputPath, "stub.tmpl", gen.getTemplateData())
+
+			outputFullPath := filepath.Join("out", tt.outputPath)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.NotContains(t, gen.memFS.files, outputFullPath)
+				return
+			}
+			require.NoError(t, err)
+			written := gen.memFS.files[outputFullPath].data
+			assert.Equal(t, tt.expected, string(written))
+			if filepath.Ext(tt.outputPath) == ".go" {
+				formatted, err := format.Source(written)
+				require.NoError(t, err)
+				assert.Equal(t, string(formatted), string(written))
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// templateFuncs are the helper functions available to every template
+var templateFuncs = template.FuncMap{
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"title":     title,
+	"camel":     camel,
+	"snake":     snake,
+	"pluralize": pluralize,
+}
+
+// TemplateFuncs returns the helper functions available to templates, for custom
+// TemplateLoader implementations that want the same helpers
+func TemplateFuncs() template.FuncMap {
+	funcs := make(template.FuncMap, len(templateFuncs))
+	for name, fn := range templateFuncs {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+// splitWords splits s into words at spaces, '-', '_' and lower-to-upper case changes,
+// keeping acronyms together (e.g. "userID" -> [user ID], "HTTPServer" -> [HTTP Server])
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(s)
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case r == ' ' || r == '-' || r == '_':
+			flush()
+			continue
+		case unicode.IsUpper(r) && len(current) > 0:
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
+
+// capitalize upper-cases the first letter of word and lower-cases the rest.
+// Acronyms (all upper-case words such as ID) are kept as-is.
+func capitalize(word string) string {
+	if len(word) > 1 && word == strings.ToUpper(word) {
+		return word
+	}
+	runes := []rune(strings.ToLower(word))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// title converts s to space-separated title case (e.g. "post_service" -> "Post Service")
+func title(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = capitalize(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// camel converts s to lower camel case (e.g. "post_service" -> "postService")
+func camel(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+			continue
+		}
+		words[i] = capitalize(word)
+	}
+	return strings.Join(words, "")
+}
+
+// snake converts s to snake case (e.g. "PostService" -> "post_service")
+func snake(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
+
+// pluralize returns the English plural of a singular noun using the regular rules
+// (e.g. "post" -> "posts", "category" -> "categories", "box" -> "boxes")
+func pluralize(s string) string {
+	lower := strings.ToLower(s)
+	switch {
+	case s == "":
+		return s
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return s[:len(s)-1] + matchCase(s[len(s)-1:], "ies")
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return s + matchCase(s[len(s)-1:], "es")
+	default:
+		return s + matchCase(s[len(s)-1:], "s")
+	}
+}
+
+// matchCase returns suffix upper-cased if last is an upper-case letter, so
+// pluralizing "POST" gives "POSTS"
+func matchCase(last, suffix string) string {
+	if last != strings.ToLower(last) {
+		return strings.ToUpper(suffix)
+	}
+	return suffix
+}
+
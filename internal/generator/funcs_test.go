@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		input    string
+		expected string
+	}{
+		{name: "lower", template: "{{lower .}}", input: "PostService", expected: "postservice"},
+		{name: "upper", template: "{{upper .}}", input: "posts", expected: "POSTS"},
+		{name: "title from snake", template: "{{title .}}", input: "post_service", expected: "Post Service"},
+		{name: "title from kebab", template: "{{title .}}", input: "my-api", expected: "My Api"},
+		{name: "title keeps acronyms", template: "{{title .}}", input: "userID", expected: "User ID"},
+		{name: "camel from snake", template: "{{camel .}}", input: "post_service", expected: "postService"},
+		{name: "camel from pascal", template: "{{camel .}}", input: "PostService", expected: "postService"},
+		{name: "camel keeps acronyms", template: "{{camel .}}", input: "user_ID", expected: "userID"},
+		{name: "camel leading acronym", template: "{{camel .}}", input: "HTTPServer", expected: "httpServer"},
+		{name: "snake from pascal", template: "{{snake .}}", input: "PostService", expected: "post_service"},
+		{name: "snake from camel acronym", template: "{{snake .}}", input: "userID", expected: "user_id"},
+		{name: "snake from kebab", template: "{{snake .}}", input: "create-go-api", expected: "create_go_api"},
+		{name: "pluralize regular", template: "{{pluralize .}}", input: "post", expected: "posts"},
+		{name: "pluralize consonant y", template: "{{pluralize .}}", input: "category", expected: "categories"},
+		{name: "pluralize vowel y", template: "{{pluralize .}}", input: "key", expected: "keys"},
+		{name: "pluralize sibilant", template: "{{pluralize .}}", input: "box", expected: "boxes"},
+		{name: "pluralize ch", template: "{{pluralize .}}", input: "batch", expected: "batches"},
+		{name: "pluralize upper", template: "{{pluralize .}}", input: "Post", expected: "Posts"},
+		{name: "chained", template: "{{. | pluralize | snake}}", input: "BlogEntry", expected: "blog_entries"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpl, err := template.New(tt.name).Funcs(templateFuncs).Parse(tt.template)
+			require.NoError(t, err)
+
+			var out strings.Builder
+			require.NoError(t, tmpl.Execute(&out, tt.input))
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestDirTemplateLoader_Funcs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "templates"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "templates", "Makefile.tmpl"), []byte("{{.ProjectName | pluralize | upper}}"), 0644))
+
+	loader := NewDirTemplateLoader(dir, NewEmbeddedTemplateLoader())
+	tmpl, err := loader.LoadTemplate("templates/Makefile.tmpl")
+	require.NoError(t, err)
+
+	var out strings.Builder
+	require.NoError(t, tmpl.Execute(&out, map[string]string{"ProjectName": "post"}))
+	assert.Equal(t, "POSTS", out.String())
+}
+
@@ -1,68 +1,379 @@
 package generator
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
 type Generator struct {
 	config         ProjectConfig
 	fs             FileSystem
 	templateLoader TemplateLoader
+	memFS          *MemFileSystem // Set in dry-run mode
+
+	overwritePolicy OverwritePolicy
+	only            []string // Output paths to limit generation to (see WithOnly)
+	skippedFiles    []string
+	unchangedFiles  []string
+	backedUpFiles   []string
+	undo            []func() error  // Reverts this run's filesystem changes (see rollback)
+	createdDirs     map[string]bool // Directories this run created
+}
+
+// GeneratorOption configures optional Generator behavior
+type GeneratorOption func(*Generator)
+
+// WithDryRun renders all files in memory instead of writing them to disk.
+// The rendered files can be inspected with DryRunFiles after Generate.
+func WithDryRun() GeneratorOption {
+	return func(g *Generator) {
+		g.memFS = NewMemFileSystem()
+		g.fs = g.memFS
+	}
+}
+
+// WithFileSystem sets the filesystem generated files are written to
+// (e.g. a MemFileSystem to capture output in memory)
+func WithFileSystem(fs FileSystem) GeneratorOption {
+	return func(g *Generator) {
+		g.fs = fs
+	}
+}
+
+// WithOverwritePolicy sets how existing files that differ from the generated output are
+// handled (defaults to OverwritePolicySkip)
+func WithOverwritePolicy(policy OverwritePolicy) GeneratorOption {
+	return func(g *Generator) {
+		g.overwritePolicy = policy
+	}
+}
+
+// WithOnly limits generation to the files at or under paths, relative to the output
+// directory (e.g. "Makefile" or ".github"), to re-scaffold part of an existing project.
+// Generation fails if a path matches no generated file.
+func WithOnly(paths ...string) GeneratorOption {
+	return func(g *Generator) {
+		for _, p := range paths {
+			g.only = append(g.only, path.Clean(filepath.ToSlash(p)))
+		}
+	}
+}
+
+// WithTemplateDir prefers templates found in dir over the embedded ones, matched by
+// relative path (e.g. dir/templates/Makefile.tmpl overrides the Makefile template)
+func WithTemplateDir(dir string) GeneratorOption {
+	return func(g *Generator) {
+		g.templateLoader = NewDirTemplateLoader(dir, g.templateLoader)
+	}
+}
+
+// GeneratedFile describes a file produced during generation
+type GeneratedFile struct {
+	Path string // Relative to the output directory
+	Size int
+}
+
+// PlannedFile describes a file generation would produce, without rendering it
+type PlannedFile struct {
+	Path    string   `json:"path"`    // Relative to the output directory
+	Sources []string `json:"sources"` // Templates or static files the file is generated from
 }
 
 // NewGenerator creates a new generator with default dependencies
-func NewGenerator(config ProjectConfig) *Generator {
-	return &Generator{
-		config:         config,
-		fs:             &OSFileSystem{},
-		templateLoader: NewEmbeddedTemplateLoader(),
+func NewGenerator(config ProjectConfig, opts ...GeneratorOption) *Generator {
+	g := &Generator{
+		config:          config,
+		fs:              &OSFileSystem{},
+		templateLoader:  NewEmbeddedTemplateLoader(),
+		overwritePolicy: OverwritePolicySkip,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// SkippedFiles returns the existing files left untouched because they differ from the
+// generated output (only populated with OverwritePolicySkip)
+func (g *Generator) SkippedFiles() []string {
+	return g.skippedFiles
+}
+
+// BackedUpFiles returns the existing files renamed to .bak before being replaced
+// (only populated with OverwritePolicyBackup)
+func (g *Generator) BackedUpFiles() []string {
+	return g.backedUpFiles
+}
+
+// UnchangedFiles returns the existing files that already matched the generated output
+func (g *Generator) UnchangedFiles() []string {
+	return g.unchangedFiles
+}
+
+// DryRunFiles returns the files rendered by Generate in dry-run mode, sorted by path
+// Returns nil if the generator is not in dry-run mode
+func (g *Generator) DryRunFiles() []GeneratedFile {
+	if g.memFS == nil {
+		return nil
+	}
+
+	var files []GeneratedFile
+	for _, path := range g.memFS.Paths() {
+		relPath, err := filepath.Rel(g.config.OutputDir, path)
+		if err != nil {
+			relPath = path
+		}
+		files = append(files, GeneratedFile{
+			Path: filepath.ToSlash(relPath),
+			Size: len(g.memFS.files[path].data),
+		})
+	}
+	return files
+}
+
+// DryRunDirs returns the directories created by Generate in dry-run mode, relative to
+// the output directory and sorted by path (including directories that hold no files)
+// Returns nil if the generator is not in dry-run mode
+func (g *Generator) DryRunDirs() []string {
+	if g.memFS == nil {
+		return nil
+	}
+
+	var dirs []string
+	for _, path := range g.memFS.Dirs() {
+		relPath, err := filepath.Rel(g.config.OutputDir, path)
+		if err != nil || relPath == "." {
+			continue
+		}
+		dirs = append(dirs, filepath.ToSlash(relPath))
 	}
+	return dirs
 }
 
 // Generate generates the complete project structure
 func (g *Generator) Generate() error {
+	return g.GenerateContext(context.Background())
+}
+
+// GenerateContext generates the complete project structure, stopping before the next file
+// once ctx is done. A canceled generation is rolled back like a failed one and returns ctx.Err().
+func (g *Generator) GenerateContext(ctx context.Context) (err error) {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := g.validate(); err != nil {
+		return err
+	}
+
+	// Remove whatever this run created (and restore what it replaced) if it fails partway
+	g.undo, g.createdDirs = nil, nil
+	defer func() {
+		if err == nil {
+			return
+		}
+		if rollbackErr := g.rollback(); rollbackErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to roll back partial generation: %w", rollbackErr))
+		}
+	}()
+
 	// Create output directory
-	if err := g.fs.MkdirAll(g.config.OutputDir, 0755); err != nil {
+	if err := g.mkdirAll(g.config.OutputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
+	if err := g.checkWritable(); err != nil {
+		return err
+	}
 
-	// Create directory structure
-	if err := g.createDirectoryStructure(); err != nil {
-		return fmt.Errorf("failed to create directory structure: %w", err)
+	// Create directory structure (re-scaffolding selected files only creates their directories)
+	if len(g.only) == 0 {
+		if err := g.createDirectoryStructure(); err != nil {
+			return fmt.Errorf("failed to create directory structure: %w", err)
+		}
 	}
 
 	// Get all file generation rules based on project configuration
-	rules := g.getFileGenerationRules()
+	rules, err := g.selectedRules()
+	if err != nil {
+		return err
+	}
 	data := g.getTemplateData()
 
 	// Generate all files based on rules
 	for _, rule := range rules {
 		if rule.condition == nil || rule.condition(g) {
-			if err := g.generateFiles(rule.files, data); err != nil {
+			if err := g.generateFiles(ctx, rule.files, data); err != nil {
 				return err
 			}
+			for _, merged := range rule.merged {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				if err := g.copyMergedFile(merged.outputPath, merged.sourcePaths); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
 	return nil
 }
 
+// Plan resolves the file generation rules for the configuration and returns the files
+// Generate would produce, sorted by path. Nothing is rendered or written, so two plans
+// can be compared to see how configurations differ.
+func (g *Generator) Plan() ([]PlannedFile, error) {
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+
+	rules, err := g.selectedRules()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []PlannedFile
+	for _, rule := range rules {
+		if rule.condition != nil && !rule.condition(g) {
+			continue
+		}
+		for _, file := range rule.files {
+			files = append(files, PlannedFile{
+				Path:    g.layoutPath(file.outputPath),
+				Sources: []string{file.templatePath},
+			})
+		}
+		for _, merged := range rule.merged {
+			files = append(files, PlannedFile{
+				Path:    g.layoutPath(merged.outputPath),
+				Sources: append([]string(nil), merged.sourcePaths...),
+			})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
+
+// validate checks the configuration before anything is generated
+func (g *Generator) validate() error {
+	if err := ValidateProjectName(g.config.ProjectName); err != nil {
+		return err
+	}
+	if err := ValidateModulePath(g.config.ModulePath); err != nil {
+		return err
+	}
+	if err := g.validateArch(); err != nil {
+		return err
+	}
+	if err := g.validateAPIPrefix(); err != nil {
+		return err
+	}
+	if err := g.validateGRPC(); err != nil {
+		return err
+	}
+	if err := g.validateMinimal(); err != nil {
+		return err
+	}
+	if err := ValidatePort(g.config.Port); err != nil {
+		return err
+	}
+	if err := ValidateGoVersion(g.config.GoVersion); err != nil {
+		return err
+	}
+	return ValidateFlyRegion(g.config.FlyRegion)
+}
+
+// selectedRules returns the file generation rules, reduced to the files selected with WithOnly
+func (g *Generator) selectedRules() ([]fileGenerationRule, error) {
+	rules := g.getFileGenerationRules()
+	if len(g.only) == 0 {
+		return rules, nil
+	}
+
+	matched := make(map[string]bool)
+	selected := func(outputPath string) bool {
+		outputPath = g.layoutPath(outputPath)
+		for _, p := range g.only {
+			if outputPath == p || strings.HasPrefix(outputPath, p+"/") {
+				matched[p] = true
+				return true
+			}
+		}
+		return false
+	}
+
+	var selectedRules []fileGenerationRule
+	for _, rule := range rules {
+		if rule.condition != nil && !rule.condition(g) {
+			continue
+		}
+		var filtered fileGenerationRule
+		for _, file := range rule.files {
+			if selected(file.outputPath) {
+				filtered.files = append(filtered.files, file)
+			}
+		}
+		for _, merged := range rule.merged {
+			if selected(merged.outputPath) {
+				filtered.merged = append(filtered.merged, merged)
+			}
+		}
+		if len(filtered.files) > 0 || len(filtered.merged) > 0 {
+			selectedRules = append(selectedRules, filtered)
+		}
+	}
+
+	for _, p := range g.only {
+		if !matched[p] {
+			return nil, fmt.Errorf("%s matches no file generated for this configuration", p)
+		}
+	}
+	return selectedRules, nil
+}
+
 // createDirectoryStructure creates the necessary directory structure
 func (g *Generator) createDirectoryStructure() error {
 	dirs := []string{
 		"cmd/api",
 		"internal/config",
-		"internal/database",
-		"internal/posts",
-		"internal/metrics",
+		"internal/health",
+		"internal/loadshed",
+		g.packageDir("internal/database"),
+		g.packageDir("internal/posts"),
+	}
+
+	// Add the metrics directory unless generating a minimal project
+	if g.metrics() {
+		dirs = append(dirs, "internal/metrics")
 	}
 
 	// Add framework-specific directories
-	if g.config.Framework == FrameworkTypeConnectRPC {
+	if g.hasConnectRPC() {
 		dirs = append(dirs, "internal/protos/posts/v1", "internal/protos/gen/posts/v1")
 	}
 
+	// Add the api package for the structured request logging middleware
+	if g.config.Framework == FrameworkTypeChi || g.config.Framework == FrameworkTypeConnectRPC {
+		dirs = append(dirs, g.packageDir("internal/api"))
+	}
+
+	// Add auth directory if JWT authentication is enabled
+	if g.config.Auth {
+		dirs = append(dirs, "internal/auth")
+	}
+
+	// Add client directory if generating the REST client
+	if g.restClient() {
+		dirs = append(dirs, "client")
+	}
+
 	// Add migrations directory if using PostgreSQL
 	if g.config.Database.Type == DatabaseTypePostgres {
 		dirs = append(dirs, "migrations")
@@ -71,14 +382,14 @@ func (g *Generator) createDirectoryStructure() error {
 	// Add scripts directory
 	dirs = append(dirs, "scripts")
 
-	// Add terraform directory if using DynamoDB
-	if g.config.Database.Type == DatabaseTypeDynamoDB {
+	// Add terraform directory if the DynamoDB table is Terraform-managed or deploying to ECS
+	if g.terraformManagedTable() || g.hasDeployTarget(DeployTargetECS) {
 		dirs = append(dirs, "terraform")
 	}
 
 	for _, dir := range dirs {
 		path := filepath.Join(g.config.OutputDir, dir)
-		if err := g.fs.MkdirAll(path, 0755); err != nil {
+		if err := g.mkdirAll(path, 0755); err != nil {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
 	}
@@ -0,0 +1,2038 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerator_GenerateContent(t *testing.T) {
+	t.Parallel()
+
+	cfg := testProjectConfig()
+	memFS, paths := generateInMemory(t, cfg)
+
+	expectedPaths := []string{
+		".dockerignore",
+		".env",
+		".env.example",
+		".env.local",
+		".github/workflows/ci.yml",
+		".gitignore",
+		".mockery.yaml",
+		"Makefile",
+		"README.md",
+		"atlas.hcl",
+		"cmd/api/main.go",
+		"cmd/seed/main.go",
+		"docker-compose.yml",
+		"go.mod",
+		"grafana/dashboards/service.json",
+		"grafana/provisioning/dashboards/dashboard.yml",
+		"grafana/provisioning/datasources/prometheus.yml",
+		"internal/api/body_limit.go",
+		"internal/api/body_limit_test.go",
+		"internal/api/logging.go",
+		"internal/api/logging_test.go",
+		"internal/config/config.go",
+		"internal/config/config_test.go",
+		"internal/config/local.yaml",
+		"internal/config/production.yaml",
+		"internal/config/stage.go",
+		"internal/config/staging.yaml",
+		"internal/database/postgres.go",
+		"internal/database/postgres_test.go",
+		"internal/health/readiness.go",
+		"internal/health/readiness_test.go",
+		"internal/loadshed/loadshed.go",
+		"internal/loadshed/loadshed_test.go",
+		"internal/metrics/metrics.go",
+		"internal/metrics/middleware.go",
+		"internal/metrics/middleware_test.go",
+		"internal/posts/batch.go",
+		"internal/posts/batch_test.go",
+		"internal/posts/caching.go",
+		"internal/posts/caching_test.go",
+		"internal/posts/errors.go",
+		"internal/posts/idempotency.go",
+		"internal/posts/identity.go",
+		"internal/posts/identity_test.go",
+		"internal/posts/observed_table.go",
+		"internal/posts/observed_table_test.go",
+		"internal/posts/post.go",
+		"internal/posts/post_table_mock_test.go",
+		"internal/posts/postgres_table.go",
+		"internal/posts/postgres_table_test.go",
+		"internal/posts/routes.go",
+		"internal/posts/routes_test.go",
+		"internal/posts/service.go",
+		"internal/posts/service_test.go",
+		"internal/posts/table.go",
+		"internal/posts/validation.go",
+		"internal/ratelimit/memory.go",
+		"internal/ratelimit/ratelimit.go",
+		"internal/ratelimit/ratelimit_test.go",
+		"internal/ratelimit/redis.go",
+		"internal/ratelimit/redis_test.go",
+		"migrations/0001_create_posts.sql",
+		"migrations/README.md",
+		"openapi.yaml",
+		"prometheus.yml",
+		"schema.sql",
+		"scripts/check-deps.sh",
+		"scripts/generate.sh",
+		"scripts/migrate.sh",
+		"scripts/test-integration.sh",
+	}
+	assert.Equal(t, expectedPaths, paths)
+
+	readFile := func(path string) string {
+		data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	assert.True(t, strings.HasPrefix(readFile("go.mod"), "module "+cfg.ModulePath+"\n"))
+	assert.Contains(t, readFile("cmd/api/main.go"), `"`+cfg.ModulePath+`/internal/posts"`)
+	assert.NotContains(t, readFile("internal/posts/service_test.go"), "//go:build ignore")
+	assert.True(t, strings.HasPrefix(readFile("internal/posts/postgres_table_test.go"), "//go:build integration\n"), "container tests should only run with -tags integration")
+
+	for _, path := range expectedPaths {
+		content := readFile(path)
+		assert.NotContains(t, content, PlaceholderModulePath, "placeholder module path left in %s", path)
+		assert.NotContains(t, content, StaticModulePath, "static module path left in %s", path)
+		assert.NotContains(t, content, PlaceholderProjectName, "placeholder project name left in %s", path)
+	}
+
+	info, err := memFS.Stat(filepath.Join(cfg.OutputDir, "scripts/migrate.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, "-rwxr-xr-x", info.Mode().String())
+}
+
+// generateInMemory runs a full Generate against a MemFileSystem and returns
+// the filesystem along with the sorted output paths relative to the output directory
+func generateInMemory(t *testing.T, cfg ProjectConfig) (*MemFileSystem, []string) {
+	t.Helper()
+
+	memFS := NewMemFileSystem()
+	gen := NewGenerator(cfg, WithFileSystem(memFS))
+	require.NoError(t, gen.Generate())
+
+	var paths []string
+	for _, path := range memFS.Paths() {
+		relPath, err := filepath.Rel(cfg.OutputDir, path)
+		require.NoError(t, err)
+		paths = append(paths, filepath.ToSlash(relPath))
+	}
+	return memFS, paths
+}
+
+func TestGenerator_FileGenerationRules(t *testing.T) {
+	t.Parallel()
+
+	baseFiles := []string{
+		".dockerignore",
+		".env",
+		".env.example",
+		".env.local",
+		".github/workflows/ci.yml",
+		".gitignore",
+		".mockery.yaml",
+		"Makefile",
+		"README.md",
+		"cmd/api/main.go",
+		"cmd/seed/main.go",
+		"docker-compose.yml",
+		"go.mod",
+		"grafana/dashboards/service.json",
+		"grafana/provisioning/dashboards/dashboard.yml",
+		"grafana/provisioning/datasources/prometheus.yml",
+		"internal/config/config.go",
+		"internal/config/config_test.go",
+		"internal/config/local.yaml",
+		"internal/config/production.yaml",
+		"internal/config/stage.go",
+		"internal/config/staging.yaml",
+		"internal/health/readiness.go",
+		"internal/health/readiness_test.go",
+		"internal/loadshed/loadshed.go",
+		"internal/loadshed/loadshed_test.go",
+		"internal/metrics/metrics.go",
+		"internal/posts/batch.go",
+		"internal/posts/batch_test.go",
+		"internal/posts/errors.go",
+		"internal/posts/idempotency.go",
+		"internal/posts/identity.go",
+		"internal/posts/identity_test.go",
+		"internal/posts/observed_table.go",
+		"internal/posts/observed_table_test.go",
+		"internal/posts/post.go",
+		"internal/posts/post_table_mock_test.go",
+		"internal/posts/service.go",
+		"internal/posts/service_test.go",
+		"internal/posts/table.go",
+		"prometheus.yml",
+		"scripts/check-deps.sh",
+		"scripts/generate.sh",
+		"scripts/migrate.sh",
+		"scripts/test-integration.sh",
+	}
+	databaseFiles := map[DatabaseType][]string{
+		DatabaseTypePostgres: {
+			"internal/database/postgres.go",
+			"internal/database/postgres_test.go",
+			"internal/posts/postgres_table.go",
+			"internal/posts/postgres_table_test.go",
+			"schema.sql",
+			"atlas.hcl",
+			"migrations/0001_create_posts.sql",
+			"migrations/README.md",
+		},
+		DatabaseTypeDynamoDB: {
+			"internal/database/dynamodb.go",
+			"internal/database/dynamodb_test.go",
+			"internal/posts/dynamodb_consistency_test.go",
+			"internal/posts/dynamodb_converters.go",
+			"internal/posts/dynamodb_table.go",
+			"internal/posts/dynamodb_table_test.go",
+			"terraform/dynamodb.tf",
+			"terraform/main.tf",
+		},
+	}
+	frameworkFiles := map[FrameworkType][]string{
+		FrameworkTypeChi: {
+			"internal/api/body_limit.go",
+			"internal/api/body_limit_test.go",
+			"internal/api/logging.go",
+			"internal/api/logging_test.go",
+			"internal/metrics/middleware.go",
+			"internal/metrics/middleware_test.go",
+			"internal/posts/caching.go",
+			"internal/posts/caching_test.go",
+			"internal/posts/routes.go",
+			"internal/posts/routes_test.go",
+			"internal/posts/validation.go",
+			"internal/ratelimit/memory.go",
+			"internal/ratelimit/ratelimit.go",
+			"internal/ratelimit/ratelimit_test.go",
+			"internal/ratelimit/redis.go",
+			"internal/ratelimit/redis_test.go",
+			"openapi.yaml",
+		},
+		FrameworkTypeGin: {
+			"internal/metrics/middleware.go",
+			"internal/posts/caching.go",
+			"internal/posts/caching_test.go",
+			"internal/posts/routes.go",
+			"internal/posts/validation.go",
+			"openapi.yaml",
+		},
+		FrameworkTypeEcho: {
+			"internal/metrics/middleware.go",
+			"internal/posts/caching.go",
+			"internal/posts/caching_test.go",
+			"internal/posts/routes.go",
+			"internal/posts/validation.go",
+			"openapi.yaml",
+		},
+		FrameworkTypeConnectRPC: {
+			"buf.gen.yaml",
+			"buf.yaml",
+			"internal/api/logging.go",
+			"internal/api/logging_test.go",
+			"internal/api/posts_handler.go",
+			"internal/api/timeout.go",
+			"internal/loadshed/interceptor.go",
+			"internal/metrics/middleware.go",
+			"internal/posts/converters.go",
+			"internal/protos/posts/v1/posts.proto",
+			"internal/ratelimit/interceptor.go",
+			"internal/ratelimit/memory.go",
+			"internal/ratelimit/ratelimit.go",
+			"internal/ratelimit/ratelimit_test.go",
+			"internal/ratelimit/redis.go",
+			"internal/ratelimit/redis_test.go",
+		},
+	}
+	deployFiles := append([]string{"Dockerfile"}, flyDeployFiles...)
+
+	for _, db := range []DatabaseType{DatabaseTypePostgres, DatabaseTypeDynamoDB} {
+		for _, fw := range []FrameworkType{FrameworkTypeChi, FrameworkTypeGin, FrameworkTypeEcho, FrameworkTypeConnectRPC} {
+			for _, deploy := range []bool{false, true} {
+				t.Run(fmt.Sprintf("%s_%s_deploy=%t", db, fw, deploy), func(t *testing.T) {
+					t.Parallel()
+
+					cfg := testProjectConfig()
+					cfg.Database.Type = db
+					cfg.Framework = fw
+					cfg.Deploy = deploy
+
+					var expected []string
+					expected = append(expected, baseFiles...)
+					expected = append(expected, databaseFiles[db]...)
+					expected = append(expected, frameworkFiles[fw]...)
+					if deploy {
+						expected = append(expected, deployFiles...)
+					}
+					sort.Strings(expected)
+
+					_, paths := generateInMemory(t, cfg)
+					assert.Equal(t, expected, paths)
+				})
+			}
+		}
+	}
+}
+var flyDeployFiles = []string{
+	".github/workflows/deploy.yml",
+	"fly.toml",
+	"scripts/deploy.sh",
+	"scripts/destroy.sh",
+	"scripts/smoke.sh",
+}
+
+var kubernetesDeployFiles = []string{
+	"k8s/configmap.yaml",
+	"k8s/deployment.yaml",
+	"k8s/ingress.yaml",
+	"k8s/service.yaml",
+}
+
+var ecsDeployFiles = []string{
+	"scripts/deploy-ecs.sh",
+	"scripts/destroy-ecs.sh",
+	"terraform/alb.tf",
+	"terraform/ecr.tf",
+	"terraform/ecs.tf",
+	"terraform/iam.tf",
+	"terraform/main.tf",
+	"terraform/outputs.tf",
+}
+
+var railwayDeployFiles = []string{"railway.json"}
+
+var renderDeployFiles = []string{"render.yaml"}
+
+func TestGenerator_DeployTargets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		database      DatabaseType
+		deploy        bool
+		targets       []DeployTarget
+		expectedFiles []string
+	}{
+		{
+			name:          "defaults to fly",
+			deploy:        true,
+			expectedFiles: flyDeployFiles,
+		},
+		{
+			name:          "kubernetes only",
+			deploy:        true,
+			targets:       []DeployTarget{DeployTargetKubernetes},
+			expectedFiles: kubernetesDeployFiles,
+		},
+		{
+			name:          "fly alongside kubernetes",
+			deploy:        true,
+			targets:       []DeployTarget{DeployTargetFly, DeployTargetKubernetes},
+			expectedFiles: append(append([]string{}, flyDeployFiles...), kubernetesDeployFiles...),
+		},
+		{
+			name:          "ecs with postgres",
+			deploy:        true,
+			targets:       []DeployTarget{DeployTargetECS},
+			expectedFiles: ecsDeployFiles,
+		},
+		{
+			name:          "ecs with dynamodb manages the table",
+			database:      DatabaseTypeDynamoDB,
+			deploy:        true,
+			targets:       []DeployTarget{DeployTargetECS},
+			expectedFiles: append([]string{"terraform/dynamodb.tf"}, ecsDeployFiles...),
+		},
+		{
+			name:          "railway",
+			deploy:        true,
+			targets:       []DeployTarget{DeployTargetRailway},
+			expectedFiles: railwayDeployFiles,
+		},
+		{
+			name:          "render",
+			deploy:        true,
+			targets:       []DeployTarget{DeployTargetRender},
+			expectedFiles: renderDeployFiles,
+		},
+		{
+			name:    "targets ignored without deploy",
+			targets: []DeployTarget{DeployTargetKubernetes},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			if tt.database != "" {
+				cfg.Database.Type = tt.database
+			}
+			cfg.Deploy = tt.deploy
+			cfg.DeployTargets = tt.targets
+			memFS, paths := generateInMemory(t, cfg)
+
+			var generated []string
+			for _, path := range paths {
+				if path == "Dockerfile" || isDeployTargetFile(path) {
+					generated = append(generated, path)
+				}
+			}
+
+			var expected []string
+			if tt.deploy {
+				expected = append([]string{"Dockerfile"}, tt.expectedFiles...)
+			}
+			sort.Strings(expected)
+			assert.Equal(t, expected, generated)
+
+			if slices.Contains(tt.expectedFiles, ".github/workflows/deploy.yml") {
+				workflow, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, ".github/workflows/deploy.yml"))
+				require.NoError(t, err)
+				assert.Contains(t, string(workflow), "needs: deploy")
+				assert.Contains(t, string(workflow), `bash scripts/smoke.sh "https://testservice.fly.dev"`)
+			}
+
+			for _, path := range generated {
+				if !strings.HasPrefix(path, "k8s/") {
+					continue
+				}
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+
+				var manifest map[string]interface{}
+				require.NoError(t, yaml.Unmarshal(data, &manifest), "invalid YAML in %s", path)
+				assert.NotEmpty(t, manifest["kind"], "missing kind in %s", path)
+				assert.Contains(t, string(data), cfg.ProjectName)
+			}
+		})
+	}
+}
+
+func TestGenerator_RenderBlueprint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		database        DatabaseType
+		auth            bool
+		expectedSecrets []string
+	}{
+		{name: "postgres", database: DatabaseTypePostgres, expectedSecrets: []string{"DATABASE_URL", "POSTHOG_API_KEY"}},
+		{name: "dynamodb with auth", database: DatabaseTypeDynamoDB, auth: true, expectedSecrets: []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_ROLE_ARN", "JWT_SECRET", "POSTHOG_API_KEY"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Database.Type = tt.database
+			cfg.Auth = tt.auth
+			cfg.Deploy = true
+			cfg.DeployTargets = []DeployTarget{DeployTargetRender}
+			memFS, _ := generateInMemory(t, cfg)
+
+			data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "render.yaml"))
+			require.NoError(t, err)
+
+			var blueprint struct {
+				Services []struct {
+					Type            string `yaml:"type"`
+					Name            string `yaml:"name"`
+					Runtime         string `yaml:"runtime"`
+					HealthCheckPath string `yaml:"healthCheckPath"`
+					EnvVars         []struct {
+						Key   string `yaml:"key"`
+						Value string `yaml:"value"`
+					} `yaml:"envVars"`
+				} `yaml:"services"`
+			}
+			require.NoError(t, yaml.Unmarshal(data, &blueprint))
+			require.Len(t, blueprint.Services, 1)
+			service := blueprint.Services[0]
+			assert.Equal(t, "web", service.Type)
+			assert.Equal(t, "testservice", service.Name)
+			assert.Equal(t, "docker", service.Runtime)
+			assert.Equal(t, "/health", service.HealthCheckPath)
+
+			env := make(map[string]string)
+			var keys []string
+			for _, envVar := range service.EnvVars {
+				env[envVar.Key] = envVar.Value
+				keys = append(keys, envVar.Key)
+			}
+			assert.Equal(t, "production", env["STAGE"])
+			assert.Equal(t, "8080", env["PORT"])
+			assert.Equal(t, append([]string{"STAGE", "PORT"}, tt.expectedSecrets...), keys)
+		})
+	}
+}
+
+func TestGenerator_HealthPath(t *testing.T) {
+	t.Parallel()
+
+	for _, framework := range []FrameworkType{FrameworkTypeChi, FrameworkTypeGin, FrameworkTypeEcho, FrameworkTypeConnectRPC} {
+		t.Run(string(framework), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = framework
+			cfg.Deploy = true
+			cfg.DeployTargets = []DeployTarget{DeployTargetFly, DeployTargetKubernetes, DeployTargetECS}
+			memFS, _ := generateInMemory(t, cfg)
+
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			// The server registers the configured path, and every platform probes the path the config sets
+			var serverConfig struct {
+				Server struct {
+					HealthPath string `yaml:"health_path"`
+					ReadyPath  string `yaml:"ready_path"`
+				} `yaml:"server"`
+			}
+			require.NoError(t, yaml.Unmarshal([]byte(readFile("internal/config/production.yaml")), &serverConfig))
+			healthPath, readyPath := serverConfig.Server.HealthPath, serverConfig.Server.ReadyPath
+			require.Equal(t, "/health", healthPath)
+			require.Equal(t, "/ready", readyPath)
+
+			mainGo := readFile("cmd/api/main.go")
+			assert.Contains(t, mainGo, "cfg.Server.HealthPath")
+			assert.Contains(t, mainGo, "cfg.Server.ReadyPath")
+			assert.Contains(t, readFile("fly.toml"), fmt.Sprintf("path = %q", healthPath))
+			assert.Contains(t, readFile("k8s/deployment.yaml"), "livenessProbe:\n            httpGet:\n              path: "+healthPath)
+			assert.Contains(t, readFile("k8s/deployment.yaml"), "readinessProbe:\n            httpGet:\n              path: "+readyPath)
+			assert.Contains(t, readFile("terraform/alb.tf"), fmt.Sprintf("%q", readyPath))
+
+			// Readiness flips before the server stops accepting connections
+			assert.Less(t, strings.Index(mainGo, "readiness.Drain("), strings.Index(mainGo, "srv.Shutdown("))
+		})
+	}
+}
+
+func TestGenerator_Port(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		port     string
+		auth     bool
+		expected string
+	}{
+		{name: "default", expected: DefaultPort},
+		{name: "custom", port: "8081", expected: "8081"},
+		{name: "custom with auth config", port: "9000", auth: true, expected: "9000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Port = tt.port
+			cfg.Auth = tt.auth
+			cfg.Deploy = true
+			cfg.DeployTargets = []DeployTarget{DeployTargetFly, DeployTargetKubernetes, DeployTargetRender}
+			memFS, _ := generateInMemory(t, cfg)
+
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			for _, stage := range []string{"local", "staging", "production"} {
+				var serverConfig struct {
+					Server struct {
+						Port string `yaml:"port"`
+					} `yaml:"server"`
+				}
+				require.NoError(t, yaml.Unmarshal([]byte(readFile("internal/config/"+stage+".yaml")), &serverConfig))
+				assert.Equal(t, tt.expected, serverConfig.Server.Port, "%s.yaml", stage)
+			}
+			assert.Contains(t, readFile("Dockerfile"), "EXPOSE "+tt.expected)
+			assert.Contains(t, readFile("fly.toml"), "internal_port = "+tt.expected)
+			assert.Contains(t, readFile("k8s/deployment.yaml"), "containerPort: "+tt.expected)
+			assert.Contains(t, readFile("render.yaml"), fmt.Sprintf("value: %q", tt.expected))
+			assert.Contains(t, readFile("README.md"), "localhost:"+tt.expected)
+			assert.Contains(t, readFile("cmd/api/main.go"), "syscall.EADDRINUSE")
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Port = "80a"
+		_, err := NewGenerator(cfg).Plan()
+		assert.ErrorContains(t, err, "invalid port")
+	})
+}
+
+func TestGenerator_MetricsScrapeTarget(t *testing.T) {
+	t.Parallel()
+
+	for _, framework := range []FrameworkType{FrameworkTypeChi, FrameworkTypeGin, FrameworkTypeEcho, FrameworkTypeConnectRPC} {
+		t.Run(string(framework), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = framework
+			memFS, _ := generateInMemory(t, cfg)
+
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			// Prometheus scrapes the path and port the local config serves metrics on
+			var localConfig struct {
+				Server struct {
+					Port string `yaml:"port"`
+				} `yaml:"server"`
+				Metrics struct {
+					Enabled bool   `yaml:"enabled"`
+					Path    string `yaml:"path"`
+				} `yaml:"metrics"`
+			}
+			require.NoError(t, yaml.Unmarshal([]byte(readFile("internal/config/local.yaml")), &localConfig))
+			require.True(t, localConfig.Metrics.Enabled)
+
+			var prometheusConfig struct {
+				ScrapeConfigs []struct {
+					MetricsPath   string `yaml:"metrics_path"`
+					StaticConfigs []struct {
+						Targets []string `yaml:"targets"`
+					} `yaml:"static_configs"`
+				} `yaml:"scrape_configs"`
+			}
+			require.NoError(t, yaml.Unmarshal([]byte(readFile("prometheus.yml")), &prometheusConfig))
+			require.Len(t, prometheusConfig.ScrapeConfigs, 1)
+			scrape := prometheusConfig.ScrapeConfigs[0]
+			assert.Equal(t, localConfig.Metrics.Path, scrape.MetricsPath)
+			require.Len(t, scrape.StaticConfigs, 1)
+			assert.Equal(t, []string{"host.docker.internal:" + localConfig.Server.Port}, scrape.StaticConfigs[0].Targets)
+
+			mainGo := readFile("cmd/api/main.go")
+			assert.Contains(t, mainGo, "metrics.New()")
+			assert.Contains(t, mainGo, "cfg.Metrics.Path")
+		})
+	}
+}
+
+func TestGenerator_DynamoDBTableProvisioning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		provisioning     TableProvisioning
+		expectTerraform  bool
+		expectedCreation string
+	}{
+		{
+			name:             "defaults to terraform",
+			expectTerraform:  true,
+			expectedCreation: "if cfg.Secrets.EndpointURL != \"\" {\n\t\tif err := posts.CreatePostTableIfNotExists(ctx, dynamoClient, cfg.Secrets.TableName)",
+		},
+		{
+			name:             "terraform",
+			provisioning:     TableProvisioningTerraform,
+			expectTerraform:  true,
+			expectedCreation: "if cfg.Secrets.EndpointURL != \"\" {\n\t\tif err := posts.CreatePostTableIfNotExists(ctx, dynamoClient, cfg.Secrets.TableName)",
+		},
+		{
+			name:             "runtime",
+			provisioning:     TableProvisioningRuntime,
+			expectedCreation: "\n\tif err := posts.CreatePostTableIfNotExists(ctx, dynamoClient, cfg.Secrets.TableName)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Database.Type = DatabaseTypeDynamoDB
+			cfg.Database.TableProvisioning = tt.provisioning
+			memFS, paths := generateInMemory(t, cfg)
+
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			if tt.expectTerraform {
+				assert.Contains(t, paths, "terraform/dynamodb.tf")
+				assert.Contains(t, paths, "terraform/main.tf")
+				assert.Contains(t, readFile("Makefile"), "table-apply:")
+				assert.NotContains(t, readFile("terraform/main.tf"), "aws_vpc", "ECS resources should only be generated for the ECS target")
+			} else {
+				assert.NotContains(t, paths, "terraform/dynamodb.tf")
+				assert.NotContains(t, paths, "terraform/main.tf")
+				assert.NotContains(t, readFile("Makefile"), "table-apply")
+			}
+			assert.Contains(t, readFile("cmd/api/main.go"), tt.expectedCreation)
+		})
+	}
+}
+
+func TestGenerator_PostgresIndexes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		indexes  []string
+		expected []string
+	}{
+		{
+			name: "list posts index by default",
+			expected: []string{
+				"CREATE INDEX IF NOT EXISTS idx_posts_user_id_created_at ON posts(user_id, created_at DESC);",
+			},
+		},
+		{
+			name:    "additional indexes",
+			indexes: []string{"title", " user_id ,updated_at desc"},
+			expected: []string{
+				"CREATE INDEX IF NOT EXISTS idx_posts_user_id_created_at ON posts(user_id, created_at DESC);",
+				"CREATE INDEX IF NOT EXISTS idx_posts_title ON posts(title);",
+				"CREATE INDEX IF NOT EXISTS idx_posts_user_id_updated_at ON posts(user_id, updated_at DESC);",
+			},
+		},
+		{
+			name:    "duplicate of the list posts index",
+			indexes: []string{"user_id, created_at DESC"},
+			expected: []string{
+				"CREATE INDEX IF NOT EXISTS idx_posts_user_id_created_at ON posts(user_id, created_at DESC);",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Database.Indexes = tt.indexes
+			memFS, _ := generateInMemory(t, cfg)
+
+			indexStatements := func(path string) []string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+
+				var statements []string
+				for _, line := range strings.Split(string(data), "\n") {
+					if strings.HasPrefix(line, "CREATE INDEX") {
+						statements = append(statements, line)
+					}
+				}
+				return statements
+			}
+			assert.Equal(t, tt.expected, indexStatements("schema.sql"))
+
+			// The initial migration creates the same indexes, without IF NOT EXISTS
+			var migrationExpected []string
+			for _, statement := range tt.expected {
+				migrationExpected = append(migrationExpected, strings.Replace(statement, "IF NOT EXISTS ", "", 1))
+			}
+			assert.Equal(t, migrationExpected, indexStatements("migrations/0001_create_posts.sql"))
+		})
+	}
+}
+
+func TestGenerator_AtlasMigrations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("postgres", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		memFS, paths := generateInMemory(t, cfg)
+		readFile := func(path string) string {
+			data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+			require.NoError(t, err)
+			return string(data)
+		}
+
+		assert.Contains(t, paths, "atlas.hcl")
+		assert.Contains(t, paths, "migrations/0001_create_posts.sql")
+
+		atlasConfig := readFile("atlas.hcl")
+		assert.Contains(t, atlasConfig, `src = "file://schema.sql"`)
+		assert.Contains(t, atlasConfig, `dir = "file://migrations"`)
+		assert.Contains(t, atlasConfig, "localhost:5432/"+cfg.ProjectName+"?sslmode=disable")
+		assert.Contains(t, readFile("migrations/0001_create_posts.sql"), "CREATE TABLE posts (")
+		assert.Contains(t, readFile("scripts/migrate.sh"), "atlas migrate apply --env local")
+		assert.Contains(t, readFile("scripts/check-deps.sh"), "command -v atlas")
+	})
+
+	t.Run("dynamodb", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Database.Type = DatabaseTypeDynamoDB
+		_, paths := generateInMemory(t, cfg)
+
+		assert.NotContains(t, paths, "atlas.hcl")
+		for _, path := range paths {
+			assert.False(t, strings.HasPrefix(path, "migrations/"), "unexpected migration %s", path)
+		}
+	})
+}
+
+// isDeployTargetFile reports whether path belongs to any deploy target's file set
+func isDeployTargetFile(path string) bool {
+	for _, files := range [][]string{flyDeployFiles, kubernetesDeployFiles, ecsDeployFiles, railwayDeployFiles, renderDeployFiles, {"terraform/dynamodb.tf"}} {
+		for _, file := range files {
+			if path == file {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestGenerator_Arch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		arch              Arch
+		expectedGOARCH    string
+		expectedPlatforms string
+		expectQEMU        bool
+		expectNodeArch    string
+	}{
+		{
+			name:              "amd64",
+			arch:              ArchAMD64,
+			expectedGOARCH:    "GOARCH=amd64",
+			expectedPlatforms: "linux/amd64",
+			expectNodeArch:    "kubernetes.io/arch: amd64",
+		},
+		{
+			name:              "both",
+			arch:              ArchBoth,
+			expectedGOARCH:    "GOARCH=$TARGETARCH",
+			expectedPlatforms: "linux/amd64,linux/arm64",
+			expectQEMU:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Arch = tt.arch
+			cfg.Deploy = true
+			cfg.DeployTargets = []DeployTarget{DeployTargetFly, DeployTargetKubernetes, DeployTargetECS}
+			memFS, _ := generateInMemory(t, cfg)
+
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			assert.Contains(t, readFile("Dockerfile"), tt.expectedGOARCH)
+			workflow := readFile(".github/workflows/deploy.yml")
+			assert.Contains(t, workflow, "platforms: "+tt.expectedPlatforms+"\n")
+			assert.Equal(t, tt.expectQEMU, strings.Contains(workflow, "docker/setup-qemu-action"))
+			assert.Contains(t, readFile("terraform/ecs.tf"), `cpu_architecture        = "X86_64"`)
+			assert.Contains(t, readFile("scripts/deploy-ecs.sh"), "--platform "+tt.expectedPlatforms+" ")
+			if tt.expectNodeArch != "" {
+				assert.Contains(t, readFile("k8s/deployment.yaml"), tt.expectNodeArch)
+			} else {
+				assert.NotContains(t, readFile("k8s/deployment.yaml"), "nodeSelector")
+			}
+		})
+	}
+
+	t.Run("arm64 without fly", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Arch = ArchARM64
+		cfg.Deploy = true
+		cfg.DeployTargets = []DeployTarget{DeployTargetECS}
+		memFS, _ := generateInMemory(t, cfg)
+
+		dockerfile, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "Dockerfile"))
+		require.NoError(t, err)
+		assert.Contains(t, string(dockerfile), "GOARCH=arm64")
+		assert.Contains(t, string(dockerfile), "FROM --platform=linux/arm64 alpine")
+		ecs, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "terraform/ecs.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(ecs), `cpu_architecture        = "ARM64"`)
+	})
+
+	t.Run("arm64 rejected for fly", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Arch = ArchARM64
+		cfg.Deploy = true
+		err := NewGenerator(cfg, WithDryRun()).Generate()
+		assert.ErrorContains(t, err, "not supported for Fly.io")
+	})
+
+	t.Run("defaults to host arch", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Deploy = true
+		cfg.DeployTargets = []DeployTarget{DeployTargetKubernetes}
+		memFS, _ := generateInMemory(t, cfg)
+
+		dockerfile, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "Dockerfile"))
+		require.NoError(t, err)
+		assert.Contains(t, string(dockerfile), "GOARCH="+string(hostArch()))
+	})
+}
+
+func TestGenerator_Dockerfile(t *testing.T) {
+	t.Parallel()
+
+	cfg := testProjectConfig()
+	cfg.Deploy = true
+	memFS, _ := generateInMemory(t, cfg)
+
+	readFile := func(path string) string {
+		data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	// The build image uses the Go version go.mod declares
+	goVersion := NewGenerator(cfg).GoVersion()
+	assert.Contains(t, readFile("go.mod"), "\ngo "+goVersion+"\n")
+	dockerfile := readFile("Dockerfile")
+	assert.Contains(t, dockerfile, "ARG GO_VERSION="+goVersion+"\n")
+	assert.Contains(t, dockerfile, "golang:${GO_VERSION}-alpine AS builder")
+	assert.Contains(t, dockerfile, `org.opencontainers.image.description="`+cfg.ModulePath+`"`)
+
+	// Dependencies are downloaded before the source is copied, so source changes reuse that layer
+	assert.Less(t, strings.Index(dockerfile, "RUN go mod download"), strings.Index(dockerfile, "COPY . ."))
+}
+
+func TestGenerator_GoVersion(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, ValidateGoVersion(hostGoVersion()), "the default must be a valid version")
+
+	cfg := testProjectConfig()
+	cfg.GoVersion = "1.25.7"
+	cfg.Framework = FrameworkTypeConnectRPC
+	cfg.Deploy = true
+	memFS, _ := generateInMemory(t, cfg)
+
+	readFile := func(path string) string {
+		data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	assert.Contains(t, readFile("go.mod"), "\ngo 1.25.7\n")
+	assert.Contains(t, readFile("Dockerfile"), "ARG GO_VERSION=1.25.7\n")
+	// CI installs the version go.mod declares
+	ci := readFile(".github/workflows/ci.yml")
+	assert.Equal(t, strings.Count(ci, "actions/setup-go"), strings.Count(ci, "go-version-file: go.mod"))
+
+	t.Run("too old", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.GoVersion = "1.21"
+		_, err := NewGenerator(cfg).Plan()
+		assert.ErrorContains(t, err, "invalid Go version")
+	})
+}
+
+func TestGenerator_RequestValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		framework       FrameworkType
+		layout          LayoutType
+		expectedPackage string
+	}{
+		{name: "chi", framework: FrameworkTypeChi, expectedPackage: "internal/posts"},
+		{name: "gin", framework: FrameworkTypeGin, expectedPackage: "internal/posts"},
+		{name: "echo", framework: FrameworkTypeEcho, expectedPackage: "internal/posts"},
+		{name: "flat layout", framework: FrameworkTypeChi, layout: LayoutTypeFlat, expectedPackage: "internal/app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = tt.framework
+			cfg.Layout = tt.layout
+			cfg.RequestValidation = true
+			memFS, paths := generateInMemory(t, cfg)
+
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			// The schemas are embedded next to the validator and referenced by the OpenAPI spec
+			for _, schema := range []string{"create_post_request.json", "update_post_request.json"} {
+				schemaPath := tt.expectedPackage + "/schemas/" + schema
+				assert.Contains(t, paths, schemaPath)
+				assert.Contains(t, readFile("openapi.yaml"), fmt.Sprintf("$ref: './%s'", schemaPath))
+			}
+			assert.Contains(t, paths, tt.expectedPackage+"/validation_test.go")
+			assert.Contains(t, readFile(tt.expectedPackage+"/validation.go"), "//go:embed schemas/*.json")
+			assert.Contains(t, readFile("go.mod"), "github.com/santhosh-tekuri/jsonschema/v6")
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		memFS, paths := generateInMemory(t, testProjectConfig())
+		assert.NotContains(t, paths, "internal/posts/schemas/create_post_request.json")
+		assert.NotContains(t, paths, "internal/posts/validation_test.go")
+
+		goMod, err := memFS.ReadFile(filepath.Join("out", "go.mod"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(goMod), "jsonschema")
+	})
+
+	t.Run("connectrpc ignores it", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Framework = FrameworkTypeConnectRPC
+		cfg.RequestValidation = true
+		_, paths := generateInMemory(t, cfg)
+		assert.NotContains(t, paths, "internal/posts/validation.go")
+	})
+}
+
+func TestGenerator_Auth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		framework          FrameworkType
+		layout             LayoutType
+		expectedMiddleware string
+		expectedPackage    string
+		expectedTokenFiles []string
+	}{
+		{name: "chi", framework: FrameworkTypeChi, expectedMiddleware: "r.Use(authenticator.Middleware)", expectedPackage: "internal/posts", expectedTokenFiles: []string{"internal/auth/routes.go", "internal/auth/routes_test.go"}},
+		{name: "gin", framework: FrameworkTypeGin, expectedMiddleware: "authenticator.Middleware()", expectedPackage: "internal/posts", expectedTokenFiles: []string{"internal/auth/routes.go"}},
+		{name: "echo", framework: FrameworkTypeEcho, expectedMiddleware: "authenticator.Middleware()", expectedPackage: "internal/posts", expectedTokenFiles: []string{"internal/auth/routes.go"}},
+		{name: "connectrpc", framework: FrameworkTypeConnectRPC, expectedMiddleware: "authenticator.Interceptor()", expectedPackage: "internal/posts", expectedTokenFiles: []string{"internal/auth/token_handler.go", "internal/protos/auth/v1/auth.proto"}},
+		{name: "flat layout", framework: FrameworkTypeChi, layout: LayoutTypeFlat, expectedMiddleware: "r.Use(authenticator.Middleware)", expectedPackage: "internal/app", expectedTokenFiles: []string{"internal/auth/routes.go", "internal/auth/routes_test.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = tt.framework
+			cfg.Layout = tt.layout
+			cfg.Auth = true
+			memFS, paths := generateInMemory(t, cfg)
+
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			assert.Contains(t, paths, "internal/auth/jwt.go")
+			assert.Contains(t, paths, "internal/auth/jwt_test.go")
+			// The middleware stores the token's user ID where the posts handlers look for it
+			assert.Contains(t, readFile("internal/auth/middleware.go"), cfg.ModulePath+"/"+tt.expectedPackage)
+			assert.Contains(t, readFile("cmd/api/main.go"), tt.expectedMiddleware)
+			assert.Contains(t, readFile("go.mod"), "github.com/golang-jwt/jwt/v5")
+
+			// The demo token endpoint is registered outside the auth middleware
+			assert.Contains(t, paths, "internal/auth/token.go")
+			for _, path := range tt.expectedTokenFiles {
+				assert.Contains(t, paths, path)
+			}
+			assert.Contains(t, readFile("cmd/api/main.go"), "DEMO ONLY")
+			assert.Contains(t, readFile("README.md"), "Authorization: Bearer $TOKEN")
+			for _, stage := range []string{"local", "staging", "production"} {
+				assert.Contains(t, readFile("internal/config/"+stage+".yaml"), "token_expiry:")
+			}
+		})
+	}
+
+	t.Run("openapi uses bearer auth", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Auth = true
+		memFS, _ := generateInMemory(t, cfg)
+
+		openapi, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "openapi.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(openapi), "bearerAuth")
+		assert.Contains(t, string(openapi), "/auth/token:")
+		assert.NotContains(t, string(openapi), "X-User-ID")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		memFS, paths := generateInMemory(t, testProjectConfig())
+		assert.NotContains(t, paths, "internal/auth/jwt.go")
+
+		for _, path := range []string{"go.mod", "cmd/api/main.go", "internal/config/production.yaml"} {
+			data, err := memFS.ReadFile(filepath.Join("out", path))
+			require.NoError(t, err)
+			assert.NotContains(t, string(data), "auth")
+		}
+	})
+}
+
+func TestGenerator_GRPCHealthAndReflection(t *testing.T) {
+	t.Parallel()
+
+	for _, auth := range []bool{false, true} {
+		t.Run(fmt.Sprintf("auth=%t", auth), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = FrameworkTypeConnectRPC
+			cfg.Auth = auth
+			memFS, _ := generateInMemory(t, cfg)
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			mainGo := readFile("cmd/api/main.go")
+			assert.Contains(t, mainGo, "mux.Handle(grpchealth.NewHandler(healthChecker))")
+			assert.Contains(t, mainGo, "mux.Handle(grpcreflect.NewHandlerV1(reflector))")
+			assert.Contains(t, mainGo, `healthChecker.SetStatus("", grpchealth.StatusNotServing)`)
+			assert.Equal(t, auth, strings.Contains(mainGo, "authv1connect.AuthServiceName"))
+
+			goMod := readFile("go.mod")
+			assert.Contains(t, goMod, "connectrpc.com/grpchealth")
+			assert.Contains(t, goMod, "connectrpc.com/grpcreflect")
+			assert.NotContains(t, readFile("README.md"), "-import-path", "reflection makes proto files unnecessary")
+		})
+	}
+}
+
+func TestGenerator_GRPCAlongsideREST(t *testing.T) {
+	t.Parallel()
+
+	for _, auth := range []bool{false, true} {
+		t.Run(fmt.Sprintf("auth=%t", auth), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.GRPC = true
+			cfg.Auth = auth
+			cfg.Deploy = true
+			cfg.DeployTargets = []DeployTarget{DeployTargetKubernetes}
+			memFS, paths := generateInMemory(t, cfg)
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			// The REST handler and the ConnectRPC service both serve the posts API
+			assert.Contains(t, paths, "internal/posts/routes.go")
+			assert.Contains(t, paths, "internal/api/posts_handler.go")
+			assert.Contains(t, paths, "internal/protos/posts/v1/posts.proto")
+			assert.Contains(t, paths, "buf.gen.yaml")
+
+			mainGo := readFile("cmd/api/main.go")
+			assert.Contains(t, mainGo, "postsv1connect.NewPostServiceHandler(")
+			assert.Contains(t, mainGo, "h2c.NewHandler(")
+			assert.Contains(t, mainGo, "grpchealth.NewHandler(healthChecker)")
+			assert.Contains(t, mainGo, "posts.RegisterRoutes(postsService, r)")
+
+			assert.Contains(t, readFile("go.mod"), "connectrpc.com/connect")
+			assert.NotContains(t, readFile("k8s/ingress.yaml"), "backend-protocol", "REST routes share the port")
+		})
+	}
+
+	t.Run("rejected for other frameworks", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Framework = FrameworkTypeGin
+		cfg.GRPC = true
+		err := NewGenerator(cfg, WithDryRun()).Generate()
+		assert.ErrorContains(t, err, "requires the chi framework")
+	})
+}
+
+func TestGenerator_Client(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		framework          FrameworkType
+		layout             LayoutType
+		expectedRouterCall string
+		expectedPackage    string
+	}{
+		{name: "chi", framework: FrameworkTypeChi, expectedRouterCall: "chi.NewRouter()", expectedPackage: "internal/posts"},
+		{name: "gin", framework: FrameworkTypeGin, expectedRouterCall: "gin.New()", expectedPackage: "internal/posts"},
+		{name: "echo", framework: FrameworkTypeEcho, expectedRouterCall: "echo.New()", expectedPackage: "internal/posts"},
+		{name: "flat layout", framework: FrameworkTypeChi, layout: LayoutTypeFlat, expectedRouterCall: "chi.NewRouter()", expectedPackage: "internal/app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = tt.framework
+			cfg.Layout = tt.layout
+			cfg.Client = true
+			memFS, paths := generateInMemory(t, cfg)
+
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			assert.Contains(t, paths, "client/client.go")
+			assert.Contains(t, readFile("client/client_test.go"), cfg.ModulePath+"/"+tt.expectedPackage)
+			assert.Contains(t, readFile("client/server_test.go"), tt.expectedRouterCall)
+			assert.Contains(t, readFile("README.md"), "client.New(")
+		})
+	}
+
+	t.Run("connectrpc documents the connect client", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Framework = FrameworkTypeConnectRPC
+		cfg.Client = true
+		memFS, paths := generateInMemory(t, cfg)
+		assert.NotContains(t, paths, "client/client.go")
+
+		readme, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "README.md"))
+		require.NoError(t, err)
+		assert.Contains(t, string(readme), "postsv1connect.NewPostServiceClient")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		memFS, paths := generateInMemory(t, testProjectConfig())
+		assert.NotContains(t, paths, "client/client.go")
+
+		readme, err := memFS.ReadFile(filepath.Join("out", "README.md"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(readme), "## Go Client")
+	})
+}
+
+func TestGenerator_SplitConfig(t *testing.T) {
+	t.Parallel()
+
+	splitFiles := []string{
+		"internal/config/config_server.go",
+		"internal/config/config_secrets.go",
+		"internal/config/config_metrics.go",
+		"internal/config/config_auth.go",
+		"internal/config/config_posthog.go",
+		"internal/config/config_cors.go",
+		"internal/config/config_ratelimit.go",
+		"internal/config/config_postgres.go",
+		"internal/config/config_dynamodb.go",
+	}
+
+	t.Run("split", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.SplitConfig = true
+		memFS, paths := generateInMemory(t, cfg)
+		for _, path := range splitFiles {
+			assert.Contains(t, paths, path)
+		}
+
+		root, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "internal/config/config.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(root), `"Server":    serverSchema,`)
+		assert.NotContains(t, string(root), "type ServerConfig struct")
+	})
+
+	t.Run("single file by default", func(t *testing.T) {
+		t.Parallel()
+
+		memFS, paths := generateInMemory(t, testProjectConfig())
+		for _, path := range splitFiles {
+			assert.NotContains(t, paths, path)
+		}
+
+		data, err := memFS.ReadFile(filepath.Join("out", "internal/config/config.go"))
+		require.NoError(t, err)
+		config := string(data)
+		for _, decl := range []string{"type Config struct", "type ServerConfig struct", "type SecretsConfig struct", "type MetricsConfig struct", "type AuthConfig struct", "type PostHogConfig struct", "var configSchema"} {
+			assert.Contains(t, config, decl)
+		}
+		assert.Equal(t, 1, strings.Count(config, "\nimport ("), "imports should be merged into one block")
+		assert.Equal(t, 1, strings.Count(config, `"github.com/Oudwins/zog"`))
+	})
+}
+
+func TestGenerator_Minimal(t *testing.T) {
+	t.Parallel()
+
+	omitted := []string{
+		".mockery.yaml",
+		"prometheus.yml",
+		"grafana/provisioning/datasources/prometheus.yml",
+		"grafana/provisioning/dashboards/dashboard.yml",
+		"grafana/dashboards/service.json",
+		"internal/metrics/metrics.go",
+		"internal/metrics/middleware.go",
+		"internal/posts/observed_table.go",
+		"internal/posts/observed_table_test.go",
+		"internal/posts/service_test.go",
+		"internal/posts/post_table_mock_test.go",
+		".github/workflows/ci.yml",
+	}
+
+	tests := []struct {
+		name      string
+		database  DatabaseType
+		framework FrameworkType
+	}{
+		{name: "chi postgres", database: DatabaseTypePostgres, framework: FrameworkTypeChi},
+		{name: "gin dynamodb", database: DatabaseTypeDynamoDB, framework: FrameworkTypeGin},
+		{name: "echo postgres", database: DatabaseTypePostgres, framework: FrameworkTypeEcho},
+		{name: "connectrpc dynamodb", database: DatabaseTypeDynamoDB, framework: FrameworkTypeConnectRPC},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Database.Type = tt.database
+			cfg.Framework = tt.framework
+			_, fullPaths := generateInMemory(t, cfg)
+
+			cfg.Minimal = true
+			memFS, paths := generateInMemory(t, cfg)
+			for _, path := range omitted {
+				assert.NotContains(t, paths, path)
+			}
+			for _, path := range []string{"cmd/api/main.go", "internal/config/config.go", "internal/posts/service.go", "docker-compose.yml"} {
+				assert.Contains(t, paths, path)
+			}
+			assert.LessOrEqual(t, len(paths), len(fullPaths)-len(omitted))
+
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+			assert.NotContains(t, readFile("cmd/api/main.go"), "internal/metrics")
+			assert.NotContains(t, readFile("docker-compose.yml"), "prometheus")
+			assert.NotContains(t, readFile("internal/config/local.yaml"), "metrics:")
+			assert.NotContains(t, readFile("internal/posts/table.go"), "go:generate")
+			assert.NotContains(t, readFile("scripts/generate.sh"), "mocks")
+			assert.NotContains(t, readFile("README.md"), "Grafana")
+		})
+	}
+
+	t.Run("rejected with deploy", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Minimal = true
+		cfg.Deploy = true
+		err := NewGenerator(cfg, WithDryRun()).Generate()
+		assert.ErrorContains(t, err, "minimal project has no deployment files")
+	})
+}
+
+func TestGenerator_APIPrefix(t *testing.T) {
+	t.Parallel()
+
+	for _, framework := range []FrameworkType{FrameworkTypeChi, FrameworkTypeGin, FrameworkTypeEcho} {
+		t.Run(string(framework), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = framework
+			cfg.Auth = true
+			cfg.APIPrefix = "/api/v1"
+			memFS, _ := generateInMemory(t, cfg)
+
+			main, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "cmd/api/main.go"))
+			require.NoError(t, err)
+			assert.Contains(t, string(main), `"/api/v1"`)
+			assert.Contains(t, string(main), "authenticator.RegisterRoutes(apiRouter)")
+
+			spec, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "openapi.yaml"))
+			require.NoError(t, err)
+			assert.Contains(t, string(spec), "url: http://localhost:8080/api/v1")
+
+			readme, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "README.md"))
+			require.NoError(t, err)
+			assert.Contains(t, string(readme), "http://localhost:8080/api/v1/posts")
+			assert.Contains(t, string(readme), "http://localhost:8080/api/v1/auth/token")
+		})
+	}
+
+	t.Run("no prefix by default", func(t *testing.T) {
+		t.Parallel()
+
+		memFS, _ := generateInMemory(t, testProjectConfig())
+		main, err := memFS.ReadFile(filepath.Join("out", "cmd/api/main.go"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(main), "apiRouter")
+	})
+
+	t.Run("connectrpc rejected", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.Framework = FrameworkTypeConnectRPC
+		cfg.APIPrefix = "/api/v1"
+		gen := NewGenerator(cfg, WithFileSystem(NewMemFileSystem()))
+		assert.Error(t, gen.Generate())
+	})
+}
+
+func TestGenerator_CORS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		framework          FrameworkType
+		expectedMiddleware string
+		expectedModule     string
+	}{
+		{framework: FrameworkTypeChi, expectedMiddleware: "r.Use(cors.Handler(", expectedModule: "github.com/go-chi/cors"},
+		{framework: FrameworkTypeGin, expectedMiddleware: "handler = cors.New(", expectedModule: "github.com/rs/cors"},
+		{framework: FrameworkTypeEcho, expectedMiddleware: "middleware.CORSWithConfig(", expectedModule: ""},
+		{framework: FrameworkTypeConnectRPC, expectedMiddleware: "connectcors.AllowedHeaders()", expectedModule: "connectrpc.com/cors"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.framework), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = tt.framework
+			memFS, _ := generateInMemory(t, cfg)
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			main := readFile("cmd/api/main.go")
+			assert.Contains(t, main, "if cfg.CORS.Enabled() {")
+			assert.Contains(t, main, tt.expectedMiddleware)
+			if tt.expectedModule != "" {
+				assert.Contains(t, readFile("go.mod"), tt.expectedModule+" ")
+			}
+			assert.Contains(t, readFile("internal/config/local.yaml"), "# cors:")
+			assert.NotContains(t, readFile("internal/config/production.yaml"), "cors:")
+		})
+	}
+}
+
+func TestGenerator_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		framework          FrameworkType
+		expectedMiddleware string
+	}{
+		{framework: FrameworkTypeChi, expectedMiddleware: "r.Use(ratelimit.New(store, rateLimitOpts...).Middleware)"},
+		{framework: FrameworkTypeGin},
+		{framework: FrameworkTypeEcho},
+		{framework: FrameworkTypeConnectRPC, expectedMiddleware: "connect.WithInterceptors(limiter.Interceptor())"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.framework), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = tt.framework
+			memFS, paths := generateInMemory(t, cfg)
+			readFile := func(path string) string {
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				return string(data)
+			}
+
+			main := readFile("cmd/api/main.go")
+			goMod := readFile("go.mod")
+			if tt.expectedMiddleware == "" {
+				assert.NotContains(t, paths, "internal/ratelimit/ratelimit.go")
+				assert.NotContains(t, main, "ratelimit")
+				assert.NotContains(t, goMod, "github.com/redis/go-redis/v9")
+				return
+			}
+			assert.Contains(t, paths, "internal/ratelimit/ratelimit.go")
+			assert.Contains(t, main, "if cfg.RateLimit.Enabled() {")
+			assert.Contains(t, main, tt.expectedMiddleware)
+			assert.Contains(t, goMod, "github.com/redis/go-redis/v9 ")
+			assert.Contains(t, readFile(".env.example"), "REDIS_URL=")
+		})
+	}
+}
+
+func TestGenerator_PostgresPoolConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, framework := range []FrameworkType{FrameworkTypeChi, FrameworkTypeGin, FrameworkTypeEcho, FrameworkTypeConnectRPC} {
+		t.Run(string(framework), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = framework
+			memFS, _ := generateInMemory(t, cfg)
+			data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "cmd/api/main.go"))
+			require.NoError(t, err)
+
+			main := string(data)
+			assert.Contains(t, main, "database.WithMaxConns(cfg.Postgres.MaxConns)")
+			assert.Contains(t, main, "database.NewPostgres(ctx, cfg.Secrets.DatabaseURL, pgOpts...)")
+		})
+	}
+}
+
+func TestGenerator_DynamoDBRetryConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, framework := range []FrameworkType{FrameworkTypeChi, FrameworkTypeGin, FrameworkTypeEcho, FrameworkTypeConnectRPC} {
+		t.Run(string(framework), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = framework
+			cfg.Database.Type = DatabaseTypeDynamoDB
+			memFS, _ := generateInMemory(t, cfg)
+			data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "cmd/api/main.go"))
+			require.NoError(t, err)
+
+			main := string(data)
+			assert.Contains(t, main, "database.WithMaxRetries(cfg.DynamoDB.MaxRetries)")
+			assert.Contains(t, main, "database.WithMaxBackoff(cfg.DynamoDB.MaxBackoff)")
+		})
+	}
+}
+
+func TestGenerator_CIWorkflow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		framework   FrameworkType
+		database    DatabaseType
+		expectsBuf  bool
+		expectedTag string
+	}{
+		{framework: FrameworkTypeChi, database: DatabaseTypePostgres, expectedTag: "PostgreSQL"},
+		{framework: FrameworkTypeConnectRPC, database: DatabaseTypeDynamoDB, expectsBuf: true, expectedTag: "DynamoDB Local"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.framework), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Framework = tt.framework
+			cfg.Database.Type = tt.database
+			memFS, _ := generateInMemory(t, cfg)
+			data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, ".github/workflows/ci.yml"))
+			require.NoError(t, err)
+
+			var workflow struct {
+				On   map[string]any `yaml:"on"`
+				Jobs map[string]any `yaml:"jobs"`
+			}
+			require.NoError(t, yaml.Unmarshal(data, &workflow))
+			assert.Contains(t, workflow.On, "push")
+			assert.Contains(t, workflow.On, "pull_request")
+			assert.Contains(t, workflow.Jobs, "test")
+			assert.Contains(t, workflow.Jobs, "integration")
+
+			ci := string(data)
+			assert.Contains(t, ci, "bash scripts/generate.sh")
+			assert.Contains(t, ci, "bash scripts/test-integration.sh")
+			assert.Contains(t, ci, tt.expectedTag)
+			assert.Equal(t, tt.expectsBuf, strings.Contains(ci, "bufbuild/buf-action"))
+			assert.Equal(t, tt.expectsBuf, workflow.Jobs["proto"] != nil, "proto lint job")
+
+			makefile, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "Makefile"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectsBuf, strings.Contains(string(makefile), "proto-lint:\n\t@buf lint"))
+			assert.Equal(t, tt.expectsBuf, strings.Contains(string(makefile), "@buf breaking --against"))
+		})
+	}
+}
+
+func TestGenerator_TemplateDir(t *testing.T) {
+	t.Parallel()
+
+	overrideDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(overrideDir, "templates"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(overrideDir, "templates", "Makefile.tmpl"), []byte("# custom Makefile for {{.ProjectName}}\n"), 0644))
+
+	cfg := testProjectConfig()
+	memFS := NewMemFileSystem()
+	gen := NewGenerator(cfg, WithFileSystem(memFS), WithTemplateDir(overrideDir))
+	require.NoError(t, gen.Generate())
+
+	makefile, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "Makefile"))
+	require.NoError(t, err)
+	assert.Equal(t, "# custom Makefile for testservice\n", string(makefile))
+
+	// Templates without an override still come from the embedded templates
+	readme, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "README.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "# testservice")
+}
+
+func TestAWSRegionToFlyRegion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		awsRegion      string
+		expectedRegion string
+		expectedOK     bool
+	}{
+		{awsRegion: "us-east-1", expectedRegion: "iad", expectedOK: true},
+		{awsRegion: "us-west-2", expectedRegion: "sea", expectedOK: true},
+		{awsRegion: "ca-central-1", expectedRegion: "yul", expectedOK: true},
+		{awsRegion: "eu-central-1", expectedRegion: "fra", expectedOK: true},
+		{awsRegion: "ap-east-1", expectedRegion: "hkg", expectedOK: true},
+		{awsRegion: "af-south-1", expectedRegion: "jnb", expectedOK: true},
+		{awsRegion: "sa-east-1", expectedRegion: "gru", expectedOK: true},
+		{awsRegion: "mars-north-1", expectedRegion: "iad", expectedOK: false},
+		{awsRegion: "", expectedRegion: "iad", expectedOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.awsRegion, func(t *testing.T) {
+			t.Parallel()
+
+			region, ok := awsRegionToFlyRegion(tt.awsRegion)
+			assert.Equal(t, tt.expectedRegion, region)
+			assert.Equal(t, tt.expectedOK, ok)
+		})
+	}
+}
+
+func TestGenerator_FlyRegion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		database       DatabaseConfig
+		flyRegion      string
+		expectedRegion string
+	}{
+		{name: "postgres defaults to iad", database: DatabaseConfig{Type: DatabaseTypePostgres}, expectedRegion: "iad"},
+		{name: "postgres with explicit region", database: DatabaseConfig{Type: DatabaseTypePostgres}, flyRegion: "fra", expectedRegion: "fra"},
+		{name: "inferred from AWS region", database: DatabaseConfig{Type: DatabaseTypeDynamoDB, AWSRegion: "eu-central-1"}, expectedRegion: "fra"},
+		{name: "explicit region overrides AWS region", database: DatabaseConfig{Type: DatabaseTypeDynamoDB, AWSRegion: "eu-central-1"}, flyRegion: "sin", expectedRegion: "sin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Database = tt.database
+			cfg.Deploy = true
+			cfg.FlyRegion = tt.flyRegion
+			memFS, _ := generateInMemory(t, cfg)
+
+			flyToml, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, "fly.toml"))
+			require.NoError(t, err)
+			assert.Contains(t, string(flyToml), `primary_region = "`+tt.expectedRegion+`"`)
+		})
+	}
+
+	t.Run("unknown region", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.FlyRegion = "xyz"
+		gen := NewGenerator(cfg, WithFileSystem(NewMemFileSystem()))
+		assert.ErrorContains(t, gen.Generate(), "invalid Fly.io region")
+	})
+
+	t.Run("every mapped AWS region is a known Fly.io region", func(t *testing.T) {
+		t.Parallel()
+
+		for _, awsRegion := range []string{"us-east-1", "us-west-1", "us-west-2", "ca-central-1", "ca-west-1", "mx-central-1", "eu-west-1", "eu-south-2", "il-central-1", "me-south-1", "af-south-1", "ap-east-1", "ap-southeast-4", "sa-east-1"} {
+			flyRegion, ok := awsRegionToFlyRegion(awsRegion)
+			require.True(t, ok, awsRegion)
+			assert.NoError(t, ValidateFlyRegion(flyRegion), awsRegion)
+		}
+	})
+}
+
+func TestGenerator_Warnings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		awsRegion       string
+		deploy          bool
+		port            string
+		expectedWarning string
+	}{
+		{name: "mapped region", awsRegion: "ca-central-1", deploy: true},
+		{name: "unmapped region", awsRegion: "mars-north-1", deploy: true, expectedWarning: "mars-north-1"},
+		{name: "unmapped region without fly deploy", awsRegion: "mars-north-1"},
+		{name: "port used by docker compose", awsRegion: "ca-central-1", port: "8000", expectedWarning: "DynamoDB Local"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Database = DatabaseConfig{Type: DatabaseTypeDynamoDB, AWSRegion: tt.awsRegion}
+			cfg.Deploy = tt.deploy
+			cfg.Port = tt.port
+			warnings := NewGenerator(cfg).Warnings()
+			if tt.expectedWarning != "" {
+				require.Len(t, warnings, 1)
+				assert.Contains(t, warnings[0], tt.expectedWarning)
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestGenerator_AWSCredentials(t *testing.T) {
+	t.Parallel()
+
+	const accessKeyID, secretKey = "AKIAEXAMPLEKEYID", "example-secret-key"
+
+	tests := []struct {
+		name            string
+		credentials     AWSCredentials
+		profile         string
+		expected        []string
+		expectedWarning bool
+	}{
+		{name: "profile by default", profile: "dev", expected: []string{"AWS_PROFILE=dev"}},
+		{name: "no profile", expected: []string{"# AWS_PROFILE=default"}},
+		{name: "explicit profile", credentials: AWSCredentialsProfile, profile: "dev", expected: []string{"AWS_PROFILE=dev"}},
+		{name: "static", credentials: AWSCredentialsStatic, profile: "dev", expected: []string{"AWS_ACCESS_KEY_ID=" + accessKeyID, "AWS_SECRET_ACCESS_KEY=" + secretKey}, expectedWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Database = DatabaseConfig{
+				Type:           DatabaseTypeDynamoDB,
+				AWSAccessKeyID: accessKeyID,
+				AWSSecretKey:   secretKey,
+				AWSRegion:      "us-east-1",
+				AWSProfile:     tt.profile,
+				AWSCredentials: tt.credentials,
+			}
+			memFS, paths := generateInMemory(t, cfg)
+
+			envLocal, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, ".env.local"))
+			require.NoError(t, err)
+			for _, line := range tt.expected {
+				assert.Contains(t, string(envLocal), line+"\n")
+			}
+
+			// Keys never end up anywhere else, and only in .env.local when asked for
+			for _, path := range paths {
+				if path == ".env.local" && tt.credentials == AWSCredentialsStatic {
+					continue
+				}
+				data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+				require.NoError(t, err)
+				assert.NotContains(t, string(data), secretKey, "secret key written to %s", path)
+				assert.NotContains(t, string(data), accessKeyID, "access key written to %s", path)
+			}
+
+			warnings := NewGenerator(cfg).Warnings()
+			if tt.expectedWarning {
+				require.Len(t, warnings, 1)
+				assert.Contains(t, warnings[0], "plaintext")
+			} else {
+				assert.Empty(t, warnings)
+			}
+		})
+	}
+}
+
+func TestGenerator_EnvExample(t *testing.T) {
+	t.Parallel()
+
+	// .env.example must document every variable the config loader reads into SecretsConfig
+	secrets, err := staticFS.ReadFile("static/internal/config/config_secrets.go")
+	require.NoError(t, err)
+	var envVars []string
+	for _, match := range regexp.MustCompile(`env:"(\w+)"`).FindAllStringSubmatch(string(secrets), -1) {
+		envVars = append(envVars, match[1])
+	}
+	require.NotEmpty(t, envVars)
+
+	tests := []struct {
+		name     string
+		database DatabaseType
+		auth     bool
+		required []string
+	}{
+		{name: "postgres", database: DatabaseTypePostgres, required: []string{"DATABASE_URL=postgres://"}},
+		{name: "dynamodb with auth", database: DatabaseTypeDynamoDB, auth: true, required: []string{"AWS_REGION=us-east-1", "TABLE_NAME=testservice", "JWT_SECRET="}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			cfg.Database.Type = tt.database
+			cfg.Auth = tt.auth
+			memFS, _ := generateInMemory(t, cfg)
+
+			data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, ".env.example"))
+			require.NoError(t, err)
+			envExample := string(data)
+			for _, envVar := range envVars {
+				assert.Regexp(t, `(?m)^(# )?`+envVar+`=`, envExample, "%s is not documented", envVar)
+			}
+			for _, line := range tt.required {
+				assert.Contains(t, envExample, "\n"+line)
+			}
+		})
+	}
+}
+
+func TestGenerator_FlatLayout(t *testing.T) {
+	t.Parallel()
+
+	cfg := testProjectConfig()
+	cfg.Layout = LayoutTypeFlat
+	memFS, paths := generateInMemory(t, cfg)
+
+	expectedPaths := []string{
+		".dockerignore",
+		".env",
+		".env.example",
+		".env.local",
+		".github/workflows/ci.yml",
+		".gitignore",
+		".mockery.yaml",
+		"Makefile",
+		"README.md",
+		"atlas.hcl",
+		"cmd/api/main.go",
+		"cmd/seed/main.go",
+		"docker-compose.yml",
+		"go.mod",
+		"grafana/dashboards/service.json",
+		"grafana/provisioning/dashboards/dashboard.yml",
+		"grafana/provisioning/datasources/prometheus.yml",
+		"internal/app/batch.go",
+		"internal/app/batch_test.go",
+		"internal/app/body_limit.go",
+		"internal/app/body_limit_test.go",
+		"internal/app/caching.go",
+		"internal/app/caching_test.go",
+		"internal/app/errors.go",
+		"internal/app/idempotency.go",
+		"internal/app/identity.go",
+		"internal/app/identity_test.go",
+		"internal/app/logging.go",
+		"internal/app/logging_test.go",
+		"internal/app/observed_table.go",
+		"internal/app/observed_table_test.go",
+		"internal/app/post.go",
+		"internal/app/post_table_mock_test.go",
+		"internal/app/postgres.go",
+		"internal/app/postgres_table.go",
+		"internal/app/postgres_table_test.go",
+		"internal/app/postgres_test.go",
+		"internal/app/routes.go",
+		"internal/app/routes_test.go",
+		"internal/app/service.go",
+		"internal/app/service_test.go",
+		"internal/app/table.go",
+		"internal/app/validation.go",
+		"internal/config/config.go",
+		"internal/config/config_test.go",
+		"internal/config/local.yaml",
+		"internal/config/production.yaml",
+		"internal/config/stage.go",
+		"internal/config/staging.yaml",
+		"internal/health/readiness.go",
+		"internal/health/readiness_test.go",
+		"internal/loadshed/loadshed.go",
+		"internal/loadshed/loadshed_test.go",
+		"internal/metrics/metrics.go",
+		"internal/metrics/middleware.go",
+		"internal/metrics/middleware_test.go",
+		"internal/ratelimit/memory.go",
+		"internal/ratelimit/ratelimit.go",
+		"internal/ratelimit/ratelimit_test.go",
+		"internal/ratelimit/redis.go",
+		"internal/ratelimit/redis_test.go",
+		"migrations/0001_create_posts.sql",
+		"migrations/README.md",
+		"openapi.yaml",
+		"prometheus.yml",
+		"schema.sql",
+		"scripts/check-deps.sh",
+		"scripts/generate.sh",
+		"scripts/migrate.sh",
+		"scripts/test-integration.sh",
+	}
+	assert.Equal(t, expectedPaths, paths)
+
+	readFile := func(path string) string {
+		data, err := memFS.ReadFile(filepath.Join(cfg.OutputDir, path))
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	for _, path := range expectedPaths {
+		if !strings.HasPrefix(path, "internal/app/") {
+			continue
+		}
+		content := readFile(path)
+		// Integration tests keep their build constraint above the package clause
+		clause := strings.TrimPrefix(content, "//go:build integration\n\n")
+		// Generated mocks keep mockery's header above the package clause
+		clause = strings.TrimPrefix(clause, "// Code generated by mockery. DO NOT EDIT.\n\n")
+		assert.True(t, strings.HasPrefix(clause, "package app\n"), "unexpected package clause in %s", path)
+		assert.NotContains(t, content, cfg.ModulePath+"/internal/", "merged package import left in %s", path)
+	}
+
+	mainGo := readFile("cmd/api/main.go")
+	assert.Contains(t, mainGo, `"`+cfg.ModulePath+`/internal/app"`)
+	assert.Contains(t, mainGo, "app.RegisterRoutes(")
+	assert.NotContains(t, mainGo, cfg.ModulePath+"/internal/posts")
+	assert.NotContains(t, mainGo, cfg.ModulePath+"/internal/database")
+	assert.Contains(t, readFile(".mockery.yaml"), cfg.ModulePath+"/internal/app:")
+}
+
+func TestGenerator_Plan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(cfg *ProjectConfig)
+	}{
+		{name: "postgres chi", modify: func(cfg *ProjectConfig) {}},
+		{name: "dynamodb gin", modify: func(cfg *ProjectConfig) {
+			cfg.Database.Type = DatabaseTypeDynamoDB
+			cfg.Framework = FrameworkTypeGin
+		}},
+		{name: "connectrpc with client", modify: func(cfg *ProjectConfig) {
+			cfg.Framework = FrameworkTypeConnectRPC
+			cfg.Client = true
+		}},
+		{name: "flat layout", modify: func(cfg *ProjectConfig) {
+			cfg.Layout = LayoutTypeFlat
+		}},
+		{name: "split config with fly deploy", modify: func(cfg *ProjectConfig) {
+			cfg.SplitConfig = true
+			cfg.Deploy = true
+			cfg.DeployTargets = []DeployTarget{DeployTargetFly}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testProjectConfig()
+			tt.modify(&cfg)
+
+			memFS := NewMemFileSystem()
+			plan, err := NewGenerator(cfg, WithFileSystem(memFS)).Plan()
+			require.NoError(t, err)
+			assert.Empty(t, memFS.Paths(), "Plan should not write any files")
+			assert.Empty(t, memFS.Dirs(), "Plan should not create any directories")
+
+			var planned []string
+			for _, file := range plan {
+				assert.NotEmpty(t, file.Sources, "no sources for %s", file.Path)
+				planned = append(planned, file.Path)
+			}
+			_, generated := generateInMemory(t, cfg)
+			assert.Equal(t, generated, planned)
+		})
+	}
+
+	t.Run("merged config lists every source", func(t *testing.T) {
+		t.Parallel()
+
+		plan, err := NewGenerator(testProjectConfig()).Plan()
+		require.NoError(t, err)
+
+		var sources []string
+		for _, file := range plan {
+			if file.Path == "internal/config/config.go" {
+				sources = file.Sources
+			}
+		}
+		assert.Greater(t, len(sources), 1)
+		assert.Contains(t, sources, "static/internal/config/config_server.go")
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.ProjectName = ""
+		_, err := NewGenerator(cfg).Plan()
+		assert.Error(t, err)
+	})
+}
+
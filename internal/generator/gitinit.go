@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// ErrGitNotFound is returned by GitInit when git isn't installed. The project is still
+// generated, so callers report it as a warning rather than a failure.
+var ErrGitNotFound = errors.New("git not found in PATH; skipped initializing a git repository")
+
+// ErrGitRepoExists is returned by GitInit when the output directory is already a git
+// repository, which is left untouched
+var ErrGitRepoExists = errors.New("output directory is already a git repository; skipped the initial commit")
+
+// initialCommitMessage is the message of the commit GitInit makes
+const initialCommitMessage = "Initial commit from create-go-api"
+
+// GitInit makes the generated project a git repository with a single commit holding every
+// generated file (the generated .gitignore keeps build output and env files out of it).
+// When the user has no git identity configured, the commit is authored as create-go-api.
+func (g *Generator) GitInit(ctx context.Context) error {
+	if g.memFS != nil {
+		return fmt.Errorf("cannot initialize a git repository for a dry-run generation")
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return ErrGitNotFound
+	}
+	if _, err := os.Stat(filepath.Join(g.config.OutputDir, ".git")); err == nil {
+		return ErrGitRepoExists
+	}
+
+	if err := g.runInOutputDir(ctx, "git", "init"); err != nil {
+		return fmt.Errorf("failed to initialize git repository: %w", err)
+	}
+	if err := g.runInOutputDir(ctx, "git", "add", "-A"); err != nil {
+		return fmt.Errorf("failed to stage generated files: %w", err)
+	}
+
+	args := []string{"commit", "-m", initialCommitMessage}
+	if !g.hasGitIdentity(ctx) {
+		args = append([]string{"-c", "user.name=create-go-api", "-c", "user.email=create-go-api@localhost"}, args...)
+	}
+	if err := g.runInOutputDir(ctx, "git", args...); err != nil {
+		return fmt.Errorf("failed to make initial commit: %w", err)
+	}
+	return nil
+}
+
+// hasGitIdentity reports whether git has a user name and email to author commits in the
+// output directory
+func (g *Generator) hasGitIdentity(ctx context.Context) bool {
+	for _, key := range []string{"user.name", "user.email"} {
+		cmd := exec.CommandContext(ctx, "git", "config", key)
+		cmd.Dir = g.config.OutputDir
+		if output, err := cmd.Output(); err != nil || len(output) == 0 {
+			return false
+		}
+	}
+	return true
+}
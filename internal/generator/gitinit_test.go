@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_GitInit(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+
+	cfg := testProjectConfig()
+	cfg.OutputDir = filepath.Join(t.TempDir(), cfg.ProjectName)
+	gen := NewGenerator(cfg)
+	require.NoError(t, gen.Generate())
+
+	ctx := context.Background()
+	require.NoError(t, gen.GitInit(ctx))
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = cfg.OutputDir
+		output, err := cmd.Output()
+		require.NoError(t, err)
+		return strings.TrimSpace(string(output))
+	}
+	assert.Equal(t, "1", git("rev-list", "--count", "HEAD"))
+	assert.Equal(t, initialCommitMessage, git("log", "-1", "--format=%s"))
+	assert.Empty(t, git("status", "--porcelain"), "every generated file should be committed")
+	assert.Contains(t, strings.Split(git("ls-files"), "\n"), "go.mod")
+
+	// An existing repository is left alone
+	assert.ErrorIs(t, gen.GitInit(ctx), ErrGitRepoExists)
+	assert.Equal(t, "1", git("rev-list", "--count", "HEAD"))
+}
+
+func TestGenerator_GitInitDryRun(t *testing.T) {
+	t.Parallel()
+
+	gen := NewGenerator(testProjectConfig(), WithDryRun())
+	require.NoError(t, gen.Generate())
+	assert.Error(t, gen.GitInit(context.Background()))
+}
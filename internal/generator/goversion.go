@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"go/version"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// MinGoVersion is the oldest Go version generated projects can target; their dependencies
+// (e.g. google.golang.org/grpc) require it
+const MinGoVersion = "1.25"
+
+// goVersionPattern matches Go release versions as written in a go directive (1.25 or 1.25.4)
+var goVersionPattern = regexp.MustCompile(`^1\.[0-9]+(\.[0-9]+)?$`)
+
+// hostGoVersion returns the version of the Go toolchain the generator was built with
+// (e.g. 1.25.4), or DefaultGoVersion for development and pre-release toolchains
+func hostGoVersion() string {
+	goVersion := strings.TrimPrefix(strings.Fields(runtime.Version())[0], "go")
+	if ValidateGoVersion(goVersion) != nil {
+		return DefaultGoVersion
+	}
+	return goVersion
+}
+
+// GoVersion returns the Go version generated projects target: the configured version if set,
+// otherwise the version of the toolchain the generator was built with
+func (g *Generator) GoVersion() string {
+	if g.config.GoVersion != "" {
+		return g.config.GoVersion
+	}
+	return hostGoVersion()
+}
+
+// goVersionAtLeast reports whether goVersion (e.g. 1.25.4) is min or later
+func goVersionAtLeast(goVersion, min string) bool {
+	return version.Compare("go"+goVersion, "go"+min) >= 0
+}
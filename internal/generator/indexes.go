@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+)
+
+// listPostsIndex backs ListPostsByUserID (WHERE user_id = $1 ORDER BY created_at DESC)
+const listPostsIndex = "user_id, created_at DESC"
+
+// postgresIndex is an index on the posts table rendered into schema.sql
+type postgresIndex struct {
+	Name    string // e.g. idx_posts_user_id_created_at
+	Columns string // e.g. user_id, created_at DESC
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// postgresIndexes returns the posts table indexes: the ListPostsByUserID index followed by
+// any additional indexes from the config, skipping duplicates
+func (g *Generator) postgresIndexes() []postgresIndex {
+	var indexes []postgresIndex
+	seen := make(map[string]bool)
+	for _, spec := range append([]string{listPostsIndex}, g.config.Database.Indexes...) {
+		index := newPostgresIndex(spec)
+		if index.Columns == "" || seen[index.Name] {
+			continue
+		}
+		seen[index.Name] = true
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
+
+// newPostgresIndex parses a comma-separated column list (with optional ASC/DESC per column)
+// and names the index after its columns
+func newPostgresIndex(spec string) postgresIndex {
+	var columns, nameParts []string
+	for _, column := range strings.Split(spec, ",") {
+		fields := strings.Fields(column)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > 1 {
+			fields[1] = strings.ToUpper(fields[1]) // Sort order
+		}
+		columns = append(columns, strings.Join(fields, " "))
+		nameParts = append(nameParts, nonIdentifierChars.ReplaceAllString(strings.ToLower(fields[0]), ""))
+	}
+	return postgresIndex{
+		Name:    "idx_posts_" + strings.Join(nameParts, "_"),
+		Columns: strings.Join(columns, ", "),
+	}
+}
@@ -0,0 +1,166 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// flatPackageDir is the single package the flat layout merges the per-resource packages into
+const flatPackageDir = "internal/app"
+
+// flatMergedPackageDirs are the standard layout packages merged into flatPackageDir
+var flatMergedPackageDirs = []string{"internal/posts", "internal/database", "internal/api"}
+
+// packageDir returns the directory a standard layout package is generated into
+func (g *Generator) packageDir(dir string) string {
+	if g.config.Layout == LayoutTypeFlat && isFlatMergedPackageDir(dir) {
+		return flatPackageDir
+	}
+	return dir
+}
+
+func isFlatMergedPackageDir(dir string) bool {
+	for _, merged := range flatMergedPackageDirs {
+		if dir == merged {
+			return true
+		}
+	}
+	return false
+}
+
+// applyLayout maps a standard layout output path (and, for Go files, its package clause
+// and imports) onto the configured layout
+func (g *Generator) applyLayout(outputPath string, content []byte) (string, []byte, error) {
+	if g.config.Layout != LayoutTypeFlat {
+		return outputPath, content, nil
+	}
+
+	dir := path.Dir(outputPath)
+	outputPath = g.layoutPath(outputPath)
+
+	if !strings.HasSuffix(outputPath, ".go") {
+		return outputPath, content, nil
+	}
+
+	content, err := flattenGoSource(content, g.config.ModulePath, isFlatMergedPackageDir(dir))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to apply flat layout to %s: %w", outputPath, err)
+	}
+	return outputPath, content, nil
+}
+
+// layoutPath maps a standard layout output path onto the configured layout
+func (g *Generator) layoutPath(outputPath string) string {
+	if g.config.Layout != LayoutTypeFlat {
+		return outputPath
+	}
+	return path.Join(g.packageDir(path.Dir(outputPath)), path.Base(outputPath))
+}
+
+// sourceEdit replaces src[start:end] with text
+type sourceEdit struct {
+	start, end int
+	text       string
+}
+
+// flattenGoSource rewrites a Go file for the flat layout. Imports of the merged packages
+// are redirected to the flat package; when the file itself is merged into the flat package
+// those imports are dropped and their qualifiers removed.
+func flattenGoSource(src []byte, modulePath string, merged bool) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	flatImportPath := modulePath + "/" + flatPackageDir
+	flatName := path.Base(flatPackageDir)
+
+	var edits []sourceEdit
+	if merged {
+		edits = append(edits, sourceEdit{offset(file.Name.Pos()), offset(file.Name.End()), flatName})
+	}
+
+	// Find imports of merged packages and the names they are referenced by
+	mergedImports := make(map[string]bool)
+	flatImported := false
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(importPath, modulePath+"/") ||
+			!isFlatMergedPackageDir(strings.TrimPrefix(importPath, modulePath+"/")) {
+			continue
+		}
+
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		mergedImports[name] = true
+
+		if merged || flatImported {
+			edits = append(edits, removeLineEdit(src, offset(spec.Pos()), offset(spec.End())))
+			continue
+		}
+		edits = append(edits, sourceEdit{offset(spec.Pos()), offset(spec.End()), strconv.Quote(flatImportPath)})
+		flatImported = true
+	}
+	if len(mergedImports) == 0 && !merged {
+		return src, nil
+	}
+
+	// Rewrite package qualifiers (identifiers with an object are locals shadowing the import)
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok || ident.Obj != nil || !mergedImports[ident.Name] {
+			return true
+		}
+		if merged {
+			edits = append(edits, sourceEdit{offset(ident.Pos()), offset(sel.Sel.Pos()), ""})
+		} else {
+			edits = append(edits, sourceEdit{offset(ident.Pos()), offset(ident.End()), flatName})
+		}
+		return true
+	})
+
+	// Apply edits back to front so earlier offsets stay valid
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start > edits[j].start
+	})
+	out := append([]byte(nil), src...)
+	for _, edit := range edits {
+		out = append(out[:edit.start], append([]byte(edit.text), out[edit.end:]...)...)
+	}
+	return out, nil
+}
+
+// removeLineEdit removes src[start:end] along with the rest of its line
+// when nothing else is on it
+func removeLineEdit(src []byte, start, end int) sourceEdit {
+	lineStart := start
+	for lineStart > 0 && (src[lineStart-1] == ' ' || src[lineStart-1] == '\t') {
+		lineStart--
+	}
+	if lineStart == 0 || src[lineStart-1] == '\n' {
+		if newline := strings.IndexByte(string(src[end:]), '\n'); newline >= 0 &&
+			strings.TrimSpace(string(src[end:end+newline])) == "" {
+			return sourceEdit{lineStart, end + newline + 1, ""}
+		}
+	}
+	return sourceEdit{start, end, ""}
+}
@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// errDirNotEmpty is returned when removing a directory that still has entries
+var errDirNotEmpty = errors.New("directory not empty")
+
+// MemFileSystem implements FileSystem in memory. It backs dry runs and lets
+// tests and library consumers capture generated output without touching disk.
+type MemFileSystem struct {
+	files map[string]*memFile
+	dirs  map[string]bool
+}
+
+// memFile is a file stored in a MemFileSystem
+type memFile struct {
+	data []byte
+	perm os.FileMode
+}
+
+// NewMemFileSystem creates an empty in-memory filesystem
+func NewMemFileSystem() *MemFileSystem {
+	return &MemFileSystem{
+		files: make(map[string]*memFile),
+		dirs:  make(map[string]bool),
+	}
+}
+
+func (f *MemFileSystem) MkdirAll(path string, perm os.FileMode) error {
+	f.dirs[filepath.Clean(path)] = true
+	return nil
+}
+
+func (f *MemFileSystem) WriteFile(name string, data []byte, perm os.FileMode) error {
+	f.files[filepath.Clean(name)] = &memFile{
+		data: append([]byte(nil), data...),
+		perm: perm,
+	}
+	return nil
+}
+
+func (f *MemFileSystem) Stat(name string) (os.FileInfo, error) {
+	name = filepath.Clean(name)
+	if file, ok := f.files[name]; ok {
+		return memFileInfo{name: filepath.Base(name), size: int64(len(file.data)), mode: file.perm}, nil
+	}
+	if f.dirs[name] {
+		return memFileInfo{name: filepath.Base(name), mode: fs.ModeDir | 0755}, nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+func (f *MemFileSystem) Rename(oldpath, newpath string) error {
+	oldpath, newpath = filepath.Clean(oldpath), filepath.Clean(newpath)
+	file, ok := f.files[oldpath]
+	if !ok {
+		return &fs.PathError{Op: "rename", Path: oldpath, Err: fs.ErrNotExist}
+	}
+	f.files[newpath] = file
+	delete(f.files, oldpath)
+	return nil
+}
+
+// Remove deletes a file, or a directory with nothing in it
+func (f *MemFileSystem) Remove(name string) error {
+	name = filepath.Clean(name)
+	if _, ok := f.files[name]; ok {
+		delete(f.files, name)
+		return nil
+	}
+	prefix := name + string(filepath.Separator)
+	for path := range f.files {
+		if strings.HasPrefix(path, prefix) {
+			return &fs.PathError{Op: "remove", Path: name, Err: errDirNotEmpty}
+		}
+	}
+	for dir := range f.dirs {
+		if strings.HasPrefix(dir, prefix) {
+			return &fs.PathError{Op: "remove", Path: name, Err: errDirNotEmpty}
+		}
+	}
+	if !f.dirs[name] {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	delete(f.dirs, name)
+	return nil
+}
+
+// ReadFile returns the contents of a written file
+func (f *MemFileSystem) ReadFile(name string) ([]byte, error) {
+	file, ok := f.files[filepath.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), file.data...), nil
+}
+
+// Paths returns all written file paths in sorted order
+func (f *MemFileSystem) Paths() []string {
+	paths := make([]string, 0, len(f.files))
+	for path := range f.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Dirs returns all created directory paths in sorted order
+func (f *MemFileSystem) Dirs() []string {
+	dirs := make([]string, 0, len(f.dirs))
+	for dir := range f.dirs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// memFileInfo implements os.FileInfo for MemFileSystem entries
+type memFileInfo struct {
+	name string
+	size int64
+	mode os.FileMode
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return i.size }
+func (i memFileInfo) Mode() os.FileMode  { return i.mode }
+func (i memFileInfo) ModTime() time.Time { return time.Time{} }
+func (i memFileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i memFileInfo) Sys() any           { return nil }
+
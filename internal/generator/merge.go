@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mergeGoSources merges Go files of the same package into one file. The package clause
+// comes from the first file, imports are deduplicated, and declarations keep source order.
+// The result is not formatted; writeOutputFile runs it through gofmt.
+func mergeGoSources(sources [][]byte) ([]byte, error) {
+	var header []byte
+	var bodies [][]byte
+	imports := make(map[string]bool)
+
+	for i, src := range sources {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "", src, parser.ImportsOnly|parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		offset := func(pos token.Pos) int {
+			return fset.Position(pos).Offset
+		}
+
+		if i == 0 {
+			header = src[:offset(file.Name.End())]
+		}
+		bodyStart := offset(file.Name.End())
+		for _, decl := range file.Decls {
+			bodyStart = offset(decl.End())
+		}
+		bodies = append(bodies, src[bodyStart:])
+
+		for _, spec := range file.Imports {
+			importSpec := spec.Path.Value
+			if spec.Name != nil {
+				importSpec = spec.Name.Name + " " + importSpec
+			}
+			imports[importSpec] = true
+		}
+	}
+
+	// Standard library imports first, then everything else, each group sorted
+	var std, other []string
+	for importSpec := range imports {
+		importPath, err := strconv.Unquote(importSpec[strings.Index(importSpec, `"`):])
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			other = append(other, importSpec)
+		} else {
+			std = append(std, importSpec)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	buf.WriteString("\n\nimport (\n")
+	for _, importSpec := range std {
+		buf.WriteString("\t" + importSpec + "\n")
+	}
+	if len(std) > 0 && len(other) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, importSpec := range other {
+		buf.WriteString("\t" + importSpec + "\n")
+	}
+	buf.WriteString(")\n")
+	for _, body := range bodies {
+		buf.Write(body)
+	}
+	return buf.Bytes(), nil
+}
+
@@ -0,0 +1,106 @@
+package generator
+
+import "strings"
+
+// apiOperation describes one operation of the generated posts API.
+// README examples are rendered from this table, and operations_test.go checks it
+// against the route registrations and proto service so the two can't drift.
+type apiOperation struct {
+	Summary      string
+	Handler      string // REST handler function registered for the route
+	Method       string // REST HTTP method
+	Path         string // REST route path, with {param} placeholders
+	Query        string // Example REST query string, without the leading "?"
+	UserIDHeader bool   // Whether the REST request identifies the caller with X-User-ID
+	Body         string // Example REST JSON request body
+	RPC          string // PostService RPC name (ConnectRPC), empty for REST-only operations
+	RPCBody      string // Example ConnectRPC request message as JSON
+}
+
+// postsOperations lists the operations of the generated posts API in README order
+var postsOperations = []apiOperation{
+	{
+		Summary:      "Create a post",
+		Handler:      "createPost",
+		Method:       "POST",
+		Path:         "/posts",
+		UserIDHeader: true,
+		Body:         `{"title": "Hello", "content": "My first post"}`,
+		RPC:          "CreatePost",
+		RPCBody:      `{"user_id": "'"$USER_ID"'", "title": "Hello", "content": "My first post"}`,
+	},
+	{
+		Summary:      "Create posts in a batch",
+		Handler:      "createPosts",
+		Method:       "POST",
+		Path:         "/posts/batch",
+		UserIDHeader: true,
+		Body:         `{"posts": [{"title": "First", "content": "One"}, {"title": "Second", "content": "Two"}]}`,
+	},
+	{
+		Summary: "Get a post",
+		Handler: "getPost",
+		Method:  "GET",
+		Path:    "/posts/{post_id}",
+		RPC:     "GetPost",
+		RPCBody: `{"post_id": "'"$POST_ID"'"}`,
+	},
+	{
+		Summary:      "List your posts",
+		Handler:      "listPosts",
+		Method:       "GET",
+		Path:         "/posts",
+		UserIDHeader: true,
+		RPC:          "ListPosts",
+		RPCBody:      `{"user_id": "'"$USER_ID"'"}`,
+	},
+	{
+		Summary:      "Search your posts by title",
+		Handler:      "searchPosts",
+		Method:       "GET",
+		Path:         "/posts/search",
+		Query:        "q=hello",
+		UserIDHeader: true,
+	},
+	{
+		Summary:      "Replace a post",
+		Handler:      "replacePost",
+		Method:       "PUT",
+		Path:         "/posts/{post_id}",
+		UserIDHeader: true,
+		Body:         `{"title": "New title", "content": "New content"}`,
+	},
+	{
+		Summary:      "Update a post",
+		Handler:      "updatePost",
+		Method:       "PATCH",
+		Path:         "/posts/{post_id}",
+		UserIDHeader: true,
+		Body:         `{"title": "Updated title"}`,
+		RPC:          "UpdatePost",
+		RPCBody:      `{"post_id": "'"$POST_ID"'", "user_id": "'"$USER_ID"'", "title": "Updated title"}`,
+	},
+	{
+		Summary:      "Delete a post",
+		Handler:      "deletePost",
+		Method:       "DELETE",
+		Path:         "/posts/{post_id}",
+		UserIDHeader: true,
+		RPC:          "DeletePost",
+		RPCBody:      `{"post_id": "'"$POST_ID"'", "user_id": "'"$USER_ID"'"}`,
+	},
+}
+
+// ShellPath returns the route path with placeholders replaced by shell variables
+// (e.g. /posts/{post_id} becomes /posts/$POST_ID)
+func (op apiOperation) ShellPath() string {
+	path := op.Path
+	for {
+		start := strings.Index(path, "{")
+		end := strings.Index(path, "}")
+		if start < 0 || end < start {
+			return path
+		}
+		path = path[:start] + "$" + strings.ToUpper(path[start+1:end]) + path[end+1:]
+	}
+}
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registeredRoutes parses a static routes file and returns the "METHOD path" registered for each handler
+func registeredRoutes(t *testing.T, path string) map[string]string {
+	t.Helper()
+
+	src, err := fs.ReadFile(GetStaticFS(), path)
+	require.NoError(t, err)
+	file, err := parser.ParseFile(token.NewFileSet(), path, src, 0)
+	require.NoError(t, err)
+
+	routes := make(map[string]string)
+	prefix := ""
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		subPath, err := strconv.Unquote(lit.Value)
+		require.NoError(t, err)
+
+		// r.Route("/posts", ...) or e.Group("/posts") sets the prefix for the handlers below
+		if sel.Sel.Name == "Route" || sel.Sel.Name == "Group" {
+			prefix = subPath
+			return true
+		}
+
+		if len(call.Args) != 2 {
+			return true
+		}
+		handlerCall, ok := call.Args[1].(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		handler, ok := handlerCall.Fun.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		routePath := strings.TrimSuffix(prefix+subPath, "/")
+		routePath = regexp.MustCompile(`:(\w+)`).ReplaceAllString(routePath, "{$1}")
+		routes[handler.Name] = strings.ToUpper(sel.Sel.Name) + " " + routePath
+		return true
+	})
+
+	return routes
+}
+
+func TestPostsOperations_MatchRouteRegistration(t *testing.T) {
+	t.Parallel()
+
+	expected := make(map[string]string)
+	for _, op := range postsOperations {
+		expected[op.Handler] = op.Method + " " + op.Path
+	}
+
+	for _, routesFile := range []string{"routes.go", "routes_gin.go", "routes_echo.go"} {
+		t.Run(routesFile, func(t *testing.T) {
+			t.Parallel()
+
+			routes := registeredRoutes(t, filepath.ToSlash(filepath.Join("static/internal/posts", routesFile)))
+			assert.Equal(t, expected, routes)
+		})
+	}
+}
+
+func TestPostsOperations_MatchProtoService(t *testing.T) {
+	t.Parallel()
+
+	src, err := fs.ReadFile(GetStaticFS(), "static/protos/posts/v1/posts.proto")
+	require.NoError(t, err)
+
+	var rpcs []string
+	for _, match := range regexp.MustCompile(`(?m)^\s*rpc (\w+)\(`).FindAllStringSubmatch(string(src), -1) {
+		rpcs = append(rpcs, match[1])
+	}
+
+	var expected []string
+	for _, op := range postsOperations {
+		if op.RPC != "" {
+			expected = append(expected, op.RPC)
+		}
+	}
+	assert.ElementsMatch(t, expected, rpcs)
+}
+
+func TestApiOperation_ShellPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/posts", apiOperation{Path: "/posts"}.ShellPath())
+	assert.Equal(t, "/posts/$POST_ID", apiOperation{Path: "/posts/{post_id}"}.ShellPath())
+}
@@ -1,6 +1,9 @@
 package generator
 
 import (
+	"context"
+	"fmt"
+	"path"
 	"path/filepath"
 )
 
@@ -10,9 +13,16 @@ type fileMapping struct {
 	templatePath string
 }
 
+// mergedFileMapping represents static Go sources of one package merged into a single output file
+type mergedFileMapping struct {
+	outputPath  string
+	sourcePaths []string
+}
+
 // fileGenerationRule defines when and what files to generate
 type fileGenerationRule struct {
 	files     []fileMapping
+	merged    []mergedFileMapping
 	condition func(*Generator) bool
 }
 
@@ -26,23 +36,115 @@ func (g *Generator) getFileGenerationRules() []fileGenerationRule {
 			{"go.mod", "templates/base/go.mod.tmpl"},
 			{"README.md", "templates/base/README.md.tmpl"},
 			{"Makefile", "templates/Makefile.tmpl"},
+			{"cmd/seed/main.go", "templates/cmd/seed/main.go.tmpl"},
 			{".gitignore", "static/.gitignore"},
 			{".dockerignore", "static/.dockerignore"},
 			{".env", "templates/.env.tmpl"},
-			{".mockery.yaml", "templates/.mockery.yaml.tmpl"},
+			{".env.example", "templates/.env.example.tmpl"},
 			// docker-compose.yml is generated in database-specific rules
+		},
+	})
+
+	// Mocks for the service tests, generated up front so the tests pass without running mockery
+	// (skipped for minimal projects)
+	if !g.config.Minimal {
+		rules = append(rules, fileGenerationRule{
+			files: []fileMapping{
+				{".mockery.yaml", "templates/.mockery.yaml.tmpl"},
+				{"internal/posts/service_test.go", "static/internal/posts/service_test.go"},
+				{"internal/posts/post_table_mock_test.go", "static/internal/posts/post_table_mock_test.go"},
+			},
+		})
+	}
+
+	// Metrics, scraped by the local Prometheus and shown in Grafana (skipped for minimal projects;
+	// the request middleware depends on the framework, and the observed posts table times
+	// database operations)
+	if g.metrics() {
+		metricsFiles := []fileMapping{
 			{"prometheus.yml", "templates/prometheus.yml.tmpl"},
 			{"grafana/provisioning/datasources/prometheus.yml", "templates/grafana/provisioning/datasources/prometheus.yml.tmpl"},
+			{"grafana/provisioning/dashboards/dashboard.yml", "templates/grafana/provisioning/dashboards/dashboard.yml.tmpl"},
+			{"grafana/dashboards/service.json", "static/grafana/dashboards/service.json"},
+			{"internal/metrics/metrics.go", "static/internal/metrics/metrics.go"},
+			{"internal/posts/observed_table.go", "static/internal/posts/observed_table.go"},
+			{"internal/posts/observed_table_test.go", "static/internal/posts/observed_table_test.go"},
+		}
+		switch g.config.Framework {
+		case FrameworkTypeChi:
+			metricsFiles = append(metricsFiles,
+				fileMapping{"internal/metrics/middleware.go", "static/internal/metrics/middleware_chi.go"},
+				fileMapping{"internal/metrics/middleware_test.go", "static/internal/metrics/middleware_chi_test.go"},
+			)
+		case FrameworkTypeGin:
+			metricsFiles = append(metricsFiles, fileMapping{"internal/metrics/middleware.go", "static/internal/metrics/middleware_gin.go"})
+		case FrameworkTypeEcho:
+			metricsFiles = append(metricsFiles, fileMapping{"internal/metrics/middleware.go", "static/internal/metrics/middleware_echo.go"})
+		case FrameworkTypeConnectRPC:
+			metricsFiles = append(metricsFiles, fileMapping{"internal/metrics/middleware.go", "static/internal/metrics/middleware_connectrpc.go"})
+		}
+		rules = append(rules, fileGenerationRule{files: metricsFiles})
+	}
+
+	// Config files (always generated; auth projects use the variants with an auth section)
+	localYAML, stagingYAML, productionYAML := "static/internal/config/local.yaml", "static/internal/config/staging.yaml", "static/internal/config/production.yaml"
+	if g.config.Auth {
+		localYAML, stagingYAML, productionYAML = "static/internal/config/local_auth.yaml", "static/internal/config/staging_auth.yaml", "static/internal/config/production_auth.yaml"
+	}
+	configRule := fileGenerationRule{
+		files: []fileMapping{
+			{"internal/config/stage.go", "static/internal/config/stage.go"},
+			{"internal/config/config_test.go", "static/internal/config/config_test.go"},
+			{"internal/config/local.yaml", localYAML},
+			{"internal/config/staging.yaml", stagingYAML},
+			{"internal/config/production.yaml", productionYAML},
 		},
-	})
+	}
+	// The static config package is split per concern; by default it is merged into config.go
+	configSources := []string{"config.go", "config_server.go", "config_secrets.go", "config_metrics.go", "config_auth.go", "config_posthog.go", "config_cors.go", "config_ratelimit.go", "config_postgres.go", "config_dynamodb.go"}
+	if g.config.SplitConfig {
+		for _, name := range configSources {
+			configRule.files = append(configRule.files, fileMapping{"internal/config/" + name, "static/internal/config/" + name})
+		}
+	} else {
+		merged := mergedFileMapping{outputPath: "internal/config/config.go"}
+		for _, name := range configSources {
+			merged.sourcePaths = append(merged.sourcePaths, "static/internal/config/"+name)
+		}
+		configRule.merged = append(configRule.merged, merged)
+	}
+	rules = append(rules, configRule)
+
+	// Load shedding (always generated; enabled by server.max_inflight)
+	loadshedFiles := []fileMapping{
+		{"internal/loadshed/loadshed.go", "static/internal/loadshed/loadshed.go"},
+		{"internal/loadshed/loadshed_test.go", "static/internal/loadshed/loadshed_test.go"},
+	}
+	if g.config.Framework == FrameworkTypeConnectRPC {
+		loadshedFiles = append(loadshedFiles, fileMapping{"internal/loadshed/interceptor.go", "static/internal/loadshed/interceptor_connectrpc.go"})
+	}
+	rules = append(rules, fileGenerationRule{files: loadshedFiles})
+
+	// Rate limiting (Chi and ConnectRPC; enabled by the rate_limit config section)
+	if g.rateLimiting() {
+		rateLimitFiles := []fileMapping{
+			{"internal/ratelimit/ratelimit.go", "static/internal/ratelimit/ratelimit.go"},
+			{"internal/ratelimit/ratelimit_test.go", "static/internal/ratelimit/ratelimit_test.go"},
+			{"internal/ratelimit/memory.go", "static/internal/ratelimit/memory.go"},
+			{"internal/ratelimit/redis.go", "static/internal/ratelimit/redis.go"},
+			{"internal/ratelimit/redis_test.go", "static/internal/ratelimit/redis_test.go"},
+		}
+		if g.config.Framework == FrameworkTypeConnectRPC {
+			rateLimitFiles = append(rateLimitFiles, fileMapping{"internal/ratelimit/interceptor.go", "static/internal/ratelimit/interceptor_connectrpc.go"})
+		}
+		rules = append(rules, fileGenerationRule{files: rateLimitFiles})
+	}
 
-	// Config files (always generated)
+	// Health checks (always generated)
 	rules = append(rules, fileGenerationRule{
 		files: []fileMapping{
-			{"internal/config/stage.go", "static/internal/config/stage.go"},
-			{"internal/config/config.go", "static/internal/config/config.go"},
-			{"internal/config/local.yaml", "static/internal/config/local.yaml"},
-			{"internal/config/production.yaml", "static/internal/config/production.yaml"},
+			{"internal/health/readiness.go", "static/internal/health/readiness.go"},
+			{"internal/health/readiness_test.go", "static/internal/health/readiness_test.go"},
 		},
 	})
 
@@ -51,27 +153,42 @@ func (g *Generator) getFileGenerationRules() []fileGenerationRule {
 		files: []fileMapping{
 			{"internal/posts/post.go", "static/internal/posts/post.go"},
 			{"internal/posts/errors.go", "static/internal/posts/errors.go"},
+			{"internal/posts/identity.go", "static/internal/posts/identity.go"},
+			{"internal/posts/identity_test.go", "static/internal/posts/identity_test.go"},
+			{"internal/posts/idempotency.go", "static/internal/posts/idempotency.go"},
 			{"internal/posts/table.go", "static/internal/posts/table.go"},
 			{"internal/posts/service.go", "static/internal/posts/service.go"},
-			{"internal/posts/service_test.go", "static/internal/posts/service_test.go"},
+			{"internal/posts/batch.go", "static/internal/posts/batch.go"},
+			{"internal/posts/batch_test.go", "static/internal/posts/batch_test.go"},
 		},
 	})
 
+	// CI workflow (independent of the deploy target; skipped for minimal projects)
+	if !g.config.Minimal {
+		rules = append(rules, fileGenerationRule{
+			files: []fileMapping{
+				{".github/workflows/ci.yml", "templates/github/workflows/ci.yml.tmpl"},
+			},
+		})
+	}
+
 	// Scripts (always generated)
 	rules = append(rules, fileGenerationRule{
 		files: []fileMapping{
 			{"scripts/check-deps.sh", "templates/scripts/check-deps.sh.tmpl"},
 			{"scripts/generate.sh", "templates/scripts/generate.sh.tmpl"},
 			{"scripts/migrate.sh", "static/scripts/migrate.sh"},
+			{"scripts/test-integration.sh", "static/scripts/test-integration.sh"},
 		},
 	})
 
-	// Deploy scripts (only if deploy option is selected)
-	if g.config.Deploy {
+	// Deploy scripts (only if deploying to Fly.io)
+	if g.hasDeployTarget(DeployTargetFly) {
 		rules = append(rules, fileGenerationRule{
 			files: []fileMapping{
 				{"scripts/deploy.sh", "templates/scripts/deploy.sh.tmpl"},
 				{"scripts/destroy.sh", "templates/scripts/destroy.sh.tmpl"},
+				{"scripts/smoke.sh", "templates/scripts/smoke.sh.tmpl"},
 			},
 		})
 	}
@@ -82,22 +199,28 @@ func (g *Generator) getFileGenerationRules() []fileGenerationRule {
 		rules = append(rules, fileGenerationRule{
 			files: []fileMapping{
 				{"internal/database/postgres.go", "static/internal/database/postgres.go"},
+				{"internal/database/postgres_test.go", "static/internal/database/postgres_test.go"},
 				{"internal/posts/postgres_table.go", "static/internal/posts/postgres_table.go"},
 				{"internal/posts/postgres_table_test.go", "static/internal/posts/postgres_table_test.go"},
 				{".env.local", "static/.env.local.postgres"},
-				{"docker-compose.yml", "static/docker-compose.yml.postgres"},
-				{"schema.sql", "static/schema.sql"},
+				{"docker-compose.yml", "templates/docker-compose.yml.postgres.tmpl"},
+				{"schema.sql", "templates/schema.sql.tmpl"},
+				{"atlas.hcl", "templates/atlas.hcl.tmpl"},
+				{"migrations/0001_create_posts.sql", "templates/database/migrations/initial.sql.tmpl"},
+				{"migrations/README.md", "templates/database/migrations/README.md.tmpl"},
 			},
 		})
 	case DatabaseTypeDynamoDB:
 		rules = append(rules, fileGenerationRule{
 			files: []fileMapping{
 				{"internal/database/dynamodb.go", "static/internal/database/dynamodb.go"},
+				{"internal/database/dynamodb_test.go", "static/internal/database/dynamodb_test.go"},
 				{"internal/posts/dynamodb_table.go", "static/internal/posts/dynamodb_table.go"},
 				{"internal/posts/dynamodb_table_test.go", "static/internal/posts/dynamodb_table_test.go"},
+				{"internal/posts/dynamodb_consistency_test.go", "static/internal/posts/dynamodb_consistency_test.go"},
 				{"internal/posts/dynamodb_converters.go", "static/internal/posts/dynamodb_converters.go"},
 				{".env.local", "templates/.env.local.dynamodb.tmpl"},
-				{"docker-compose.yml", "static/docker-compose.yml.dynamodb"},
+				{"docker-compose.yml", "templates/docker-compose.yml.dynamodb.tmpl"},
 			},
 		})
 	}
@@ -108,107 +231,480 @@ func (g *Generator) getFileGenerationRules() []fileGenerationRule {
 		rules = append(rules, fileGenerationRule{
 			files: []fileMapping{
 				{"cmd/api/main.go", "templates/cmd/api/main_chi.go.tmpl"},
+				{"internal/api/logging.go", "static/internal/api/logging.go"},
+				{"internal/api/logging_test.go", "static/internal/api/logging_test.go"},
+				{"internal/api/body_limit.go", "static/internal/api/body_limit.go"},
+				{"internal/api/body_limit_test.go", "static/internal/api/body_limit_test.go"},
 				{"internal/posts/routes.go", "static/internal/posts/routes.go"},
+				{"internal/posts/routes_test.go", "static/internal/posts/routes_test.go"},
+				{"internal/posts/caching.go", "static/internal/posts/caching.go"},
+				{"internal/posts/caching_test.go", "static/internal/posts/caching_test.go"},
+				{"openapi.yaml", "templates/openapi.yaml.tmpl"},
+			},
+		})
+		if g.config.GRPC {
+			rules = append(rules, fileGenerationRule{files: connectRPCServiceFiles})
+		}
+	case FrameworkTypeGin:
+		rules = append(rules, fileGenerationRule{
+			files: []fileMapping{
+				{"cmd/api/main.go", "templates/cmd/api/main_gin.go.tmpl"},
+				{"internal/posts/routes.go", "static/internal/posts/routes_gin.go"},
+				{"internal/posts/caching.go", "static/internal/posts/caching.go"},
+				{"internal/posts/caching_test.go", "static/internal/posts/caching_test.go"},
+				{"openapi.yaml", "templates/openapi.yaml.tmpl"},
+			},
+		})
+	case FrameworkTypeEcho:
+		rules = append(rules, fileGenerationRule{
+			files: []fileMapping{
+				{"cmd/api/main.go", "templates/cmd/api/main_echo.go.tmpl"},
+				{"internal/posts/routes.go", "static/internal/posts/routes_echo.go"},
+				{"internal/posts/caching.go", "static/internal/posts/caching.go"},
+				{"internal/posts/caching_test.go", "static/internal/posts/caching_test.go"},
+				{"openapi.yaml", "templates/openapi.yaml.tmpl"},
 			},
 		})
 	case FrameworkTypeConnectRPC:
 		rules = append(rules, fileGenerationRule{
 			files: []fileMapping{
 				{"cmd/api/main.go", "templates/cmd/api/main_connectrpc.go.tmpl"},
-				{"internal/api/posts_handler.go", "static/internal/api/posts_handler_connectrpc.go"},
-				{"internal/posts/converters.go", "templates/internal/posts/converters.go.tmpl"},
-				{"internal/protos/posts/v1/posts.proto", "static/protos/posts/v1/posts.proto"},
-				{"buf.yaml", "static/buf.yaml"},
-				{"buf.gen.yaml", "templates/buf.gen.yaml.tmpl"},
+				{"internal/api/logging.go", "static/internal/api/logging.go"},
+				{"internal/api/logging_test.go", "static/internal/api/logging_test.go"},
+				{"internal/api/timeout.go", "static/internal/api/timeout_connectrpc.go"},
 			},
 		})
+		rules = append(rules, fileGenerationRule{files: connectRPCServiceFiles})
+	}
+
+	// Request body decoding for the REST handlers, validated against JSON schemas when enabled
+	if g.requestValidation() {
+		schemasDir := path.Join(g.packageDir("internal/posts"), "schemas")
+		rules = append(rules, fileGenerationRule{
+			files: []fileMapping{
+				{"internal/posts/validation.go", "static/internal/posts/validation.go"},
+				{"internal/posts/validation_test.go", "static/internal/posts/validation_test.go"},
+				{path.Join(schemasDir, "create_post_request.json"), "static/internal/posts/schemas/create_post_request.json"},
+				{path.Join(schemasDir, "update_post_request.json"), "static/internal/posts/schemas/update_post_request.json"},
+			},
+		})
+	} else if g.config.Framework != FrameworkTypeConnectRPC {
+		rules = append(rules, fileGenerationRule{
+			files: []fileMapping{
+				{"internal/posts/validation.go", "static/internal/posts/validation_none.go"},
+			},
+		})
+	}
+
+	// JWT authentication and the demo token endpoint (the middleware and handler depend on the framework)
+	if g.config.Auth {
+		files := []fileMapping{
+			{"internal/auth/jwt.go", "static/internal/auth/jwt.go"},
+			{"internal/auth/jwt_test.go", "static/internal/auth/jwt_test.go"},
+			{"internal/auth/token.go", "static/internal/auth/token.go"},
+		}
+		switch g.config.Framework {
+		case FrameworkTypeChi:
+			files = append(files,
+				fileMapping{"internal/auth/middleware.go", "static/internal/auth/middleware_chi.go"},
+				fileMapping{"internal/auth/middleware_test.go", "static/internal/auth/middleware_chi_test.go"},
+				fileMapping{"internal/auth/routes.go", "static/internal/auth/routes_chi.go"},
+				fileMapping{"internal/auth/routes_test.go", "static/internal/auth/routes_chi_test.go"},
+			)
+		case FrameworkTypeGin:
+			files = append(files,
+				fileMapping{"internal/auth/middleware.go", "static/internal/auth/middleware_gin.go"},
+				fileMapping{"internal/auth/routes.go", "static/internal/auth/routes_gin.go"},
+			)
+		case FrameworkTypeEcho:
+			files = append(files,
+				fileMapping{"internal/auth/middleware.go", "static/internal/auth/middleware_echo.go"},
+				fileMapping{"internal/auth/routes.go", "static/internal/auth/routes_echo.go"},
+			)
+		case FrameworkTypeConnectRPC:
+			files = append(files,
+				fileMapping{"internal/auth/middleware.go", "static/internal/auth/middleware_connectrpc.go"},
+				fileMapping{"internal/auth/token_handler.go", "static/internal/auth/token_handler_connectrpc.go"},
+				fileMapping{"internal/protos/auth/v1/auth.proto", "static/protos/auth/v1/auth.proto"},
+			)
+		}
+		rules = append(rules, fileGenerationRule{files: files})
+	}
+
+	// Typed Go client for the REST API, tested against the generated routes
+	if g.restClient() {
+		files := []fileMapping{
+			{"client/client.go", "static/client/client.go"},
+			{"client/client_test.go", "static/client/client_test.go"},
+		}
+		switch g.config.Framework {
+		case FrameworkTypeChi:
+			files = append(files, fileMapping{"client/server_test.go", "static/client/server_chi_test.go"})
+		case FrameworkTypeGin:
+			files = append(files, fileMapping{"client/server_test.go", "static/client/server_gin_test.go"})
+		case FrameworkTypeEcho:
+			files = append(files, fileMapping{"client/server_test.go", "static/client/server_echo_test.go"})
+		}
+		rules = append(rules, fileGenerationRule{files: files})
 	}
 
-	// Deployment files
+	// Container image (shared by all deploy targets)
 	if g.config.Deploy {
+		rules = append(rules, fileGenerationRule{
+			files: []fileMapping{
+				{"Dockerfile", "templates/Dockerfile.tmpl"},
+			},
+		})
+	}
+
+	// Kubernetes manifests
+	if g.hasDeployTarget(DeployTargetKubernetes) {
+		rules = append(rules, fileGenerationRule{
+			files: []fileMapping{
+				{"k8s/configmap.yaml", "templates/deploy/k8s/configmap.yaml.tmpl"},
+				{"k8s/deployment.yaml", "templates/deploy/k8s/deployment.yaml.tmpl"},
+				{"k8s/service.yaml", "templates/deploy/k8s/service.yaml.tmpl"},
+				{"k8s/ingress.yaml", "templates/deploy/k8s/ingress.yaml.tmpl"},
+			},
+		})
+	}
+
+	// AWS ECS Fargate infrastructure (Terraform)
+	if g.hasDeployTarget(DeployTargetECS) {
+		files := []fileMapping{
+			{"terraform/main.tf", "templates/deploy/terraform/main.tf.tmpl"},
+			{"terraform/ecr.tf", "templates/deploy/terraform/ecr.tf.tmpl"},
+			{"terraform/ecs.tf", "templates/deploy/terraform/ecs.tf.tmpl"},
+			{"terraform/alb.tf", "templates/deploy/terraform/alb.tf.tmpl"},
+			{"terraform/iam.tf", "templates/deploy/terraform/iam.tf.tmpl"},
+			{"terraform/outputs.tf", "templates/deploy/terraform/outputs.tf.tmpl"},
+			{"scripts/deploy-ecs.sh", "templates/scripts/deploy-ecs.sh.tmpl"},
+			{"scripts/destroy-ecs.sh", "templates/scripts/destroy-ecs.sh.tmpl"},
+		}
+		if g.config.Database.Type == DatabaseTypeDynamoDB {
+			files = append(files, fileMapping{"terraform/dynamodb.tf", "templates/deploy/terraform/dynamodb.tf.tmpl"})
+		}
+		rules = append(rules, fileGenerationRule{files: files})
+	} else if g.terraformManagedTable() {
+		// Standalone Terraform for the DynamoDB table when not deploying to ECS
+		rules = append(rules, fileGenerationRule{
+			files: []fileMapping{
+				{"terraform/main.tf", "templates/deploy/terraform/main.tf.tmpl"},
+				{"terraform/dynamodb.tf", "templates/deploy/terraform/dynamodb.tf.tmpl"},
+			},
+		})
+	}
+
+	// Railway service config (builds the Dockerfile)
+	if g.hasDeployTarget(DeployTargetRailway) {
+		rules = append(rules, fileGenerationRule{
+			files: []fileMapping{
+				{"railway.json", "templates/deploy/railway.json.tmpl"},
+			},
+		})
+	}
+
+	// Render Blueprint (builds the Dockerfile)
+	if g.hasDeployTarget(DeployTargetRender) {
+		rules = append(rules, fileGenerationRule{
+			files: []fileMapping{
+				{"render.yaml", "templates/deploy/render.yaml.tmpl"},
+			},
+		})
+	}
+
+	// Fly.io deployment files
+	if g.hasDeployTarget(DeployTargetFly) {
 		rules = append(rules, fileGenerationRule{
 			files: []fileMapping{
 				{"fly.toml", "templates/deploy/fly.toml.tmpl"},
-				{"Dockerfile", "static/Dockerfile"},
 				{".github/workflows/deploy.yml", "templates/deploy/github/workflows/deploy.yml.tmpl"},
 			},
-			condition: func(g *Generator) bool {
-				// Create .github/workflows directory if needed
-				_ = g.fs.MkdirAll(filepath.Join(g.config.OutputDir, ".github", "workflows"), 0755)
-				return true
-			},
 		})
 	}
 
 	return rules
 }
 
-// awsRegionToFlyRegion maps AWS regions to Fly.io regions
-// This ensures DynamoDB tables are in the same region as the Fly.io deployment
-func awsRegionToFlyRegion(awsRegion string) string {
-	// Map AWS regions to Fly.io regions
-	// Fly.io regions are typically 3-letter codes
+// connectRPCServiceFiles are the proto definitions and Connect handler for the posts service,
+// generated for ConnectRPC projects and for chi projects that also serve gRPC
+var connectRPCServiceFiles = []fileMapping{
+	{"internal/api/posts_handler.go", "static/internal/api/posts_handler_connectrpc.go"},
+	{"internal/posts/converters.go", "templates/internal/posts/converters.go.tmpl"},
+	{"internal/protos/posts/v1/posts.proto", "static/protos/posts/v1/posts.proto"},
+	{"buf.yaml", "static/buf.yaml"},
+	{"buf.gen.yaml", "templates/buf.gen.yaml.tmpl"},
+}
+
+// hasConnectRPC reports whether the project serves the posts API with ConnectRPC handlers,
+// either as its framework or alongside chi's REST routes
+func (g *Generator) hasConnectRPC() bool {
+	return g.config.Framework == FrameworkTypeConnectRPC || g.config.GRPC
+}
+
+// hasDeployTarget reports whether deployment files should be generated for target
+func (g *Generator) hasDeployTarget(target DeployTarget) bool {
+	if !g.config.Deploy {
+		return false
+	}
+	if len(g.config.DeployTargets) == 0 {
+		return target == DeployTargetFly
+	}
+	for _, t := range g.config.DeployTargets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+// requestValidation reports whether REST request bodies are validated against JSON schemas
+// (ConnectRPC requests are typed by the proto definitions instead)
+func (g *Generator) requestValidation() bool {
+	return g.config.RequestValidation && g.config.Framework != FrameworkTypeConnectRPC
+}
+
+// rateLimiting reports whether the rate limiter package is generated; Gin and Echo projects
+// don't apply the rate_limit config section
+func (g *Generator) rateLimiting() bool {
+	return g.config.Framework == FrameworkTypeChi || g.config.Framework == FrameworkTypeConnectRPC
+}
+
+// metrics reports whether the Prometheus metrics package, its middleware and the local
+// Prometheus and Grafana services are generated
+func (g *Generator) metrics() bool {
+	return !g.config.Minimal
+}
+
+// restClient reports whether the typed REST client package is generated
+// (ConnectRPC services already get a typed client from buf generate)
+func (g *Generator) restClient() bool {
+	return g.config.Client && g.config.Framework != FrameworkTypeConnectRPC
+}
+
+// FlyRegions are the Fly.io region codes a project can be deployed to (see `fly platform regions`)
+var FlyRegions = []string{
+	"ams", "arn", "atl", "bog", "bom", "bos", "cdg", "den", "dfw", "ewr", "eze", "fra",
+	"gdl", "gig", "gru", "hkg", "iad", "jnb", "lax", "lhr", "mad", "mia", "nrt", "ord",
+	"otp", "phx", "qro", "scl", "sea", "sin", "sjc", "syd", "waw", "yul", "yyz",
+}
+
+// awsRegionToFlyRegion maps AWS regions to the nearest Fly.io region
+// This ensures DynamoDB tables are in the same region as the Fly.io deployment.
+// Unknown regions fall back to iad (Washington, DC) with ok set to false, so callers
+// can warn that the app may be deployed far from its table.
+func awsRegionToFlyRegion(awsRegion string) (flyRegion string, ok bool) {
+	// Fly.io regions are 3-letter codes, mostly named after the nearest airport
 	regionMap := map[string]string{
-		// US East
-		"us-east-1":      "iad", // Washington, DC
-		"us-east-2":      "ord", // Chicago
-		// US West
-		"us-west-1":      "sea", // Seattle
-		"us-west-2":      "dfw", // Dallas
+		// US
+		"us-east-1":     "iad", // N. Virginia -> Washington, DC
+		"us-east-2":     "ord", // Ohio -> Chicago
+		"us-west-1":     "sjc", // N. California -> San Jose
+		"us-west-2":     "sea", // Oregon -> Seattle
+		"us-gov-east-1": "iad", // GovCloud (US-East) -> Washington, DC
+		"us-gov-west-1": "sea", // GovCloud (US-West) -> Seattle
+		// Canada and Mexico
+		"ca-central-1": "yul", // Montreal
+		"ca-west-1":    "sea", // Calgary -> Seattle
+		"mx-central-1": "qro", // Querétaro
 		// Europe
-		"eu-west-1":      "ams", // Amsterdam
-		"eu-west-2":      "lhr", // London
-		"eu-west-3":      "cdg", // Paris
-		"eu-central-1":   "fra", // Frankfurt
-		"eu-north-1":     "arn", // Stockholm
+		"eu-west-1":    "lhr", // Ireland -> London
+		"eu-west-2":    "lhr", // London
+		"eu-west-3":    "cdg", // Paris
+		"eu-central-1": "fra", // Frankfurt
+		"eu-central-2": "fra", // Zurich -> Frankfurt
+		"eu-south-1":   "fra", // Milan -> Frankfurt
+		"eu-south-2":   "mad", // Spain -> Madrid
+		"eu-north-1":   "arn", // Stockholm
+		// Middle East and Africa
+		"il-central-1": "otp", // Tel Aviv -> Bucharest
+		"me-south-1":   "bom", // Bahrain -> Mumbai
+		"me-central-1": "bom", // UAE -> Mumbai
+		"af-south-1":   "jnb", // Cape Town -> Johannesburg
 		// Asia Pacific
+		"ap-east-1":      "hkg", // Hong Kong
+		"ap-east-2":      "hkg", // Taipei -> Hong Kong
+		"ap-south-1":     "bom", // Mumbai
+		"ap-south-2":     "bom", // Hyderabad -> Mumbai
 		"ap-southeast-1": "sin", // Singapore
 		"ap-southeast-2": "syd", // Sydney
+		"ap-southeast-3": "sin", // Jakarta -> Singapore
+		"ap-southeast-4": "syd", // Melbourne -> Sydney
+		"ap-southeast-5": "sin", // Malaysia -> Singapore
+		"ap-southeast-7": "sin", // Thailand -> Singapore
 		"ap-northeast-1": "nrt", // Tokyo
-		"ap-south-1":     "bom", // Mumbai
+		"ap-northeast-2": "nrt", // Seoul -> Tokyo
+		"ap-northeast-3": "nrt", // Osaka -> Tokyo
 		// South America
-		"sa-east-1":      "gru", // São Paulo
+		"sa-east-1": "gru", // São Paulo
 	}
 
 	if flyRegion, ok := regionMap[awsRegion]; ok {
-		return flyRegion
+		return flyRegion, true
 	}
 
 	// Default to iad (Washington, DC) if region not found
+	return "iad", false
+}
+
+// FlyRegion returns the Fly.io primary region: the configured region if set, otherwise
+// the region nearest the DynamoDB table's AWS region, defaulting to iad
+func (g *Generator) FlyRegion() string {
+	if g.config.FlyRegion != "" {
+		return g.config.FlyRegion
+	}
+	if g.config.Database.Type == DatabaseTypeDynamoDB && g.config.Database.AWSRegion != "" {
+		flyRegion, _ := awsRegionToFlyRegion(g.config.Database.AWSRegion)
+		return flyRegion
+	}
 	return "iad"
 }
 
+// DefaultGoVersion is the Go version generated projects target when the generator's own
+// toolchain version can't be used (see hostGoVersion)
+const DefaultGoVersion = "1.25"
+
+// DefaultPort is the port the generated server listens on unless ProjectConfig.Port is set
+const DefaultPort = "8080"
+
+// composePorts are the host ports the generated docker-compose.yml publishes for the database, by service
+var composePorts = map[DatabaseType]map[string]string{
+	DatabaseTypePostgres: {"5432": "Postgres"},
+	DatabaseTypeDynamoDB: {"8000": "DynamoDB Local"},
+}
+
+// metricsComposePorts are the host ports docker-compose.yml publishes for Prometheus and Grafana
+var metricsComposePorts = map[string]string{"9090": "Prometheus", "3000": "Grafana"}
+
+// Port returns the port the server listens on: the configured port, or DefaultPort
+func (g *Generator) Port() string {
+	if g.config.Port != "" {
+		return g.config.Port
+	}
+	return DefaultPort
+}
+
+// Warnings returns problems with the configuration that don't prevent generation
+// but that the user should know about, e.g. an AWS region without a nearby Fly.io region
+func (g *Generator) Warnings() []string {
+	var warnings []string
+	if g.hasDeployTarget(DeployTargetFly) && g.config.FlyRegion == "" &&
+		g.config.Database.Type == DatabaseTypeDynamoDB && g.config.Database.AWSRegion != "" {
+		if flyRegion, ok := awsRegionToFlyRegion(g.config.Database.AWSRegion); !ok {
+			warnings = append(warnings, fmt.Sprintf("AWS region %s has no known Fly.io equivalent; the app will be deployed to %s, which may be far from the DynamoDB table (choose a Fly.io region explicitly to change it)", g.config.Database.AWSRegion, flyRegion))
+		}
+	}
+	service, ok := composePorts[g.config.Database.Type][g.Port()]
+	if !ok && g.metrics() {
+		service, ok = metricsComposePorts[g.Port()]
+	}
+	if ok {
+		warnings = append(warnings, fmt.Sprintf("port %s is also used by %s in docker-compose.yml, so the service can't start locally while it runs (choose another port)", g.Port(), service))
+	}
+	if g.staticAWSCredentials() {
+		warnings = append(warnings, "AWS access keys will be written to .env.local in plaintext; don't commit it (the default, profile credentials, references AWS_PROFILE instead)")
+	}
+	return warnings
+}
+
+// staticAWSCredentials reports whether AWS keys are written to .env.local instead of
+// referencing a profile
+func (g *Generator) staticAWSCredentials() bool {
+	return g.config.Database.Type == DatabaseTypeDynamoDB &&
+		g.config.Database.AWSCredentials == AWSCredentialsStatic &&
+		g.config.Database.AWSAccessKeyID != ""
+}
+
+// flyAppName returns the Fly.io app name used by fly.toml and the deploy scripts
+func (g *Generator) flyAppName() string {
+	return g.config.ProjectName
+}
+
+// flyAppURL returns the public URL Fly.io serves the app at
+func (g *Generator) flyAppURL() string {
+	return "https://" + g.flyAppName() + ".fly.dev"
+}
+
+// terraformManagedTable reports whether the DynamoDB table is defined in Terraform
+// instead of being created by the application at startup
+func (g *Generator) terraformManagedTable() bool {
+	return g.config.Database.Type == DatabaseTypeDynamoDB &&
+		g.config.Database.TableProvisioning != TableProvisioningRuntime
+}
+
 // getTemplateData returns the data structure for template execution
 func (g *Generator) getTemplateData() map[string]interface{} {
-	// Determine Fly.io region based on AWS region if DynamoDB is selected
-	flyRegion := "iad" // Default
-	if g.config.Database.Type == DatabaseTypeDynamoDB && g.config.Database.AWSRegion != "" {
-		flyRegion = awsRegionToFlyRegion(g.config.Database.AWSRegion)
+	// AWS region for Terraform-managed infrastructure, co-located with DynamoDB when set
+	awsRegion := "us-east-1" // Default
+	if g.config.Database.AWSRegion != "" {
+		awsRegion = g.config.Database.AWSRegion
+	}
+
+	// Keys only reach the templates when static credentials were asked for explicitly
+	var awsAccessKeyID, awsSecretKey string
+	if g.staticAWSCredentials() {
+		awsAccessKeyID, awsSecretKey = g.config.Database.AWSAccessKeyID, g.config.Database.AWSSecretKey
 	}
 
 	return map[string]interface{}{
 		"ProjectName": g.config.ProjectName,
 		"ModulePath":  g.config.ModulePath,
+		"GoVersion":   g.GoVersion(), // The go directive in go.mod, which CI's setup-go reads
 		"Database": map[string]interface{}{
 			"Type":           string(g.config.Database.Type),
-			"AWSAccessKeyID": g.config.Database.AWSAccessKeyID,
-			"AWSSecretKey":   g.config.Database.AWSSecretKey,
+			"AWSAccessKeyID": awsAccessKeyID,
+			"AWSSecretKey":   awsSecretKey,
 			"AWSRegion":      g.config.Database.AWSRegion,
+			"AWSProfile":     g.config.Database.AWSProfile,
 		},
 		"Framework":    string(g.config.Framework),
 		"HasPostgres":  g.config.Database.Type == DatabaseTypePostgres,
 		"HasDynamoDB":  g.config.Database.Type == DatabaseTypeDynamoDB,
 		"HasChi":       g.config.Framework == FrameworkTypeChi,
-		"HasConnectRPC": g.config.Framework == FrameworkTypeConnectRPC,
-		"HasGRPC":      g.config.Framework == FrameworkTypeConnectRPC,
+		"HasGin":       g.config.Framework == FrameworkTypeGin,
+		"HasEcho":      g.config.Framework == FrameworkTypeEcho,
+		"HasREST":      g.config.Framework != FrameworkTypeConnectRPC,
+		"HasConnectRPC": g.hasConnectRPC(),
+		"HasGRPC":      g.hasConnectRPC(),
+		"GRPC":         g.config.GRPC,
+		"RateLimit":    g.rateLimiting(),
+		"Metrics":      g.metrics(),
+		"Minimal":      g.config.Minimal,
 		"Deploy":       g.config.Deploy,
-		"FlyRegion":    flyRegion,
+		"DeployFly":    g.hasDeployTarget(DeployTargetFly),
+		"DeployKubernetes": g.hasDeployTarget(DeployTargetKubernetes),
+		"DeployECS":    g.hasDeployTarget(DeployTargetECS),
+		"DeployRailway": g.hasDeployTarget(DeployTargetRailway),
+		"DeployRender":  g.hasDeployTarget(DeployTargetRender),
+		"AWSRegion":    awsRegion,
+		"Port":         g.Port(), // Matches server.port in the stage config files
+		"HealthPath":   "/health", // Matches server.health_path in the config YAML
+		"ReadyPath":    "/ready",  // Matches server.ready_path in the config YAML
+		"MetricsPath":  "/metrics", // Matches metrics.path in the config YAML
+		"FlyRegion":    g.FlyRegion(),
+		"FlyAppName":   g.flyAppName(),
+		"FlyAppURL":    g.flyAppURL(),
+		"PostsPackage": g.packageDir("internal/posts"),
+		"DynamoDBTerraform": g.terraformManagedTable(),
+		"PostgresIndexes": g.postgresIndexes(),
+		"RequestValidation": g.requestValidation(),
+		"Auth":              g.config.Auth,
+		"Client":            g.config.Client,
+		"APIPrefix":         g.config.APIPrefix,
+		"Arch":              string(g.resolvedArch()),
+		"MultiArch":         g.resolvedArch() == ArchBoth,
+		"Platforms":         g.imagePlatforms(),
+		"ECSCPUArchitecture": g.ecsCPUArchitecture(),
+		"Operations":   postsOperations,
 	}
 }
 
 // generateFiles generates multiple files from a list of mappings
-func (g *Generator) generateFiles(files []fileMapping, data interface{}) error {
+func (g *Generator) generateFiles(ctx context.Context, files []fileMapping, data interface{}) error {
 	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		// Check if this is a static file (no .tmpl extension) that should be copied without template processing
 		if filepath.Ext(file.templatePath) != ".tmpl" {
 			if err := g.copyFile(file.outputPath, file.templatePath); err != nil {
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// recordUndo registers an action that reverts a change made to the filesystem. If
+// Generate fails, the actions run in reverse order to leave the output as it was found.
+func (g *Generator) recordUndo(undo func() error) {
+	g.undo = append(g.undo, undo)
+}
+
+// rollback reverts every change recorded during generation, newest first
+func (g *Generator) rollback() error {
+	var errs []error
+	for i := len(g.undo) - 1; i >= 0; i-- {
+		if err := g.undo[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	g.undo = nil
+	g.createdDirs = nil
+	return errors.Join(errs...)
+}
+
+// mkdirAll creates path and any missing parents, recording the directories that didn't
+// exist before so a rollback removes them (and only them). Directories are tracked in
+// createdDirs since a filesystem may not report intermediate directories as existing.
+func (g *Generator) mkdirAll(path string, perm os.FileMode) error {
+	var missing []string
+	for dir := filepath.Clean(path); !g.createdDirs[dir]; dir = filepath.Dir(dir) {
+		exists, err := fileExists(g.fs, dir)
+		if err != nil {
+			return err
+		}
+		if exists {
+			break
+		}
+		missing = append(missing, dir)
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+
+	if err := g.fs.MkdirAll(path, perm); err != nil {
+		return err
+	}
+
+	if g.createdDirs == nil {
+		g.createdDirs = make(map[string]bool)
+	}
+	// Parents are recorded first so they're removed after their children
+	for i := len(missing) - 1; i >= 0; i-- {
+		dir := missing[i]
+		g.createdDirs[dir] = true
+		g.recordUndo(func() error {
+			// An in-memory filesystem only tracks intermediate directories implicitly
+			if err := g.fs.Remove(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to remove directory %s: %w", dir, err)
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+// recordFileWrite records how to revert writing name: a file that didn't exist is removed,
+// one that did gets its previous content and permissions back
+func (g *Generator) recordFileWrite(name string, existed bool, existing []byte, perm os.FileMode) {
+	if !existed {
+		g.recordUndo(func() error {
+			if err := g.fs.Remove(name); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", name, err)
+			}
+			return nil
+		})
+		return
+	}
+	g.recordUndo(func() error {
+		if err := g.fs.WriteFile(name, existing, perm); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// recordRename records how to revert renaming oldpath to newpath
+func (g *Generator) recordRename(oldpath, newpath string) {
+	g.recordUndo(func() error {
+		if err := g.fs.Rename(newpath, oldpath); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", oldpath, err)
+		}
+		return nil
+	})
+}
+
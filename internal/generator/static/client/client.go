@@ -0,0 +1,253 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotFound matches (via errors.Is) errors for requests the API answered with 404
+var ErrNotFound error = errors.New("not found")
+
+// ErrForbidden matches (via errors.Is) errors for requests the API answered with 403,
+// such as updating or deleting another user's post
+var ErrForbidden error = errors.New("forbidden")
+
+// Post is a post as returned by the API
+type Post struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PostInput is a single post to create in a batch
+type PostInput struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// BatchResult reports the outcome of one item in a batch; results are in request order
+type BatchResult struct {
+	Index  int    `json:"index"`
+	Status int    `json:"status"`
+	Post   *Post  `json:"post,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// FieldError describes a single invalid field in a rejected request body
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is returned when the API responds with a non-2xx status
+type Error struct {
+	StatusCode int
+	Code       string // stable error code, e.g. POST_NOT_FOUND
+	Message    string
+	Details    []FieldError
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("api error (status %d, %s): %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("api error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether the error matches ErrNotFound or ErrForbidden
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// Client calls the posts API over HTTP
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	userID     uuid.UUID
+}
+
+// Option configures optional client behavior
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests (defaults to http.DefaultClient)
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserID sets the user the client acts as, sent in the X-User-ID header
+func WithUserID(userID uuid.UUID) Option {
+	return func(c *Client) {
+		c.userID = userID
+	}
+}
+
+// New creates a client for the API at baseURL (e.g. http://localhost:8080)
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CreatePost creates a post owned by the client's user
+func (c *Client) CreatePost(ctx context.Context, title, content string) (*Post, error) {
+	var post Post
+	body := PostInput{Title: title, Content: content}
+	if err := c.do(ctx, http.MethodPost, "/posts", body, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// CreatePosts creates posts owned by the client's user in one request.
+// Items succeed or fail independently; check each result's Status.
+func (c *Client) CreatePosts(ctx context.Context, inputs []PostInput) ([]BatchResult, error) {
+	var resp struct {
+		Results []BatchResult `json:"results"`
+	}
+	body := struct {
+		Posts []PostInput `json:"posts"`
+	}{Posts: inputs}
+	if err := c.do(ctx, http.MethodPost, "/posts/batch", body, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// GetPost retrieves a post by ID
+func (c *Client) GetPost(ctx context.Context, postID uuid.UUID) (*Post, error) {
+	var post Post
+	if err := c.do(ctx, http.MethodGet, "/posts/"+postID.String(), nil, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// ListUserPosts lists the posts of userID, newest first
+func (c *Client) ListUserPosts(ctx context.Context, userID uuid.UUID) ([]Post, error) {
+	var posts []Post
+	query := url.Values{"user_id": {userID.String()}}
+	if err := c.do(ctx, http.MethodGet, "/posts?"+query.Encode(), nil, &posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// SearchPosts returns the client's user's posts whose title contains query, newest first
+func (c *Client) SearchPosts(ctx context.Context, query string) ([]Post, error) {
+	var posts []Post
+	params := url.Values{"q": {query}}
+	if err := c.do(ctx, http.MethodGet, "/posts/search?"+params.Encode(), nil, &posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// ReplacePost replaces a post's title and content
+func (c *Client) ReplacePost(ctx context.Context, postID uuid.UUID, title, content string) (*Post, error) {
+	var post Post
+	body := PostInput{Title: title, Content: content}
+	if err := c.do(ctx, http.MethodPut, "/posts/"+postID.String(), body, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// UpdatePost partially updates a post: nil fields are left unchanged
+func (c *Client) UpdatePost(ctx context.Context, postID uuid.UUID, title, content *string) (*Post, error) {
+	var post Post
+	body := struct {
+		Title   *string `json:"title,omitempty"`
+		Content *string `json:"content,omitempty"`
+	}{Title: title, Content: content}
+	if err := c.do(ctx, http.MethodPatch, "/posts/"+postID.String(), body, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// DeletePost deletes a post by ID
+func (c *Client) DeletePost(ctx context.Context, postID uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, "/posts/"+postID.String(), nil, nil)
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response into out (if non-nil).
+// Non-2xx responses are returned as *Error.
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.userID != uuid.Nil {
+		req.Header.Set("X-User-ID", c.userID.String())
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		var errResp struct {
+			Error struct {
+				Code    string       `json:"code"`
+				Message string       `json:"message"`
+				Details []FieldError `json:"details"`
+			} `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil && errResp.Error.Code != "" {
+			apiErr.Code = errResp.Error.Code
+			apiErr.Message = errResp.Error.Message
+			apiErr.Details = errResp.Error.Details
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
@@ -0,0 +1,243 @@
+//go:build ignore
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acme/postservice/internal/posts"
+)
+
+// memoryService is an in-memory posts.Service backing the test server
+type memoryService struct {
+	mu    sync.Mutex
+	posts map[uuid.UUID]posts.Post
+}
+
+func newMemoryService() *memoryService {
+	return &memoryService{posts: make(map[uuid.UUID]posts.Post)}
+}
+
+func (s *memoryService) CreatePost(ctx context.Context, userID uuid.UUID, title, content string) (*posts.Post, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	post := posts.NewPost(userID, title, content)
+	s.posts[post.ID] = *post
+	return post, nil
+}
+
+func (s *memoryService) GetPost(ctx context.Context, postID uuid.UUID) (*posts.Post, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	post, ok := s.posts[postID]
+	if !ok {
+		return nil, posts.ErrPostNotFound
+	}
+	return &post, nil
+}
+
+func (s *memoryService) ListUserPosts(ctx context.Context, userID uuid.UUID) ([]posts.Post, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	userPosts := []posts.Post{}
+	for _, post := range s.posts {
+		if post.UserID == userID {
+			userPosts = append(userPosts, post)
+		}
+	}
+	return userPosts, nil
+}
+
+func (s *memoryService) SearchPosts(ctx context.Context, userID uuid.UUID, query string) ([]posts.Post, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, posts.ErrEmptySearchQuery
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	matches := []posts.Post{}
+	for _, post := range s.posts {
+		if post.UserID == userID && strings.Contains(post.Title, query) {
+			matches = append(matches, post)
+		}
+	}
+	return matches, nil
+}
+
+func (s *memoryService) UpdatePost(ctx context.Context, userID, postID uuid.UUID, title, content *string) (*posts.Post, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	post, ok := s.posts[postID]
+	if !ok {
+		return nil, posts.ErrPostNotFound
+	}
+	if post.UserID != userID {
+		return nil, posts.ErrForbidden
+	}
+	if title != nil {
+		post.Title = *title
+	}
+	if content != nil {
+		post.Content = *content
+	}
+	s.posts[postID] = post
+	return &post, nil
+}
+
+func (s *memoryService) DeletePost(ctx context.Context, userID, postID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	post, ok := s.posts[postID]
+	if !ok {
+		return posts.ErrPostNotFound
+	}
+	if post.UserID != userID {
+		return posts.ErrForbidden
+	}
+	delete(s.posts, postID)
+	return nil
+}
+
+func (s *memoryService) CreatePosts(ctx context.Context, userID uuid.UUID, inputs []posts.PostInput, dedupe bool) ([]posts.BatchResult, error) {
+	results := make([]posts.BatchResult, len(inputs))
+	for i, input := range inputs {
+		results[i].Index = i
+		results[i].Post, results[i].Err = s.CreatePost(ctx, userID, input.Title, input.Content)
+	}
+	return results, nil
+}
+
+func (s *memoryService) GetPostsByIDs(ctx context.Context, postIDs []uuid.UUID) ([]posts.Post, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	found := []posts.Post{}
+	for _, postID := range postIDs {
+		if post, ok := s.posts[postID]; ok {
+			found = append(found, post)
+		}
+	}
+	return found, nil
+}
+
+func TestClient_PostLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	server := newTestServer(t, newMemoryService())
+	c := New(server.URL, WithHTTPClient(server.Client()), WithUserID(userID))
+
+	created, err := c.CreatePost(ctx, "Hello", "First post")
+	require.NoError(t, err)
+	assert.Equal(t, userID, created.UserID)
+	assert.Equal(t, "Hello", created.Title)
+
+	fetched, err := c.GetPost(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, fetched.ID)
+
+	listed, err := c.ListUserPosts(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, created.ID, listed[0].ID)
+
+	found, err := c.SearchPosts(ctx, "Hell")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, created.ID, found[0].ID)
+
+	_, err = c.SearchPosts(ctx, " ")
+	assert.Error(t, err)
+
+	title := "Hello again"
+	updated, err := c.UpdatePost(ctx, created.ID, &title, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello again", updated.Title)
+	assert.Equal(t, created.Content, updated.Content)
+
+	replaced, err := c.ReplacePost(ctx, created.ID, "Replaced", "New content")
+	require.NoError(t, err)
+	assert.Equal(t, "Replaced", replaced.Title)
+	assert.Equal(t, "New content", replaced.Content)
+
+	require.NoError(t, c.DeletePost(ctx, created.ID))
+
+	_, err = c.GetPost(ctx, created.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestClient_CreatePosts(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	server := newTestServer(t, newMemoryService())
+	c := New(server.URL, WithHTTPClient(server.Client()), WithUserID(userID))
+
+	results, err := c.CreatePosts(context.Background(), []PostInput{
+		{Title: "One", Content: "First"},
+		{Title: "Two", Content: "Second"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		assert.Equal(t, http.StatusCreated, result.Status)
+		require.NotNil(t, result.Post)
+		assert.Equal(t, userID, result.Post.UserID)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := newTestServer(t, newMemoryService())
+
+	t.Run("without a user ID", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(server.URL, WithHTTPClient(server.Client()))
+		_, err := c.CreatePost(ctx, "Hello", "First post")
+
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		assert.Equal(t, "Authentication required", apiErr.Message)
+		assert.Equal(t, "UNAUTHENTICATED", apiErr.Code)
+	})
+
+	t.Run("missing post", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(server.URL, WithHTTPClient(server.Client()), WithUserID(uuid.New()))
+		_, err := c.GetPost(ctx, uuid.New())
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		err = c.DeletePost(ctx, uuid.New())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("another user's post", func(t *testing.T) {
+		t.Parallel()
+
+		owner := New(server.URL, WithHTTPClient(server.Client()), WithUserID(uuid.New()))
+		created, err := owner.CreatePost(ctx, "Hello", "First post")
+		require.NoError(t, err)
+
+		other := New(server.URL, WithHTTPClient(server.Client()), WithUserID(uuid.New()))
+		title := "Hijacked"
+		_, err = other.UpdatePost(ctx, created.ID, &title, nil)
+		assert.ErrorIs(t, err, ErrForbidden)
+
+		err = other.DeletePost(ctx, created.ID)
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
@@ -0,0 +1,24 @@
+//go:build ignore
+
+package client
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/acme/postservice/internal/posts"
+)
+
+// newTestServer serves the generated posts routes backed by service
+func newTestServer(t *testing.T, service posts.Service) *httptest.Server {
+	t.Helper()
+
+	r := chi.NewRouter()
+	posts.RegisterRoutes(service, r)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return server
+}
+
@@ -0,0 +1,24 @@
+//go:build ignore
+
+package client
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/acme/postservice/internal/posts"
+)
+
+// newTestServer serves the generated posts routes backed by service
+func newTestServer(t *testing.T, service posts.Service) *httptest.Server {
+	t.Helper()
+
+	e := echo.New()
+	posts.RegisterRoutes(service, e)
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server
+}
+
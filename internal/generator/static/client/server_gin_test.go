@@ -0,0 +1,25 @@
+//go:build ignore
+
+package client
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/acme/postservice/internal/posts"
+)
+
+// newTestServer serves the generated posts routes backed by service
+func newTestServer(t *testing.T, service posts.Service) *httptest.Server {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	posts.RegisterRoutes(service, r)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return server
+}
+
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// MaxBodyBytes limits request bodies to maxBytes. Requests whose Content-Length is over
+// the limit are rejected with 413 before the handler runs; bodies without a declared
+// length are wrapped with http.MaxBytesReader, so reading past the limit fails with
+// *http.MaxBytesError instead of buffering the whole body.
+func MaxBodyBytes(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				json.NewEncoder(w).Encode(map[string]map[string]string{"error": {"code": "REQUEST_TOO_LARGE", "message": "Request body too large"}})
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxBodyBytes(t *testing.T) {
+	t.Parallel()
+
+	const maxBytes = 16
+
+	tests := []struct {
+		name           string
+		body           string
+		chunked        bool
+		expectedStatus int
+	}{
+		{name: "body within the limit", body: strings.Repeat("a", maxBytes), expectedStatus: http.StatusOK},
+		{name: "content length over the limit", body: strings.Repeat("a", maxBytes+1), expectedStatus: http.StatusRequestEntityTooLarge},
+		{name: "chunked body over the limit", body: strings.Repeat("a", maxBytes+1), chunked: true, expectedStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Maps the read error the way the posts handlers do
+			handler := MaxBodyBytes(maxBytes)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if _, err := io.ReadAll(r.Body); err != nil {
+					var maxBytesErr *http.MaxBytesError
+					require.True(t, errors.As(err, &maxBytesErr))
+					w.WriteHeader(http.StatusRequestEntityTooLarge)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/posts", strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusRequestEntityTooLarge && !tt.chunked {
+				var body map[string]map[string]string
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, "REQUEST_TOO_LARGE", body["error"]["code"])
+				assert.Equal(t, "Request body too large", body["error"]["message"])
+			}
+		})
+	}
+}
+
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeLogLines decodes each JSON record written by a slog.JSONHandler
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	decoder := json.NewDecoder(buf)
+	for decoder.More() {
+		var record map[string]any
+		require.NoError(t, decoder.Decode(&record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogging(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		requestID         func(context.Context) string
+		header            string
+		status            int
+		expectedLevel     string
+		expectedRequestID string
+	}{
+		{
+			name:              "router request ID",
+			requestID:         func(context.Context) string { return "router-id" },
+			header:            "header-id",
+			status:            http.StatusCreated,
+			expectedLevel:     "INFO",
+			expectedRequestID: "router-id",
+		},
+		{
+			name:              "header request ID",
+			header:            "header-id",
+			status:            http.StatusNotFound,
+			expectedLevel:     "INFO",
+			expectedRequestID: "header-id",
+		},
+		{
+			name:          "server error",
+			status:        http.StatusInternalServerError,
+			expectedLevel: "ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			logger := slog.New(NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+
+			handler := Logging(logger, tt.requestID)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				logger.InfoContext(r.Context(), "handling")
+				w.WriteHeader(tt.status)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/123", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			records := decodeLogLines(t, &buf)
+			require.Len(t, records, 2)
+			handlerRecord, accessRecord := records[0], records[1]
+
+			assert.Equal(t, "request", accessRecord["msg"])
+			assert.Equal(t, tt.expectedLevel, accessRecord["level"])
+			assert.Equal(t, http.MethodGet, accessRecord["method"])
+			assert.Equal(t, "/posts/123", accessRecord["path"])
+			assert.EqualValues(t, tt.status, accessRecord["status"])
+			assert.Contains(t, accessRecord, "duration")
+
+			requestID, ok := accessRecord["request_id"].(string)
+			require.True(t, ok)
+			if tt.expectedRequestID != "" {
+				assert.Equal(t, tt.expectedRequestID, requestID)
+			} else {
+				assert.NotEmpty(t, requestID)
+			}
+
+			// Records logged by the handler carry the same request ID
+			assert.Equal(t, requestID, handlerRecord["request_id"])
+		})
+	}
+}
+
+func TestLogging_DefaultStatus(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	handler := Logging(logger, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	records := decodeLogLines(t, &buf)
+	require.Len(t, records, 1)
+	assert.EqualValues(t, http.StatusOK, records[0]["status"])
+	assert.Contains(t, records[0], "duration")
+}
+
+
+func TestLogging_Flush(t *testing.T) {
+	t.Parallel()
+
+	// Streaming handlers need the wrapped ResponseWriter to stay flushable
+	handler := Logging(slog.New(slog.DiscardHandler), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok, "ResponseWriter should implement http.Flusher")
+		w.Write([]byte("chunk"))
+		flusher.Flush()
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	assert.True(t, rec.Flushed)
+}
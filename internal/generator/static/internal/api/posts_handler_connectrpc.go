@@ -33,15 +33,25 @@ func (h *PostServiceHandler) CreatePost(
 	ctx context.Context,
 	req *postsv1.CreatePostRequest,
 ) (*postsv1.CreatePostResponse, error) {
-	// Parse user ID
-	userID, err := uuid.Parse(req.UserId)
+	// Resolve the author, preferring the authenticated identity over user_id
+	userID, err := requestUserID(ctx, req.UserId)
 	if err != nil {
 		slog.ErrorContext(ctx, "Invalid user_id", "error", err, "user_id", req.UserId)
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid user_id"))
 	}
 
-	// Create post
+	// Create post, once per idempotency key
+	ctx = posts.ContextWithIdempotencyKey(ctx, req.IdempotencyKey)
 	post, err := h.service.CreatePost(ctx, userID, req.Title, req.Content)
+	if errors.Is(err, posts.ErrValidation) || errors.Is(err, posts.ErrInvalidIdempotencyKey) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if errors.Is(err, posts.ErrIdempotencyKeyReused) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	if errors.Is(err, posts.ErrIdempotencyKeyInUse) {
+		return nil, connect.NewError(connect.CodeAborted, err)
+	}
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to create post", "error", err, "user_id", userID)
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create post"))
@@ -91,8 +101,14 @@ func (h *PostServiceHandler) ListPosts(
 	ctx context.Context,
 	req *postsv1.ListPostsRequest,
 ) (*postsv1.ListPostsResponse, error) {
-	// Parse user ID
-	userID, err := uuid.Parse(req.UserId)
+	// Parse user ID, listing the authenticated caller's posts when user_id is not set
+	var userID uuid.UUID
+	var err error
+	if req.UserId != "" {
+		userID, err = uuid.Parse(req.UserId)
+	} else {
+		userID, err = requestUserID(ctx, req.UserId)
+	}
 	if err != nil {
 		slog.ErrorContext(ctx, "Invalid user_id", "error", err, "user_id", req.UserId)
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid user_id"))
@@ -121,6 +137,13 @@ func (h *PostServiceHandler) UpdatePost(
 	ctx context.Context,
 	req *postsv1.UpdatePostRequest,
 ) (*postsv1.UpdatePostResponse, error) {
+	// Resolve the acting user, preferring the authenticated identity over user_id
+	userID, err := requestUserID(ctx, req.UserId)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid user_id", "error", err, "user_id", req.UserId)
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid user_id"))
+	}
+
 	// Parse post ID
 	postID, err := uuid.Parse(req.PostId)
 	if err != nil {
@@ -128,23 +151,20 @@ func (h *PostServiceHandler) UpdatePost(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid post_id"))
 	}
 
-	// Prepare update values
-	title := ""
-	if req.Title != nil {
-		title = *req.Title
-	}
-	content := ""
-	if req.Content != nil {
-		content = *req.Content
-	}
-
-	// Update post
-	post, err := h.service.UpdatePost(ctx, postID, title, content)
+	// Update post (unset optional fields are left unchanged)
+	post, err := h.service.UpdatePost(ctx, userID, postID, req.Title, req.Content)
 	if err != nil {
+		if errors.Is(err, posts.ErrValidation) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
 		if errors.Is(err, posts.ErrPostNotFound) {
 			slog.WarnContext(ctx, "Post not found for update", "post_id", postID)
 			return nil, connect.NewError(connect.CodeNotFound, errors.New("post not found"))
 		}
+		if errors.Is(err, posts.ErrForbidden) {
+			slog.WarnContext(ctx, "Post belongs to another user", "post_id", postID, "user_id", userID)
+			return nil, connect.NewError(connect.CodePermissionDenied, errors.New("post belongs to another user"))
+		}
 		slog.ErrorContext(ctx, "Failed to update post", "error", err, "post_id", postID)
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update post"))
 	}
@@ -162,6 +182,13 @@ func (h *PostServiceHandler) DeletePost(
 	ctx context.Context,
 	req *postsv1.DeletePostRequest,
 ) (*postsv1.DeletePostResponse, error) {
+	// Resolve the acting user, preferring the authenticated identity over user_id
+	userID, err := requestUserID(ctx, req.UserId)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid user_id", "error", err, "user_id", req.UserId)
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid user_id"))
+	}
+
 	// Parse post ID
 	postID, err := uuid.Parse(req.PostId)
 	if err != nil {
@@ -170,12 +197,16 @@ func (h *PostServiceHandler) DeletePost(
 	}
 
 	// Delete post
-	err = h.service.DeletePost(ctx, postID)
+	err = h.service.DeletePost(ctx, userID, postID)
 	if err != nil {
 		if errors.Is(err, posts.ErrPostNotFound) {
 			slog.WarnContext(ctx, "Post not found for delete", "post_id", postID)
 			return nil, connect.NewError(connect.CodeNotFound, errors.New("post not found"))
 		}
+		if errors.Is(err, posts.ErrForbidden) {
+			slog.WarnContext(ctx, "Post belongs to another user", "post_id", postID, "user_id", userID)
+			return nil, connect.NewError(connect.CodePermissionDenied, errors.New("post belongs to another user"))
+		}
 		slog.ErrorContext(ctx, "Failed to delete post", "error", err, "post_id", postID)
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to delete post"))
 	}
@@ -184,3 +215,13 @@ func (h *PostServiceHandler) DeletePost(
 		Message: "Post deleted successfully",
 	}, nil
 }
+
+// requestUserID returns the authenticated user ID set by the auth interceptor,
+// falling back to the request's user_id field when no identity is present
+func requestUserID(ctx context.Context, rawUserID string) (uuid.UUID, error) {
+	if userID, ok := posts.UserIDFromContext(ctx); ok {
+		return userID, nil
+	}
+	return uuid.Parse(rawUserID)
+}
+
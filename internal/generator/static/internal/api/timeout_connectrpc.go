@@ -0,0 +1,28 @@
+//go:build ignore
+
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// TimeoutInterceptor bounds each RPC to timeout, failing RPCs that run out of time
+// with CodeDeadlineExceeded. Shorter client deadlines still apply.
+func TimeoutInterceptor(timeout time.Duration) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			resp, err := next(ctx, req)
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && connect.CodeOf(err) != connect.CodeDeadlineExceeded {
+				return nil, connect.NewError(connect.CodeDeadlineExceeded, errors.New("request timed out"))
+			}
+			return resp, err
+		}
+	}
+}
+
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// DefaultTokenExpiry is the token lifetime used when auth.token_expiry is not set
+const DefaultTokenExpiry = time.Hour
+
+// ErrInvalidToken is returned when a token is malformed, expired, or not signed with the secret
+var ErrInvalidToken error = errors.New("invalid token")
+
+// ErrMissingToken is returned when a request has no Bearer token
+var ErrMissingToken error = errors.New("missing bearer token")
+
+// Authenticator issues and validates HS256 JWTs whose subject is the user ID
+type Authenticator struct {
+	secret      []byte
+	tokenExpiry time.Duration
+}
+
+// New creates an Authenticator signing tokens with secret (JWT_SECRET).
+// Tokens expire after tokenExpiry, or DefaultTokenExpiry if it is zero.
+func New(secret string, tokenExpiry time.Duration) *Authenticator {
+	if tokenExpiry == 0 {
+		tokenExpiry = DefaultTokenExpiry
+	}
+	return &Authenticator{
+		secret:      []byte(secret),
+		tokenExpiry: tokenExpiry,
+	}
+}
+
+// IssueToken returns a signed token for userID that expires after the token expiry
+func (a *Authenticator) IssueToken(userID uuid.UUID) (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Subject:   userID.String(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(a.tokenExpiry)),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(a.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, nil
+}
+
+// ParseToken validates token and returns the user ID in its subject.
+// Returns ErrInvalidToken if the signature, algorithm, expiry or subject is invalid.
+func (a *Authenticator) ParseToken(token string) (uuid.UUID, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return a.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: subject is not a user ID", ErrInvalidToken)
+	}
+	return userID, nil
+}
+
+// authenticate validates the Bearer token in an Authorization header value
+func (a *Authenticator) authenticate(header string) (uuid.UUID, error) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return uuid.Nil, ErrMissingToken
+	}
+	return a.ParseToken(strings.TrimSpace(token))
+}
+
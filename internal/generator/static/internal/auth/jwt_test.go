@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func TestAuthenticator_ParseToken(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	authenticator := New(testSecret, time.Hour)
+
+	signed := func(t *testing.T, method jwt.SigningMethod, key any, claims jwt.Claims) string {
+		t.Helper()
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		require.NoError(t, err)
+		return token
+	}
+
+	tests := []struct {
+		name        string
+		token       func(t *testing.T) string
+		expectedErr error
+	}{
+		{
+			name: "issued token",
+			token: func(t *testing.T) string {
+				token, err := authenticator.IssueToken(userID)
+				require.NoError(t, err)
+				return token
+			},
+		},
+		{
+			name: "expired token",
+			token: func(t *testing.T) string {
+				token, err := New(testSecret, -time.Minute).IssueToken(userID)
+				require.NoError(t, err)
+				return token
+			},
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name: "signed with another secret",
+			token: func(t *testing.T) string {
+				token, err := New("other-secret", time.Hour).IssueToken(userID)
+				require.NoError(t, err)
+				return token
+			},
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name: "unsigned token",
+			token: func(t *testing.T) string {
+				return signed(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.RegisteredClaims{
+					Subject:   userID.String(),
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+				})
+			},
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name: "no expiry",
+			token: func(t *testing.T) string {
+				return signed(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.RegisteredClaims{
+					Subject: userID.String(),
+				})
+			},
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name: "subject is not a user ID",
+			token: func(t *testing.T) string {
+				return signed(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.RegisteredClaims{
+					Subject:   "alice",
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+				})
+			},
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name: "malformed token",
+			token: func(t *testing.T) string {
+				return "not-a-jwt"
+			},
+			expectedErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parsed, err := authenticator.ParseToken(tt.token(t))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, userID, parsed)
+		})
+	}
+}
+
@@ -0,0 +1,30 @@
+//go:build ignore
+
+package auth
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/acme/postservice/internal/posts"
+)
+
+// Middleware authenticates requests with a Bearer token, storing the token's user ID
+// in the request context for the posts handlers. Responds 401 if the token is missing or invalid.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, err := a.authenticate(r.Header.Get("Authorization"))
+		if err != nil {
+			slog.WarnContext(r.Context(), "Authentication failed", "error", err)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(posts.NewErrorResponse(posts.CodeUnauthenticated, "Authentication required"))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(posts.ContextWithUserID(r.Context(), userID)))
+	})
+}
+
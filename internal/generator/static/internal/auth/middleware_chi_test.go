@@ -0,0 +1,86 @@
+//go:build ignore
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/acme/postservice/internal/posts"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	authenticator := New(testSecret, time.Hour)
+	validToken, err := authenticator.IssueToken(userID)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+	}{
+		{
+			name:           "valid bearer token",
+			authorization:  "Bearer " + validToken,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "lowercase scheme",
+			authorization:  "bearer " + validToken,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing header",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong scheme",
+			authorization:  "Basic " + validToken,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "invalid token",
+			authorization:  "Bearer not-a-jwt",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotUserID uuid.UUID
+			handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUserID, _ = posts.UserIDFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+			// A raw X-User-ID header is not enough once auth is enabled
+			req.Header.Set("X-User-ID", userID.String())
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, userID, gotUserID)
+			} else {
+				assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+				assert.JSONEq(t, `{"error":{"code":"UNAUTHENTICATED","message":"Authentication required"}}`, rec.Body.String())
+			}
+		})
+	}
+}
+
@@ -0,0 +1,30 @@
+//go:build ignore
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"connectrpc.com/connect"
+
+	"github.com/acme/postservice/internal/posts"
+)
+
+// Interceptor authenticates RPCs with a Bearer token, storing the token's user ID
+// in the context for the handlers. Fails with CodeUnauthenticated (HTTP 401) if the token is missing or invalid.
+func (a *Authenticator) Interceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			userID, err := a.authenticate(req.Header().Get("Authorization"))
+			if err != nil {
+				slog.WarnContext(ctx, "Authentication failed", "error", err, "procedure", req.Spec().Procedure)
+				return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+			}
+
+			return next(posts.ContextWithUserID(ctx, userID), req)
+		}
+	}
+}
+
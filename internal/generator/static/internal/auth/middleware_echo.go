@@ -0,0 +1,31 @@
+//go:build ignore
+
+package auth
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/acme/postservice/internal/posts"
+)
+
+// Middleware authenticates requests with a Bearer token, storing the token's user ID
+// in the request context for the posts handlers. Responds 401 if the token is missing or invalid.
+func (a *Authenticator) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, err := a.authenticate(c.Request().Header.Get("Authorization"))
+			if err != nil {
+				slog.WarnContext(c.Request().Context(), "Authentication failed", "error", err)
+				c.Response().Header().Set("WWW-Authenticate", "Bearer")
+				return c.JSON(http.StatusUnauthorized, posts.NewErrorResponse(posts.CodeUnauthenticated, "Authentication required"))
+			}
+
+			c.SetRequest(c.Request().WithContext(posts.ContextWithUserID(c.Request().Context(), userID)))
+			return next(c)
+		}
+	}
+}
+
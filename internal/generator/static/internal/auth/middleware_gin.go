@@ -0,0 +1,30 @@
+//go:build ignore
+
+package auth
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/acme/postservice/internal/posts"
+)
+
+// Middleware authenticates requests with a Bearer token, storing the token's user ID
+// in the request context for the posts handlers. Responds 401 if the token is missing or invalid.
+func (a *Authenticator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := a.authenticate(c.GetHeader("Authorization"))
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "Authentication failed", "error", err)
+			c.Header("WWW-Authenticate", "Bearer")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, posts.NewErrorResponse(posts.CodeUnauthenticated, "Authentication required"))
+			return
+		}
+
+		c.Request = c.Request.WithContext(posts.ContextWithUserID(c.Request.Context(), userID))
+		c.Next()
+	}
+}
+
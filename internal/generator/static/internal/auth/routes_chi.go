@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RegisterRoutes registers the demo token endpoint (POST /auth/token).
+// It must stay outside the auth middleware so clients can get their first token.
+func (a *Authenticator) RegisterRoutes(r chi.Router) {
+	r.Post(TokenPath, a.issueToken)
+}
+
+// issueToken handles POST /auth/token (DEMO ONLY, see issueTokenFor)
+func (a *Authenticator) issueToken(w http.ResponseWriter, r *http.Request) {
+	var req IssueTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		jsonResponse(w, errorResponse("INVALID_REQUEST", "Invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := a.issueTokenFor(req.UserID)
+	if errors.Is(err, ErrInvalidUserID) {
+		jsonResponse(w, errorResponse("INVALID_USER_ID", "Invalid user ID"), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to issue token", "error", err)
+		jsonResponse(w, errorResponse("INTERNAL", "Failed to issue token"), http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, resp, http.StatusOK)
+}
+
+// jsonResponse writes a JSON response
+func jsonResponse(w http.ResponseWriter, data any, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutes_IssueToken(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	authenticator := New(testSecret, 15*time.Minute)
+	r := chi.NewRouter()
+	authenticator.RegisterRoutes(r)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedError  string
+		expectedCode   string
+	}{
+		{
+			name:           "valid user ID",
+			body:           `{"user_id":"` + userID.String() + `"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid user ID",
+			body:           `{"user_id":"alice"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid user ID",
+			expectedCode:   "INVALID_USER_ID",
+		},
+		{
+			name:           "malformed body",
+			body:           `{"user_id":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid request body",
+			expectedCode:   "INVALID_REQUEST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader(tt.body)))
+			require.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedError != "" {
+				var resp map[string]map[string]string
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedCode, resp["error"]["code"])
+				assert.Equal(t, tt.expectedError, resp["error"]["message"])
+				return
+			}
+
+			var resp IssueTokenResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, "Bearer", resp.TokenType)
+			assert.Equal(t, int64(900), resp.ExpiresIn)
+
+			// The issued token is accepted by the auth middleware
+			tokenUserID, err := authenticator.authenticate("Bearer " + resp.AccessToken)
+			require.NoError(t, err)
+			assert.Equal(t, userID, tokenUserID)
+		})
+	}
+}
+
@@ -0,0 +1,43 @@
+//go:build ignore
+
+package auth
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// postRouter is satisfied by both *echo.Echo and *echo.Group
+type postRouter interface {
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterRoutes registers the demo token endpoint (POST /auth/token).
+// It must stay outside the auth middleware so clients can get their first token.
+func (a *Authenticator) RegisterRoutes(e postRouter) {
+	e.POST(TokenPath, a.issueToken)
+}
+
+// issueToken handles POST /auth/token (DEMO ONLY, see issueTokenFor)
+func (a *Authenticator) issueToken(c echo.Context) error {
+	var req IssueTokenRequest
+	if err := c.Bind(&req); err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		return c.JSON(http.StatusBadRequest, errorResponse("INVALID_REQUEST", "Invalid request body"))
+	}
+
+	resp, err := a.issueTokenFor(req.UserID)
+	if errors.Is(err, ErrInvalidUserID) {
+		return c.JSON(http.StatusBadRequest, errorResponse("INVALID_USER_ID", "Invalid user ID"))
+	}
+	if err != nil {
+		slog.Error("Failed to issue token", "error", err)
+		return c.JSON(http.StatusInternalServerError, errorResponse("INTERNAL", "Failed to issue token"))
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
@@ -0,0 +1,41 @@
+//go:build ignore
+
+package auth
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes registers the demo token endpoint (POST /auth/token).
+// It must stay outside the auth middleware so clients can get their first token.
+func (a *Authenticator) RegisterRoutes(r gin.IRouter) {
+	r.POST(TokenPath, a.issueToken)
+}
+
+// issueToken handles POST /auth/token (DEMO ONLY, see issueTokenFor)
+func (a *Authenticator) issueToken(c *gin.Context) {
+	var req IssueTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		c.JSON(http.StatusBadRequest, errorResponse("INVALID_REQUEST", "Invalid request body"))
+		return
+	}
+
+	resp, err := a.issueTokenFor(req.UserID)
+	if errors.Is(err, ErrInvalidUserID) {
+		c.JSON(http.StatusBadRequest, errorResponse("INVALID_USER_ID", "Invalid user ID"))
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to issue token", "error", err)
+		c.JSON(http.StatusInternalServerError, errorResponse("INTERNAL", "Failed to issue token"))
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// TokenPath is the path of the demo token endpoint
+const TokenPath = "/auth/token"
+
+// ErrInvalidUserID is returned when a token request's user ID is not a UUID
+var ErrInvalidUserID error = errors.New("invalid user ID")
+
+// IssueTokenRequest is the request body for POST /auth/token
+type IssueTokenRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// IssueTokenResponse is the response body for POST /auth/token
+type IssueTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"` // Seconds until the token expires
+}
+
+// errorResponse returns a token endpoint error body in the posts API's envelope
+// ({"error": {"code": ..., "message": ...}}), with codes from posts.ErrorCode
+func errorResponse(code, message string) map[string]map[string]string {
+	return map[string]map[string]string{"error": {"code": code, "message": message}}
+}
+
+// issueTokenFor issues a token for rawUserID.
+// DEMO ONLY: there is no credential check, so anyone can get a token for any user.
+// Replace this with a real login (e.g. a password store or an identity provider) before production.
+func (a *Authenticator) issueTokenFor(rawUserID string) (IssueTokenResponse, error) {
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		return IssueTokenResponse{}, ErrInvalidUserID
+	}
+
+	token, err := a.IssueToken(userID)
+	if err != nil {
+		return IssueTokenResponse{}, err
+	}
+
+	return IssueTokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(a.tokenExpiry.Seconds()),
+	}, nil
+}
+
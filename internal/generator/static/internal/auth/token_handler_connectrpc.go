@@ -0,0 +1,50 @@
+//go:build ignore
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"connectrpc.com/connect"
+
+	authv1 "github.com/acme/postservice/internal/protos/gen/auth/v1"
+	authv1connect "github.com/acme/postservice/internal/protos/gen/auth/v1/authv1connect"
+)
+
+// TokenServiceHandler implements the demo AuthService
+type TokenServiceHandler struct {
+	authv1connect.UnimplementedAuthServiceHandler
+	authenticator *Authenticator
+}
+
+// NewTokenServiceHandler creates the AuthService handler.
+// Register it without the auth interceptor so clients can get their first token.
+func NewTokenServiceHandler(authenticator *Authenticator) *TokenServiceHandler {
+	return &TokenServiceHandler{
+		authenticator: authenticator,
+	}
+}
+
+// IssueToken issues a token for the requested user (DEMO ONLY, see issueTokenFor)
+func (h *TokenServiceHandler) IssueToken(
+	ctx context.Context,
+	req *authv1.IssueTokenRequest,
+) (*authv1.IssueTokenResponse, error) {
+	resp, err := h.authenticator.issueTokenFor(req.UserId)
+	if errors.Is(err, ErrInvalidUserID) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid user_id"))
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to issue token", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to issue token"))
+	}
+
+	return &authv1.IssueTokenResponse{
+		AccessToken: resp.AccessToken,
+		TokenType:   resp.TokenType,
+		ExpiresIn:   resp.ExpiresIn,
+	}, nil
+}
+
@@ -2,7 +2,9 @@ package config
 
 import (
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"os"
 	"strings"
@@ -13,54 +15,21 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-//go:embed production.yaml local.yaml
+//go:embed *.yaml
 var configFS embed.FS
 
 var _ = env.Parse // Imported for secrets parsing when needed
 
 type Config struct {
-	Server  ServerConfig   `yaml:"server"`
-	Auth    *AuthConfig    `yaml:"auth,omitempty"`
-	Metrics *MetricsConfig `yaml:"metrics,omitempty"`
-	PostHog *PostHogConfig `yaml:"posthog,omitempty"`
-	Secrets SecretsConfig  `yaml:"-"`
-}
-
-type ServerConfig struct {
-	Port  string `yaml:"port"`
-	Stage Stage  `yaml:"stage"`
-}
-
-type AuthConfig struct {
-	TokenExpiry string `yaml:"token_expiry"`
-}
-
-type MetricsConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	Path    string `yaml:"path"`
-}
-
-type PostHogConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	Host    string `yaml:"host"`
-}
-
-type SecretsConfig struct {
-	// DynamoDB configuration (from environment variables)
-	AWSRegion          string `env:"AWS_REGION"`
-	TableName          string `env:"TABLE_NAME"`
-	EndpointURL        string `env:"DYNAMODB_ENDPOINT_URL"` // Optional: for local DynamoDB (e.g., http://localhost:8000)
-	AWSAccessKeyID     string `env:"AWS_ACCESS_KEY_ID"`
-	AWSSecretAccessKey string `env:"AWS_SECRET_ACCESS_KEY"`
-
-	// Postgres configuration (from environment variables)
-	DatabaseURL string `env:"DATABASE_URL"`
-
-	// Auth secrets
-	JWTSecret string `env:"JWT_SECRET"`
-
-	// PostHog secrets
-	PostHogAPIKey string `env:"POSTHOG_API_KEY"`
+	Server    ServerConfig     `yaml:"server"`
+	Auth      *AuthConfig      `yaml:"auth,omitempty"`
+	Metrics   *MetricsConfig   `yaml:"metrics,omitempty"`
+	PostHog   *PostHogConfig   `yaml:"posthog,omitempty"`
+	CORS      *CORSConfig      `yaml:"cors,omitempty"`
+	RateLimit *RateLimitConfig `yaml:"rate_limit,omitempty"`
+	Postgres  *PostgresConfig  `yaml:"postgres,omitempty"`
+	DynamoDB  *DynamoDBConfig  `yaml:"dynamodb,omitempty"`
+	Secrets   SecretsConfig    `yaml:"-"`
 }
 
 // Load reads configuration from stage-specific YAML file and secrets from environment variables
@@ -91,11 +60,21 @@ func Load() (*Config, error) {
 	// This ensures values from .env files are available when parsing
 	switch stage {
 	case StageLocal:
-		// Load .env.local file from current working directory
-		// STAGE=local means Configuration will godotenv load .env.local and use local.yaml
-		if err := godotenv.Load(".env.local"); err != nil {
+		// Load .env then .env.local from current working directory
+		// STAGE=local means shared defaults come from .env (optional), local overrides
+		// from .env.local, and local.yaml is used
+		if _, err := os.Stat(".env.local"); err != nil {
 			return nil, fmt.Errorf("failed to load .env.local file for local stage: %w. The file should exist in the project root", err)
 		}
+		if err := loadEnvFiles(".env", ".env.local"); err != nil {
+			return nil, err
+		}
+	case StageStaging:
+		// STAGE=staging loads the optional .env.staging and uses staging.yaml
+		// Deployed staging environments normally set secrets via the platform instead
+		if err := loadEnvFiles(".env.staging"); err != nil {
+			return nil, err
+		}
 	case StageProduction:
 		// STAGE=production means it will not load any environment file and use production.yaml
 		// Secrets are set via deployment platform environment variables only
@@ -103,7 +82,7 @@ func Load() (*Config, error) {
 	}
 
 	// Load config from embedded filesystem (all config files are bundled in binary)
-	// Both local.yaml and production.yaml are embedded, STAGE selects which to use
+	// Every <stage>.yaml is embedded, STAGE selects which to use
 	// This allows the application to run in any mode without filesystem access
 	configFileName := fmt.Sprintf("%s.yaml", stage)
 	data, err := configFS.ReadFile(configFileName)
@@ -115,6 +94,24 @@ func Load() (*Config, error) {
 	if err := yaml.Unmarshal(data, cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config file for stage %s: %w", stage, err)
 	}
+	if cfg.Server.HealthPath == "" {
+		cfg.Server.HealthPath = DefaultHealthPath
+	}
+	if cfg.Server.ReadyPath == "" {
+		cfg.Server.ReadyPath = DefaultReadyPath
+	}
+	if cfg.Server.RequestTimeout == 0 {
+		cfg.Server.RequestTimeout = DefaultRequestTimeout
+	}
+	if cfg.Server.MaxBodyBytes == 0 {
+		cfg.Server.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if cfg.CORS != nil && len(cfg.CORS.AllowedMethods) == 0 {
+		cfg.CORS.AllowedMethods = DefaultCORSMethods
+	}
+	if cfg.CORS != nil && len(cfg.CORS.AllowedHeaders) == 0 {
+		cfg.CORS.AllowedHeaders = DefaultCORSHeaders
+	}
 
 	// Parse secrets from environment variables (already loaded from .env files above)
 	// Note: AWS credentials are optional when using local DynamoDB (endpoint_url is set)
@@ -125,62 +122,46 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
-// configSchema defines the declarative validation schema for Config using zog
-var configSchema = zog.Struct(zog.Shape{
-	"Server": zog.Struct(zog.Shape{
-		"Port": zog.String().Min(1).Required(zog.Message("server.port is required")),
-		// Stage is a custom type, validated in TestFunc below
-	}).TestFunc(func(server any, ctx zog.Ctx) bool {
-		s, ok := server.(ServerConfig)
-		if !ok {
-			return false
+// loadEnvFiles loads env files in order, with later files overriding earlier ones
+// (godotenv.Overload ordering). Missing files are skipped, and variables already set
+// in the process environment always take precedence over file values.
+func loadEnvFiles(filenames ...string) error {
+	values := make(map[string]string)
+	for _, filename := range filenames {
+		fileValues, err := godotenv.Read(filename)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
 		}
-		return s.Stage.IsValid()
-	}, zog.Message("server.stage must be one of: local, production")),
-	"Secrets": zog.Struct(zog.Shape{
-		"AWSRegion":          zog.String(),
-		"TableName":          zog.String(),
-		"EndpointURL":        zog.String(),
-		"AWSAccessKeyID":     zog.String(),
-		"AWSSecretAccessKey": zog.String(),
-		"DatabaseURL":        zog.String(),
-		"JWTSecret":          zog.String(),
-		"PostHogAPIKey":      zog.String(),
-	}).TestFunc(func(secrets any, ctx zog.Ctx) bool {
-		s, ok := secrets.(SecretsConfig)
-		if !ok {
-			return false
+		if err != nil {
+			return fmt.Errorf("failed to load %s file: %w", filename, err)
+		}
+		for key, value := range fileValues {
+			values[key] = value
 		}
-		hasDynamoDB := s.AWSRegion != "" || s.TableName != ""
-		hasPostgres := s.DatabaseURL != ""
-
-	if !hasDynamoDB && !hasPostgres {
-			return false
-	}
-	if hasDynamoDB && hasPostgres {
-			return false
 	}
 
-	if hasDynamoDB {
-			if s.AWSRegion == "" || s.TableName == "" {
-				return false
+	for key, value := range values {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
 		}
-			if s.EndpointURL == "" {
-				if s.AWSAccessKeyID == "" || s.AWSSecretAccessKey == "" {
-					return false
-				}
-			}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set environment variable %s: %w", key, err)
 		}
+	}
+	return nil
+}
 
-		return true
-	}, zog.Message("database configuration is invalid: must set either DynamoDB (AWS_REGION, TABLE_NAME) or Postgres (DATABASE_URL), but not both")),
-	"Auth": zog.Ptr(zog.Struct(zog.Shape{
-		"TokenExpiry": zog.String(),
-	})),
-	"PostHog": zog.Ptr(zog.Struct(zog.Shape{
-		"Enabled": zog.Bool(),
-		"Host":    zog.String(),
-	})),
+// configSchema defines the declarative validation schema for Config using zog
+var configSchema = zog.Struct(zog.Shape{
+	"Server":    serverSchema,
+	"Secrets":   secretsSchema,
+	"Metrics":   metricsSchema,
+	"Auth":      authSchema,
+	"PostHog":   postHogSchema,
+	"CORS":      corsSchema,
+	"RateLimit": rateLimitSchema,
+	"Postgres":  postgresSchema,
+	"DynamoDB":  dynamoDBSchema,
 }).TestFunc(func(cfg any, ctx zog.Ctx) bool {
 	c, ok := cfg.(*Config)
 	if !ok {
@@ -202,7 +183,10 @@ var configSchema = zog.Struct(zog.Shape{
 	}
 
 	return true
-}, zog.Message("JWT_SECRET is required when auth is enabled; POSTHOG_API_KEY is required when posthog is enabled"))
+}, zog.Message("JWT_SECRET is required when auth is enabled; POSTHOG_API_KEY is required when posthog is enabled")).TestFunc(func(cfg any, ctx zog.Ctx) bool {
+	c, ok := cfg.(*Config)
+	return ok && !(c.RateLimit.Enabled() && c.RateLimit.Store == RateLimitStoreRedis && c.Secrets.RedisURL == "")
+}, zog.Message("REDIS_URL is required when rate_limit.store is redis"))
 
 // Validate validates the loaded configuration using declarative zog schema
 // Returns an error if required fields are missing or invalid
@@ -230,4 +214,3 @@ func (c *Config) Validate() error {
 	}
 	return nil
 }
-
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Oudwins/zog"
+)
+
+type AuthConfig struct {
+	// TokenExpiry is the lifetime of issued JWTs (e.g. 1h)
+	TokenExpiry time.Duration `yaml:"token_expiry"`
+}
+
+// authSchema validates the optional auth section
+var authSchema = zog.Ptr(zog.Struct(zog.Shape{
+	// TokenExpiry is a time.Duration, validated in TestFunc below
+}).TestFunc(func(auth any, ctx zog.Ctx) bool {
+	a, ok := auth.(*AuthConfig)
+	return ok && a.TokenExpiry >= 0
+}, zog.Message("auth.token_expiry must not be negative")))
+
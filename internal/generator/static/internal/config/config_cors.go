@@ -0,0 +1,42 @@
+package config
+
+import (
+	"slices"
+
+	"github.com/Oudwins/zog"
+)
+
+// CORSConfig lets browser apps on other origins call the API. CORS is disabled
+// (cross-origin browser requests are refused) unless allowed_origins is set.
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to call the API (e.g. http://localhost:5173), or "*" for any
+	AllowedOrigins []string `yaml:"allowed_origins"`
+	// AllowedMethods defaults to DefaultCORSMethods
+	AllowedMethods []string `yaml:"allowed_methods"`
+	// AllowedHeaders defaults to DefaultCORSHeaders
+	AllowedHeaders []string `yaml:"allowed_headers"`
+	// AllowCredentials lets browsers send cookies and HTTP auth; it requires explicit origins
+	AllowCredentials bool `yaml:"allow_credentials"`
+	// MaxAge is how many seconds browsers may cache a preflight response (0 uses the browser default)
+	MaxAge int `yaml:"max_age"`
+}
+
+// DefaultCORSMethods are the methods allowed when cors.allowed_methods is not set
+var DefaultCORSMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// DefaultCORSHeaders are the request headers allowed when cors.allowed_headers is not set
+var DefaultCORSHeaders = []string{"Content-Type", "Authorization", "X-User-ID", "X-Request-Id"}
+
+// Enabled reports whether any origin is allowed, so a nil or empty section disables CORS
+func (c *CORSConfig) Enabled() bool {
+	return c != nil && len(c.AllowedOrigins) > 0
+}
+
+// corsSchema validates the optional cors section
+var corsSchema = zog.Ptr(zog.Struct(zog.Shape{
+	"MaxAge": zog.Int().GTE(0, zog.Message("cors.max_age must not be negative")),
+}).TestFunc(func(cors any, ctx zog.Ctx) bool {
+	c, ok := cors.(*CORSConfig)
+	return ok && !(c.AllowCredentials && slices.Contains(c.AllowedOrigins, "*"))
+}, zog.Message("cors.allow_credentials requires explicit allowed_origins, not *")))
+
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Oudwins/zog"
+)
+
+// DynamoDBConfig tunes retries of throttled DynamoDB requests and reads of just-written
+// posts. Unset (zero) fields keep the database and posts package defaults.
+type DynamoDBConfig struct {
+	// MaxRetries is how many times a throttled request is retried (default 5)
+	MaxRetries int `yaml:"max_retries"`
+	// MaxBackoff caps the exponential backoff between retries (default 5s)
+	MaxBackoff time.Duration `yaml:"max_backoff"`
+	// ReadAfterWriteWait is how long getting a post by ID keeps retrying before answering not
+	// found, so a post read right after it was created is found (default 0, no retries)
+	ReadAfterWriteWait time.Duration `yaml:"read_after_write_wait"`
+}
+
+// dynamoDBSchema validates the optional dynamodb section
+var dynamoDBSchema = zog.Ptr(zog.Struct(zog.Shape{
+	"MaxRetries": zog.Int().GTE(0, zog.Message("dynamodb.max_retries must not be negative")),
+	// MaxBackoff and ReadAfterWriteWait are time.Durations, validated in TestFunc below
+}).TestFunc(func(dynamoDB any, ctx zog.Ctx) bool {
+	d, ok := dynamoDB.(*DynamoDBConfig)
+	return ok && d.MaxBackoff >= 0
+}, zog.Message("dynamodb.max_backoff must not be negative")).TestFunc(func(dynamoDB any, ctx zog.Ctx) bool {
+	d, ok := dynamoDB.(*DynamoDBConfig)
+	return ok && d.ReadAfterWriteWait >= 0
+}, zog.Message("dynamodb.read_after_write_wait must not be negative")))
+
@@ -0,0 +1,14 @@
+package config
+
+import "github.com/Oudwins/zog"
+
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"`
+}
+
+// metricsSchema validates the optional metrics section
+var metricsSchema = zog.Ptr(zog.Struct(zog.Shape{
+	"Path": zog.String().HasPrefix("/", zog.Message("metrics.path must start with /")),
+}))
+
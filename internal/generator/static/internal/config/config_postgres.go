@@ -0,0 +1,36 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Oudwins/zog"
+)
+
+// PostgresConfig tunes the PostgreSQL connection pool. Unset (zero) fields keep the
+// database package defaults.
+type PostgresConfig struct {
+	// MaxConns is the maximum number of open connections (default 10)
+	MaxConns int `yaml:"max_conns"`
+	// MinConns is how many idle connections are kept open (default 2)
+	MinConns int `yaml:"min_conns"`
+	// MaxConnLifetime is how long a connection is reused before being replaced (default 1h)
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime"`
+	// MaxConnIdleTime is how long an idle connection is kept (default 30m)
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time"`
+	// StatementCacheCapacity is how many prepared statements each connection caches (default 512)
+	StatementCacheCapacity int `yaml:"statement_cache_capacity"`
+}
+
+// postgresSchema validates the optional postgres section
+var postgresSchema = zog.Ptr(zog.Struct(zog.Shape{
+	"MaxConns":               zog.Int().GTE(0, zog.Message("postgres.max_conns must not be negative")),
+	"MinConns":               zog.Int().GTE(0, zog.Message("postgres.min_conns must not be negative")),
+	"StatementCacheCapacity": zog.Int().GTE(0, zog.Message("postgres.statement_cache_capacity must not be negative")),
+	// MaxConnLifetime and MaxConnIdleTime are time.Durations, validated in TestFunc below
+}).TestFunc(func(postgres any, ctx zog.Ctx) bool {
+	p, ok := postgres.(*PostgresConfig)
+	return ok && p.MaxConnLifetime >= 0 && p.MaxConnIdleTime >= 0
+}, zog.Message("postgres.max_conn_lifetime and postgres.max_conn_idle_time must not be negative")).TestFunc(func(postgres any, ctx zog.Ctx) bool {
+	p, ok := postgres.(*PostgresConfig)
+	return ok && (p.MaxConns == 0 || p.MinConns <= p.MaxConns)
+}, zog.Message("postgres.min_conns must not exceed postgres.max_conns")))
@@ -0,0 +1,15 @@
+package config
+
+import "github.com/Oudwins/zog"
+
+type PostHogConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Host    string `yaml:"host"`
+}
+
+// postHogSchema validates the optional posthog section
+var postHogSchema = zog.Ptr(zog.Struct(zog.Shape{
+	"Enabled": zog.Bool(),
+	"Host":    zog.String(),
+}))
+
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Oudwins/zog"
+)
+
+// Rate limit keys (rate_limit.key) and stores (rate_limit.store)
+const (
+	RateLimitKeyIP       = "ip"
+	RateLimitKeyUser     = "user"
+	RateLimitStoreMemory = "memory"
+	RateLimitStoreRedis  = "redis"
+)
+
+// RateLimitConfig limits how many requests each client may make. Each client gets a token
+// bucket of Requests tokens that refills over Window. Rate limiting is disabled unless the
+// rate_limit section is set.
+type RateLimitConfig struct {
+	// Requests is how many requests a client may make in a burst, and per Window on average
+	Requests int `yaml:"requests"`
+	// Window is how long an empty bucket takes to refill
+	Window time.Duration `yaml:"window"`
+	// Key identifies clients: "ip" (default) or "user" (the X-User-ID header, falling back to the IP)
+	Key string `yaml:"key"`
+	// Store is "memory" (default; each instance limits on its own) or "redis" (one limit shared
+	// by every instance; needs REDIS_URL)
+	Store string `yaml:"store"`
+}
+
+// Enabled reports whether requests are limited, so a nil section disables rate limiting
+func (c *RateLimitConfig) Enabled() bool {
+	return c != nil && c.Requests > 0
+}
+
+// rateLimitSchema validates the optional rate_limit section
+var rateLimitSchema = zog.Ptr(zog.Struct(zog.Shape{
+	"Requests": zog.Int().GT(0, zog.Message("rate_limit.requests must be positive")),
+	"Key":      zog.String().OneOf([]string{RateLimitKeyIP, RateLimitKeyUser}, zog.Message("rate_limit.key must be one of: ip, user")),
+	"Store":    zog.String().OneOf([]string{RateLimitStoreMemory, RateLimitStoreRedis}, zog.Message("rate_limit.store must be one of: memory, redis")),
+	// Window is a time.Duration, validated in TestFunc below
+}).TestFunc(func(rateLimit any, ctx zog.Ctx) bool {
+	r, ok := rateLimit.(*RateLimitConfig)
+	return ok && r.Window > 0
+}, zog.Message("rate_limit.window must be positive")))
+
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"regexp"
+
+	"github.com/Oudwins/zog"
+)
+
+type SecretsConfig struct {
+	// DynamoDB configuration (from environment variables)
+	AWSRegion          string `env:"AWS_REGION"`
+	TableName          string `env:"TABLE_NAME"`
+	EndpointURL        string `env:"DYNAMODB_ENDPOINT_URL"` // Optional: for local DynamoDB (e.g., http://localhost:8000)
+	AWSAccessKeyID     string `env:"AWS_ACCESS_KEY_ID"`
+	AWSSecretAccessKey string `env:"AWS_SECRET_ACCESS_KEY"`
+	// Shared config profile the AWS SDK loads credentials from, in place of access keys
+	AWSProfile string `env:"AWS_PROFILE"`
+	// Set by ECS when the task has an IAM role, in which case no access keys are needed
+	AWSContainerCredentialsURI string `env:"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"`
+	// Optional: role assumed with the default credentials chain, e.g. for cross-account table access
+	AWSRoleARN string `env:"AWS_ROLE_ARN"`
+
+	// Postgres configuration (from environment variables)
+	DatabaseURL string `env:"DATABASE_URL"`
+
+	// Auth secrets
+	JWTSecret string `env:"JWT_SECRET"`
+
+	// PostHog secrets
+	PostHogAPIKey string `env:"POSTHOG_API_KEY"`
+
+	// Redis for rate limits shared across instances (e.g. redis://localhost:6379/0)
+	RedisURL string `env:"REDIS_URL"`
+}
+
+// roleARNPattern matches IAM role ARNs (arn:<partition>:iam::<account-id>:role/<path/name>)
+var roleARNPattern = regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::\d{12}:role/[\w+=,.@/-]+$`)
+
+// secretsSchema validates the secrets loaded from environment variables
+var secretsSchema = zog.Struct(zog.Shape{
+	"AWSRegion":                  zog.String(),
+	"TableName":                  zog.String(),
+	"EndpointURL":                zog.String(),
+	"AWSAccessKeyID":             zog.String(),
+	"AWSSecretAccessKey":         zog.String(),
+	"AWSProfile":                 zog.String(),
+	"AWSContainerCredentialsURI": zog.String(),
+	"AWSRoleARN":                 zog.String().Match(roleARNPattern, zog.Message("AWS_ROLE_ARN must be an IAM role ARN (arn:aws:iam::<account-id>:role/<name>)")),
+	"DatabaseURL":                zog.String(),
+	"JWTSecret":                  zog.String(),
+	"PostHogAPIKey":              zog.String(),
+	"RedisURL":                   zog.String(),
+}).TestFunc(func(secrets any, ctx zog.Ctx) bool {
+	s, ok := secrets.(*SecretsConfig)
+	if !ok {
+		return false
+	}
+	hasDynamoDB := s.AWSRegion != "" || s.TableName != ""
+	hasPostgres := s.DatabaseURL != ""
+
+	if !hasDynamoDB && !hasPostgres {
+		return false
+	}
+	if hasDynamoDB && hasPostgres {
+		return false
+	}
+
+	if hasDynamoDB {
+		if s.AWSRegion == "" || s.TableName == "" {
+			return false
+		}
+		// Local endpoints, shared config profiles and ECS task roles don't need static credentials
+		if s.EndpointURL == "" && s.AWSProfile == "" && s.AWSContainerCredentialsURI == "" {
+			if s.AWSAccessKeyID == "" || s.AWSSecretAccessKey == "" {
+				return false
+			}
+		}
+	}
+
+	return true
+}, zog.Message("database configuration is invalid: must set either DynamoDB (AWS_REGION, TABLE_NAME) or Postgres (DATABASE_URL), but not both"))
+
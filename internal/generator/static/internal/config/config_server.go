@@ -0,0 +1,68 @@
+package config
+
+import (
+	"time"
+
+	"github.com/Oudwins/zog"
+)
+
+type ServerConfig struct {
+	Port       string `yaml:"port"`
+	Stage      Stage  `yaml:"stage"`
+	HealthPath string `yaml:"health_path"` // Health check endpoint, defaults to DefaultHealthPath
+	ReadyPath  string `yaml:"ready_path"`  // Readiness endpoint, defaults to DefaultReadyPath
+	// ShutdownGracePeriod is how long readiness fails before the server stops accepting
+	// connections on shutdown, so load balancers can stop routing traffic to it
+	ShutdownGracePeriod time.Duration `yaml:"shutdown_grace_period"`
+	// MaxConcurrentWrites bounds concurrent database writes across all batch requests (0 uses the service default)
+	MaxConcurrentWrites int `yaml:"max_concurrent_writes"`
+	// MaxInflight sheds requests with 503 once this many are being served (0 disables load shedding)
+	MaxInflight int `yaml:"max_inflight"`
+	// RequestTimeout bounds how long a request may run, defaults to DefaultRequestTimeout
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// MaxBodyBytes caps request body size; larger bodies get 413. Defaults to DefaultMaxBodyBytes
+	MaxBodyBytes int64 `yaml:"max_body_bytes"`
+	// MaxTitleLength and MaxContentLength bound post fields in characters (0 uses the service defaults)
+	MaxTitleLength   int `yaml:"max_title_length"`
+	MaxContentLength int `yaml:"max_content_length"`
+}
+
+// DefaultHealthPath is the health check endpoint used when server.health_path is not set
+const DefaultHealthPath = "/health"
+
+// DefaultReadyPath is the readiness endpoint used when server.ready_path is not set
+const DefaultReadyPath = "/ready"
+
+// DefaultRequestTimeout is the per-request deadline used when server.request_timeout is not set
+const DefaultRequestTimeout = 30 * time.Second
+
+// DefaultMaxBodyBytes is the request body limit (1 MiB) used when server.max_body_bytes is not set
+const DefaultMaxBodyBytes = 1 << 20
+
+// serverSchema validates the server section
+var serverSchema = zog.Struct(zog.Shape{
+	"Port":                zog.String().Min(1).Required(zog.Message("server.port is required")),
+	"HealthPath":          zog.String().HasPrefix("/", zog.Message("server.health_path must start with /")),
+	"ReadyPath":           zog.String().HasPrefix("/", zog.Message("server.ready_path must start with /")),
+	"MaxConcurrentWrites": zog.Int().GTE(0, zog.Message("server.max_concurrent_writes must not be negative")),
+	"MaxInflight":         zog.Int().GTE(0, zog.Message("server.max_inflight must not be negative")),
+	"MaxTitleLength":      zog.Int().GTE(0, zog.Message("server.max_title_length must not be negative")),
+	"MaxContentLength":    zog.Int().GTE(0, zog.Message("server.max_content_length must not be negative")),
+	// Stage is a custom type, validated in TestFunc below
+}).TestFunc(func(server any, ctx zog.Ctx) bool {
+	s, ok := server.(*ServerConfig)
+	if !ok {
+		return false
+	}
+	return s.Stage.IsValid()
+}, zog.Message("server.stage must be one of: "+stageNames())).TestFunc(func(server any, ctx zog.Ctx) bool {
+	s, ok := server.(*ServerConfig)
+	return ok && s.ShutdownGracePeriod >= 0
+}, zog.Message("server.shutdown_grace_period must not be negative")).TestFunc(func(server any, ctx zog.Ctx) bool {
+	s, ok := server.(*ServerConfig)
+	return ok && s.RequestTimeout >= 0
+}, zog.Message("server.request_timeout must not be negative")).TestFunc(func(server any, ctx zog.Ctx) bool {
+	s, ok := server.(*ServerConfig)
+	return ok && s.MaxBodyBytes >= 0
+}, zog.Message("server.max_body_bytes must not be negative"))
+
@@ -0,0 +1,509 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_LocalEnvFileLayering(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	writeFile := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	writeFile(".env", "DATABASE_URL=postgres://shared\nJWT_SECRET=shared-secret\nPOSTHOG_API_KEY=shared-key\n")
+	writeFile(".env.local", "DATABASE_URL=postgres://local\n")
+
+	t.Setenv("STAGE", "local")
+	t.Setenv("POSTHOG_API_KEY", "from-environment")
+	for _, key := range []string{"DATABASE_URL", "JWT_SECRET"} {
+		unsetEnv(t, key)
+	}
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "postgres://local", cfg.Secrets.DatabaseURL, ".env.local should override .env")
+	assert.Equal(t, "shared-secret", cfg.Secrets.JWTSecret, "values only in .env should be loaded")
+	assert.Equal(t, "from-environment", cfg.Secrets.PostHogAPIKey, "process environment should override env files")
+}
+
+func TestLoad_LocalRequiresEnvLocal(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("DATABASE_URL=postgres://shared\n"), 0644))
+	t.Setenv("STAGE", "local")
+
+	_, err := Load()
+	assert.ErrorContains(t, err, ".env.local")
+}
+
+func TestLoad_Staging(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env.staging"), []byte("DATABASE_URL=postgres://staging\n"), 0644))
+	t.Setenv("STAGE", "staging")
+	unsetEnv(t, "DATABASE_URL")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, StageStaging, cfg.Server.Stage, "staging.yaml should be loaded")
+	assert.Equal(t, "postgres://staging", cfg.Secrets.DatabaseURL, ".env.staging should be loaded")
+}
+
+func TestParseStage(t *testing.T) {
+	for _, stage := range Stages {
+		parsed, err := ParseStage(stage.String())
+		require.NoError(t, err)
+		assert.Equal(t, stage, parsed)
+
+		_, err = configFS.ReadFile(stage.String() + ".yaml")
+		assert.NoError(t, err, "every stage needs an embedded config file")
+	}
+
+	_, err := ParseStage("qa")
+	assert.ErrorContains(t, err, "must be one of: local, staging, production")
+}
+
+// unsetEnv unsets key for the duration of the test, restoring any previous value
+func unsetEnv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	require.NoError(t, os.Unsetenv(key))
+}
+
+func TestConfig_ValidateDynamoDBCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		secrets     SecretsConfig
+		expectedErr bool
+	}{
+		{
+			name:    "static credentials",
+			secrets: SecretsConfig{AWSRegion: "us-east-1", TableName: "posts", AWSAccessKeyID: "id", AWSSecretAccessKey: "secret"},
+		},
+		{
+			name:    "local endpoint without credentials",
+			secrets: SecretsConfig{AWSRegion: "us-east-1", TableName: "posts", EndpointURL: "http://localhost:8000"},
+		},
+		{
+			name:    "shared config profile without credentials",
+			secrets: SecretsConfig{AWSRegion: "us-east-1", TableName: "posts", AWSProfile: "dev"},
+		},
+		{
+			name:    "ECS task role without credentials",
+			secrets: SecretsConfig{AWSRegion: "us-east-1", TableName: "posts", AWSContainerCredentialsURI: "/v2/credentials/abc"},
+		},
+		{
+			name:        "no credentials source",
+			secrets:     SecretsConfig{AWSRegion: "us-east-1", TableName: "posts"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:  ServerConfig{Port: "8080", Stage: StageProduction},
+				Secrets: tt.secrets,
+			}
+			err := cfg.Validate()
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAWSRoleARN(t *testing.T) {
+	tests := []struct {
+		name        string
+		roleARN     string
+		expectedErr string
+	}{
+		{name: "unset uses the default chain"},
+		{name: "role ARN", roleARN: "arn:aws:iam::123456789012:role/posts-table"},
+		{name: "role ARN with path", roleARN: "arn:aws:iam::123456789012:role/service/posts-table"},
+		{name: "GovCloud partition", roleARN: "arn:aws-us-gov:iam::123456789012:role/posts-table"},
+		{name: "not an ARN", roleARN: "posts-table", expectedErr: "AWS_ROLE_ARN must be an IAM role ARN"},
+		{name: "user ARN", roleARN: "arn:aws:iam::123456789012:user/alice", expectedErr: "AWS_ROLE_ARN must be an IAM role ARN"},
+		{name: "short account ID", roleARN: "arn:aws:iam::1234:role/posts-table", expectedErr: "AWS_ROLE_ARN must be an IAM role ARN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server: ServerConfig{Port: "8080", Stage: StageProduction},
+				Secrets: SecretsConfig{
+					AWSRegion:          "us-east-1",
+					TableName:          "posts",
+					AWSAccessKeyID:     "id",
+					AWSSecretAccessKey: "secret",
+					AWSRoleARN:         tt.roleARN,
+				},
+			}
+			err := cfg.Validate()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+
+func TestConfig_ValidateStage(t *testing.T) {
+	cfg := &Config{
+		Server:  ServerConfig{Port: "8080", Stage: Stage("qa")},
+		Secrets: SecretsConfig{DatabaseURL: "postgres://localhost/posts"},
+	}
+	assert.ErrorContains(t, cfg.Validate(), "server.stage must be one of: local, staging, production")
+}
+
+func TestConfig_ValidateHealthPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		healthPath  string
+		expectedErr bool
+	}{
+		{name: "default path", healthPath: DefaultHealthPath},
+		{name: "custom path", healthPath: "/healthz"},
+		{name: "missing leading slash", healthPath: "healthz", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:  ServerConfig{Port: "8080", Stage: StageProduction, HealthPath: tt.healthPath},
+				Secrets: SecretsConfig{DatabaseURL: "postgres://localhost/posts"},
+			}
+			err := cfg.Validate()
+			if tt.expectedErr {
+				assert.ErrorContains(t, err, "server.health_path must start with /")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoad_HealthPath(t *testing.T) {
+	t.Setenv("STAGE", "production")
+	t.Setenv("DATABASE_URL", "postgres://localhost/posts")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultHealthPath, cfg.Server.HealthPath)
+}
+
+func TestLoad_ShutdownGracePeriod(t *testing.T) {
+	t.Setenv("STAGE", "production")
+	t.Setenv("DATABASE_URL", "postgres://localhost/posts")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, cfg.Server.ShutdownGracePeriod)
+	assert.Equal(t, DefaultReadyPath, cfg.Server.ReadyPath)
+}
+
+func TestLoad_RequestLimits(t *testing.T) {
+	t.Setenv("STAGE", "production")
+	t.Setenv("DATABASE_URL", "postgres://localhost/posts")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRequestTimeout, cfg.Server.RequestTimeout)
+	assert.Equal(t, int64(DefaultMaxBodyBytes), cfg.Server.MaxBodyBytes)
+}
+
+func TestConfig_ValidateShutdownGracePeriod(t *testing.T) {
+	tests := []struct {
+		name        string
+		gracePeriod time.Duration
+		expectedErr bool
+	}{
+		{name: "no grace period", gracePeriod: 0},
+		{name: "positive grace period", gracePeriod: 5 * time.Second},
+		{name: "negative grace period", gracePeriod: -time.Second, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:  ServerConfig{Port: "8080", Stage: StageProduction, ShutdownGracePeriod: tt.gracePeriod},
+				Secrets: SecretsConfig{DatabaseURL: "postgres://localhost/posts"},
+			}
+			err := cfg.Validate()
+			if tt.expectedErr {
+				assert.ErrorContains(t, err, "server.shutdown_grace_period must not be negative")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateMaxConcurrentWrites(t *testing.T) {
+	tests := []struct {
+		name                string
+		maxConcurrentWrites int
+		expectedErr         bool
+	}{
+		{name: "unset uses the default", maxConcurrentWrites: 0},
+		{name: "positive limit", maxConcurrentWrites: 25},
+		{name: "negative limit", maxConcurrentWrites: -1, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:  ServerConfig{Port: "8080", Stage: StageProduction, MaxConcurrentWrites: tt.maxConcurrentWrites},
+				Secrets: SecretsConfig{DatabaseURL: "postgres://localhost/posts"},
+			}
+			err := cfg.Validate()
+			if tt.expectedErr {
+				assert.ErrorContains(t, err, "server.max_concurrent_writes must not be negative")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateMaxInflight(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxInflight int
+		expectedErr bool
+	}{
+		{name: "unset disables load shedding", maxInflight: 0},
+		{name: "positive limit", maxInflight: 1000},
+		{name: "negative limit", maxInflight: -1, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:  ServerConfig{Port: "8080", Stage: StageProduction, MaxInflight: tt.maxInflight},
+				Secrets: SecretsConfig{DatabaseURL: "postgres://localhost/posts"},
+			}
+			err := cfg.Validate()
+			if tt.expectedErr {
+				assert.ErrorContains(t, err, "server.max_inflight must not be negative")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateRequestLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestTimeout time.Duration
+		maxBodyBytes   int64
+		expectedErr    string
+	}{
+		{name: "positive limits", requestTimeout: 30 * time.Second, maxBodyBytes: 1 << 20},
+		{name: "negative request timeout", requestTimeout: -time.Second, maxBodyBytes: 1 << 20, expectedErr: "server.request_timeout must not be negative"},
+		{name: "negative body limit", requestTimeout: 30 * time.Second, maxBodyBytes: -1, expectedErr: "server.max_body_bytes must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:  ServerConfig{Port: "8080", Stage: StageProduction, RequestTimeout: tt.requestTimeout, MaxBodyBytes: tt.maxBodyBytes},
+				Secrets: SecretsConfig{DatabaseURL: "postgres://localhost/posts"},
+			}
+			err := cfg.Validate()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateCORS(t *testing.T) {
+	tests := []struct {
+		name        string
+		cors        *CORSConfig
+		expectedErr string
+	}{
+		{name: "no cors section", cors: nil},
+		{name: "explicit origins with credentials", cors: &CORSConfig{AllowedOrigins: []string{"http://localhost:5173"}, AllowCredentials: true}},
+		{name: "any origin", cors: &CORSConfig{AllowedOrigins: []string{"*"}}},
+		{name: "any origin with credentials", cors: &CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}, expectedErr: "cors.allow_credentials requires explicit allowed_origins"},
+		{name: "negative max age", cors: &CORSConfig{AllowedOrigins: []string{"*"}, MaxAge: -1}, expectedErr: "cors.max_age must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:  ServerConfig{Port: "8080", Stage: StageProduction},
+				Secrets: SecretsConfig{DatabaseURL: "postgres://localhost/posts"},
+				CORS:    tt.cors,
+			}
+			err := cfg.Validate()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCORSConfig_Enabled(t *testing.T) {
+	var nilConfig *CORSConfig
+	assert.False(t, nilConfig.Enabled())
+	assert.False(t, (&CORSConfig{}).Enabled())
+	assert.True(t, (&CORSConfig{AllowedOrigins: []string{"http://localhost:5173"}}).Enabled())
+}
+
+func TestConfig_ValidatePostgres(t *testing.T) {
+	tests := []struct {
+		name        string
+		postgres    *PostgresConfig
+		expectedErr string
+	}{
+		{name: "no postgres section", postgres: nil},
+		{name: "empty section keeps defaults", postgres: &PostgresConfig{}},
+		{name: "tuned pool", postgres: &PostgresConfig{MaxConns: 20, MinConns: 5, MaxConnLifetime: time.Hour, MaxConnIdleTime: time.Minute, StatementCacheCapacity: 128}},
+		{name: "only min conns", postgres: &PostgresConfig{MinConns: 4}},
+		{name: "negative max conns", postgres: &PostgresConfig{MaxConns: -1}, expectedErr: "postgres.max_conns must not be negative"},
+		{name: "negative min conns", postgres: &PostgresConfig{MinConns: -1}, expectedErr: "postgres.min_conns must not be negative"},
+		{name: "negative statement cache", postgres: &PostgresConfig{StatementCacheCapacity: -1}, expectedErr: "postgres.statement_cache_capacity must not be negative"},
+		{name: "min conns above max conns", postgres: &PostgresConfig{MaxConns: 2, MinConns: 5}, expectedErr: "postgres.min_conns must not exceed postgres.max_conns"},
+		{name: "negative lifetime", postgres: &PostgresConfig{MaxConnLifetime: -time.Minute}, expectedErr: "must not be negative"},
+		{name: "negative idle time", postgres: &PostgresConfig{MaxConnIdleTime: -time.Minute}, expectedErr: "must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:   ServerConfig{Port: "8080", Stage: StageProduction},
+				Secrets:  SecretsConfig{DatabaseURL: "postgres://localhost/posts"},
+				Postgres: tt.postgres,
+			}
+			err := cfg.Validate()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateDynamoDBRetries(t *testing.T) {
+	tests := []struct {
+		name        string
+		dynamoDB    *DynamoDBConfig
+		expectedErr string
+	}{
+		{name: "no dynamodb section", dynamoDB: nil},
+		{name: "empty section keeps defaults", dynamoDB: &DynamoDBConfig{}},
+		{name: "tuned retries", dynamoDB: &DynamoDBConfig{MaxRetries: 10, MaxBackoff: 2 * time.Second}},
+		{name: "negative max retries", dynamoDB: &DynamoDBConfig{MaxRetries: -1}, expectedErr: "dynamodb.max_retries must not be negative"},
+		{name: "negative max backoff", dynamoDB: &DynamoDBConfig{MaxBackoff: -time.Second}, expectedErr: "dynamodb.max_backoff must not be negative"},
+		{name: "read after write wait", dynamoDB: &DynamoDBConfig{ReadAfterWriteWait: time.Second}},
+		{name: "negative read after write wait", dynamoDB: &DynamoDBConfig{ReadAfterWriteWait: -time.Second}, expectedErr: "dynamodb.read_after_write_wait must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:   ServerConfig{Port: "8080", Stage: StageProduction},
+				Secrets:  SecretsConfig{AWSRegion: "us-east-1", TableName: "posts", EndpointURL: "http://localhost:8000"},
+				DynamoDB: tt.dynamoDB,
+			}
+			err := cfg.Validate()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAuth(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenExpiry time.Duration
+		jwtSecret   string
+		expectedErr string
+	}{
+		{name: "valid auth config", tokenExpiry: time.Hour, jwtSecret: "secret"},
+		{name: "unset expiry uses the default", jwtSecret: "secret"},
+		{name: "missing JWT secret", tokenExpiry: time.Hour, expectedErr: "JWT_SECRET is required when auth is enabled"},
+		{name: "negative expiry", tokenExpiry: -time.Hour, jwtSecret: "secret", expectedErr: "auth.token_expiry must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Server:  ServerConfig{Port: "8080", Stage: StageProduction},
+				Auth:    &AuthConfig{TokenExpiry: tt.tokenExpiry},
+				Secrets: SecretsConfig{DatabaseURL: "postgres://localhost/posts", JWTSecret: tt.jwtSecret},
+			}
+			err := cfg.Validate()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateComposedSections(t *testing.T) {
+	valid := func() *Config {
+		return &Config{
+			Server:  ServerConfig{Port: "8080", Stage: StageProduction},
+			Auth:    &AuthConfig{TokenExpiry: time.Hour},
+			Metrics: &MetricsConfig{Enabled: true, Path: "/metrics"},
+			PostHog: &PostHogConfig{Enabled: true, Host: "https://us.i.posthog.com"},
+			Secrets: SecretsConfig{DatabaseURL: "postgres://localhost/posts", JWTSecret: "secret", PostHogAPIKey: "key"},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr string
+	}{
+		{name: "all sections valid", modify: func(*Config) {}},
+		{name: "optional sections omitted", modify: func(c *Config) { c.Auth, c.Metrics, c.PostHog = nil, nil, nil }},
+		{name: "server section", modify: func(c *Config) { c.Server.Port = "" }, expectedErr: "server.port is required"},
+		{name: "secrets section", modify: func(c *Config) { c.Secrets.DatabaseURL = "" }, expectedErr: "database configuration is invalid"},
+		{name: "metrics section", modify: func(c *Config) { c.Metrics.Path = "metrics" }, expectedErr: "metrics.path must start with /"},
+		{name: "auth section", modify: func(c *Config) { c.Auth.TokenExpiry = -time.Hour }, expectedErr: "auth.token_expiry must not be negative"},
+		{name: "posthog section", modify: func(c *Config) { c.Secrets.PostHogAPIKey = "" }, expectedErr: "POSTHOG_API_KEY is required when posthog is enabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
@@ -1,15 +1,24 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
 
 // Stage represents the deployment stage/environment
 type Stage string
 
 const (
 	StageLocal      Stage = "local"
+	StageStaging    Stage = "staging"
 	StageProduction Stage = "production"
 )
 
+// Stages lists every known stage. To add a stage, declare it above, add it here and
+// add a matching <stage>.yaml next to this file; all yaml files are embedded.
+var Stages = []Stage{StageLocal, StageStaging, StageProduction}
+
 // String returns the string representation of the stage
 func (s Stage) String() string {
 	return string(s)
@@ -20,6 +29,11 @@ func (s Stage) IsLocal() bool {
 	return s == StageLocal
 }
 
+// IsStaging returns true if the stage is staging
+func (s Stage) IsStaging() bool {
+	return s == StageStaging
+}
+
 // IsProduction returns true if the stage is production
 func (s Stage) IsProduction() bool {
 	return s == StageProduction
@@ -27,7 +41,7 @@ func (s Stage) IsProduction() bool {
 
 // IsValid returns true if the stage is a valid known stage
 func (s Stage) IsValid() bool {
-	return s == StageLocal || s == StageProduction
+	return slices.Contains(Stages, s)
 }
 
 // ParseStage parses a string into a Stage enum and validates it
@@ -35,8 +49,16 @@ func (s Stage) IsValid() bool {
 func ParseStage(s string) (Stage, error) {
 	stage := Stage(s)
 	if !stage.IsValid() {
-		return "", fmt.Errorf("unknown stage: %s (must be one of: %s, %s)", s, StageLocal, StageProduction)
+		return "", fmt.Errorf("unknown stage: %s (must be one of: %s)", s, stageNames())
 	}
 	return stage, nil
 }
 
+// stageNames returns the known stages as a comma separated list for error messages
+func stageNames() string {
+	names := make([]string, len(Stages))
+	for i, known := range Stages {
+		names[i] = known.String()
+	}
+	return strings.Join(names, ", ")
+}
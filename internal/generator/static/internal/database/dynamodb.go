@@ -2,11 +2,22 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/ratelimit"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// Retry defaults for throttled DynamoDB requests
+const (
+	DefaultMaxRetries = 5
+	DefaultMaxBackoff = 5 * time.Second
 )
 
 type DynamoDBOption func(*aws.Config)
@@ -28,6 +39,51 @@ func WithRegion(region string) DynamoDBOption {
 	}
 }
 
+// WithAssumeRole assumes roleARN (e.g. for cross-account table access) using the credentials
+// from the default chain. Apply it after WithRegion so STS is called in the same region.
+// An empty roleARN leaves the default credentials chain in place.
+func WithAssumeRole(roleARN string) DynamoDBOption {
+	return func(cfg *aws.Config) {
+		if roleARN != "" {
+			provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(*cfg), roleARN)
+			cfg.Credentials = aws.NewCredentialsCache(provider)
+		}
+	}
+}
+
+// WithMaxRetries sets how many times a throttled or transiently failing request is retried
+// Values below 1 keep DefaultMaxRetries
+func WithMaxRetries(n int) DynamoDBOption {
+	return func(cfg *aws.Config) {
+		if n > 0 {
+			cfg.RetryMaxAttempts = n + 1
+		}
+	}
+}
+
+// WithMaxBackoff caps the exponential backoff between retries
+// Values below 1 keep DefaultMaxBackoff
+func WithMaxBackoff(d time.Duration) DynamoDBOption {
+	return func(cfg *aws.Config) {
+		if d > 0 {
+			cfg.Retryer = newRetryer(d)
+		}
+	}
+}
+
+// newRetryer returns the SDK standard retryer, which backs off exponentially with jitter on
+// ProvisionedThroughputExceededException, ThrottlingException and other retryable errors.
+// The client-side retry quota is disabled so a burst of throttled writes is retried
+// instead of failing once the quota is spent.
+func newRetryer(maxBackoff time.Duration) func() aws.Retryer {
+	return func() aws.Retryer {
+		return retry.NewStandard(func(o *retry.StandardOptions) {
+			o.MaxBackoff = maxBackoff
+			o.RateLimiter = ratelimit.None
+		})
+	}
+}
+
 // NewDynamoDB creates a new DynamoDB client
 // Uses default AWS SDK configuration which will use IAM roles when running on AWS infrastructure
 // (EC2, ECS, Lambda, etc.) or environment credentials
@@ -37,6 +93,8 @@ func NewDynamoDB(ctx context.Context, opts ...DynamoDBOption) (*dynamodb.Client,
 	if err != nil {
 		return nil, err
 	}
+	cfg.Retryer = newRetryer(DefaultMaxBackoff)
+	cfg.RetryMaxAttempts = DefaultMaxRetries + 1
 
 	// Apply options
 	for _, opt := range opts {
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"hash/crc32"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newThrottlingServer fakes a DynamoDB endpoint that rejects the first throttledAttempts
+// requests with ProvisionedThroughputExceededException and accepts the rest
+func newThrottlingServer(t *testing.T, throttledAttempts int64) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+
+	var attempts atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, body := http.StatusOK, `{}`
+		if attempts.Add(1) <= throttledAttempts {
+			status = http.StatusBadRequest
+			body = `{"__type":"com.amazonaws.dynamodb.v20120810#ProvisionedThroughputExceededException","message":"Rate of requests exceeds the allowed throughput"}`
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		w.Header().Set("X-Amz-Crc32", strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(body))), 10))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &attempts
+}
+
+func putItem(ctx context.Context, client *dynamodb.Client) error {
+	_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String("posts"),
+		Item: map[string]types.AttributeValue{
+			"post_id": &types.AttributeValueMemberS{Value: "1"},
+		},
+	})
+	return err
+}
+
+func TestNewDynamoDB_RetriesThrottledRequests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		maxRetries        int
+		throttledAttempts int64
+		expectedAttempts  int64
+		expectedErr       string
+	}{
+		{name: "succeeds after throttling", maxRetries: 3, throttledAttempts: 2, expectedAttempts: 3},
+		{name: "default retries", throttledAttempts: DefaultMaxRetries, expectedAttempts: DefaultMaxRetries + 1},
+		{name: "gives up after max retries", maxRetries: 2, throttledAttempts: 10, expectedAttempts: 3, expectedErr: "ProvisionedThroughputExceededException"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, attempts := newThrottlingServer(t, tt.throttledAttempts)
+			client, err := NewDynamoDB(context.Background(),
+				WithRegion("us-east-1"),
+				WithEndpoint(server.URL),
+				WithMaxRetries(tt.maxRetries),
+				WithMaxBackoff(time.Millisecond),
+			)
+			require.NoError(t, err)
+
+			err = putItem(context.Background(), client)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedAttempts, attempts.Load())
+		})
+	}
+}
+
+func TestNewDynamoDB_BurstWritesSurviveThrottling(t *testing.T) {
+	t.Parallel()
+
+	const writes = 50
+	// Every write is throttled at least once before the table catches up
+	server, _ := newThrottlingServer(t, writes*2)
+	client, err := NewDynamoDB(context.Background(),
+		WithRegion("us-east-1"),
+		WithEndpoint(server.URL),
+		WithMaxRetries(10),
+		WithMaxBackoff(time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writes)
+	for range writes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- putItem(context.Background(), client)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+
+func TestNewDynamoDB_LocalEndpointNeedsNoAWSCredentials(t *testing.T) {
+	// No credentials in the environment or shared files, as on a laptop running DynamoDB Local
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_CONFIG_FILE", t.TempDir()+"/config")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", t.TempDir()+"/credentials")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	server, attempts := newThrottlingServer(t, 0)
+	client, err := NewDynamoDB(context.Background(), WithRegion("us-east-1"), WithEndpoint(server.URL))
+	require.NoError(t, err)
+
+	require.NoError(t, putItem(context.Background(), client))
+	assert.Equal(t, int64(1), attempts.Load(), "the request should reach the local endpoint")
+}
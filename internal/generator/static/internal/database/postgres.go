@@ -3,25 +3,81 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// NewPostgres creates a new PostgreSQL connection pool
-// This is provider-agnostic and works with any PostgreSQL database (Supabase, AWS RDS, etc.)
-func NewPostgres(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
-	if databaseURL == "" {
-		return nil, fmt.Errorf("database URL is required")
+// Default connection pool settings, used unless overridden with a PostgresOption
+const (
+	DefaultMaxConns        = 10
+	DefaultMinConns        = 2
+	DefaultMaxConnLifetime = time.Hour
+	DefaultMaxConnIdleTime = 30 * time.Minute
+	// DefaultStatementCacheCapacity is how many prepared statements each connection keeps.
+	// The posts table has a handful of fixed queries, plus one multi-row insert per batch size.
+	DefaultStatementCacheCapacity = 512
+)
+
+// PostgresOption configures the connection pool
+type PostgresOption func(*pgxpool.Config)
+
+// WithMaxConns sets the maximum number of open connections (0 keeps DefaultMaxConns)
+func WithMaxConns(n int) PostgresOption {
+	return func(cfg *pgxpool.Config) {
+		if n > 0 {
+			cfg.MaxConns = int32(n)
+		}
 	}
+}
 
-	config, err := pgxpool.ParseConfig(databaseURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse database URL: %w", err)
+// WithMinConns sets how many idle connections the pool keeps open (0 keeps DefaultMinConns)
+func WithMinConns(n int) PostgresOption {
+	return func(cfg *pgxpool.Config) {
+		if n > 0 {
+			cfg.MinConns = int32(n)
+		}
 	}
+}
 
-	// Configure pool settings
-	config.MaxConns = 10
-	config.MinConns = 2
+// WithMaxConnLifetime sets how long a connection is reused before it is closed and replaced,
+// e.g. to pick up DNS changes after a failover (0 keeps DefaultMaxConnLifetime)
+func WithMaxConnLifetime(d time.Duration) PostgresOption {
+	return func(cfg *pgxpool.Config) {
+		if d > 0 {
+			cfg.MaxConnLifetime = d
+		}
+	}
+}
+
+// WithMaxConnIdleTime sets how long an idle connection is kept before it is closed
+// (0 keeps DefaultMaxConnIdleTime)
+func WithMaxConnIdleTime(d time.Duration) PostgresOption {
+	return func(cfg *pgxpool.Config) {
+		if d > 0 {
+			cfg.MaxConnIdleTime = d
+		}
+	}
+}
+
+// WithStatementCacheCapacity sets how many prepared statements each connection caches
+// (0 keeps DefaultStatementCacheCapacity)
+func WithStatementCacheCapacity(n int) PostgresOption {
+	return func(cfg *pgxpool.Config) {
+		if n > 0 {
+			cfg.ConnConfig.StatementCacheCapacity = n
+		}
+	}
+}
+
+// NewPostgres creates a new PostgreSQL connection pool
+// This is provider-agnostic and works with any PostgreSQL database (Supabase, AWS RDS, etc.)
+func NewPostgres(ctx context.Context, databaseURL string, opts ...PostgresOption) (*pgxpool.Pool, error) {
+	config, err := newPostgresConfig(databaseURL, opts...)
+	if err != nil {
+		return nil, err
+	}
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -37,3 +93,33 @@ func NewPostgres(ctx context.Context, databaseURL string) (*pgxpool.Pool, error)
 	return pool, nil
 }
 
+// newPostgresConfig parses databaseURL and applies the default pool settings, then opts
+func newPostgresConfig(databaseURL string, opts ...PostgresOption) (*pgxpool.Config, error) {
+	if databaseURL == "" {
+		return nil, fmt.Errorf("database URL is required")
+	}
+
+	config, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database URL: %w", err)
+	}
+
+	// Configure pool settings
+	config.MaxConns = DefaultMaxConns
+	config.MinConns = DefaultMinConns
+	config.MaxConnLifetime = DefaultMaxConnLifetime
+	config.MaxConnIdleTime = DefaultMaxConnIdleTime
+	// Prepare each query once per connection and reuse it, so repeated queries skip parsing
+	// and planning on the server
+	config.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+	config.ConnConfig.StatementCacheCapacity = DefaultStatementCacheCapacity
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.MinConns > config.MaxConns {
+		return nil, fmt.Errorf("min conns (%d) must not exceed max conns (%d)", config.MinConns, config.MaxConns)
+	}
+
+	return config, nil
+}
+
@@ -0,0 +1,50 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// Readiness serves the readiness endpoint. It reports ready until Drain is called,
+// so load balancers stop routing new traffic before the server shuts down.
+type Readiness struct {
+	draining atomic.Bool
+}
+
+// NewReadiness creates a Readiness that reports ready
+func NewReadiness() *Readiness {
+	return &Readiness{}
+}
+
+// Ready reports whether the service should receive traffic
+func (r *Readiness) Ready() bool {
+	return !r.draining.Load()
+}
+
+// ServeHTTP responds 200 while ready and 503 once draining has started
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !r.Ready() {
+		http.Error(w, "shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	w.Write([]byte("."))
+}
+
+// Drain flips readiness to 503 and waits for gracePeriod (or until ctx is done), giving
+// load balancers time to notice before the server stops accepting connections
+func (r *Readiness) Drain(ctx context.Context, gracePeriod time.Duration) {
+	r.draining.Store(true)
+	if gracePeriod <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readinessStatus(r *Readiness) int {
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	return rec.Code
+}
+
+func TestReadiness_NotReadyAfterShutdownBegins(t *testing.T) {
+	t.Parallel()
+
+	r := NewReadiness()
+	assert.Equal(t, http.StatusOK, readinessStatus(r))
+
+	done := make(chan struct{})
+	go func() {
+		r.Drain(context.Background(), time.Second)
+		close(done)
+	}()
+
+	// Readiness fails during the grace period, while the server is still accepting connections
+	assert.Eventually(t, func() bool {
+		return readinessStatus(r) == http.StatusServiceUnavailable
+	}, 500*time.Millisecond, 5*time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("Drain returned before the grace period elapsed")
+	default:
+	}
+
+	<-done
+	assert.Equal(t, http.StatusServiceUnavailable, readinessStatus(r))
+}
+
+func TestReadiness_DrainGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		gracePeriod time.Duration
+		cancel      bool
+		minWait     time.Duration
+	}{
+		{name: "no grace period", gracePeriod: 0},
+		{name: "waits for the grace period", gracePeriod: 50 * time.Millisecond, minWait: 50 * time.Millisecond},
+		{name: "stops waiting when the context is done", gracePeriod: time.Hour, cancel: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			r := NewReadiness()
+			start := time.Now()
+			r.Drain(ctx, tt.gracePeriod)
+
+			assert.GreaterOrEqual(t, time.Since(start), tt.minWait)
+			assert.Less(t, time.Since(start), time.Second)
+			assert.False(t, r.Ready())
+		})
+	}
+}
+
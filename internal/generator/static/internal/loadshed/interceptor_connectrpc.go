@@ -0,0 +1,25 @@
+//go:build ignore
+
+package loadshed
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+)
+
+// Interceptor rejects RPCs beyond the inflight limit with CodeUnavailable,
+// which clients treat as retryable
+func (l *Limiter) Interceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if !l.acquire() {
+				return nil, connect.NewError(connect.CodeUnavailable, errors.New("server is overloaded, retry later"))
+			}
+			defer l.release()
+			return next(ctx, req)
+		}
+	}
+}
+
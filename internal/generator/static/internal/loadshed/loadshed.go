@@ -0,0 +1,85 @@
+package loadshed
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// Limiter sheds requests once more than maxInflight are being served, so the service
+// answers excess traffic immediately with 503 instead of queuing it unboundedly
+type Limiter struct {
+	maxInflight int64
+	inflight    atomic.Int64
+	onShed      func()
+	exemptPaths map[string]bool
+}
+
+// Option configures optional limiter behavior
+type Option func(*Limiter)
+
+// WithOnShed sets a callback run for every shed request (e.g. to count them in metrics)
+func WithOnShed(onShed func()) Option {
+	return func(l *Limiter) {
+		l.onShed = onShed
+	}
+}
+
+// WithExemptPaths excludes paths (health checks, readiness, metrics) from shedding,
+// so probes and scrapes keep working under overload
+func WithExemptPaths(paths ...string) Option {
+	return func(l *Limiter) {
+		for _, path := range paths {
+			l.exemptPaths[path] = true
+		}
+	}
+}
+
+// New creates a limiter allowing up to maxInflight concurrent requests
+func New(maxInflight int, opts ...Option) *Limiter {
+	l := &Limiter{
+		maxInflight: int64(maxInflight),
+		exemptPaths: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// acquire reserves an inflight slot, reporting false (and counting the request as shed)
+// if the limit is reached. Callers that acquire a slot must release it.
+func (l *Limiter) acquire() bool {
+	if l.inflight.Add(1) > l.maxInflight {
+		l.inflight.Add(-1)
+		if l.onShed != nil {
+			l.onShed()
+		}
+		return false
+	}
+	return true
+}
+
+func (l *Limiter) release() {
+	l.inflight.Add(-1)
+}
+
+// Middleware rejects requests beyond the inflight limit with 503 and a Retry-After header
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.exemptPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !l.acquire() {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(map[string]map[string]string{"error": {"code": "OVERLOADED", "message": "Server is overloaded, retry later"}})
+			return
+		}
+		defer l.release()
+		next.ServeHTTP(w, r)
+	})
+}
+
@@ -0,0 +1,104 @@
+package loadshed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Middleware(t *testing.T) {
+	t.Parallel()
+
+	const maxInflight, excess = 3, 5
+
+	var shed atomic.Int64
+	limiter := New(maxInflight, WithOnShed(func() { shed.Add(1) }))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Fill every inflight slot with a request blocked in the handler
+	var wg sync.WaitGroup
+	statuses := make(chan int, maxInflight)
+	for range maxInflight {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts", nil))
+			statuses <- rec.Code
+		}()
+	}
+	for range maxInflight {
+		<-started
+	}
+
+	// Concurrent requests past the limit are shed immediately
+	var excessWG sync.WaitGroup
+	excessStatuses := make(chan *httptest.ResponseRecorder, excess)
+	for range excess {
+		excessWG.Add(1)
+		go func() {
+			defer excessWG.Done()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts", nil))
+			excessStatuses <- rec
+		}()
+	}
+	excessWG.Wait()
+	close(excessStatuses)
+	for rec := range excessStatuses {
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{"error":{"code":"OVERLOADED","message":"Server is overloaded, retry later"}}`, rec.Body.String())
+	}
+	assert.EqualValues(t, excess, shed.Load())
+
+	// The admitted requests complete normally
+	close(release)
+	wg.Wait()
+	close(statuses)
+	for status := range statuses {
+		assert.Equal(t, http.StatusOK, status)
+	}
+
+	// Slots are released once requests finish
+	rec := httptest.NewRecorder()
+	go func() { <-started }()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.EqualValues(t, excess, shed.Load())
+}
+
+func TestLimiter_ExemptPaths(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(0, WithExemptPaths("/health"))
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+	}{
+		{path: "/health", expectedStatus: http.StatusOK},
+		{path: "/posts", expectedStatus: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, tt.expectedStatus, rec.Code, tt.path)
+	}
+}
+
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unmatchedRoute labels requests that didn't match a registered route, so unknown
+// paths can't each create their own series
+const unmatchedRoute = "unmatched"
+
+// Metrics holds the service's Prometheus collectors.
+// Requests are labeled by route template (e.g. /posts/{post_id}), never the raw path,
+// which would create a series per post ID.
+type Metrics struct {
+	registry            *prometheus.Registry
+	requestsTotal       *prometheus.CounterVec
+	requestDuration     *prometheus.HistogramVec
+	shedRequests        prometheus.Counter
+	dbOperationDuration *prometheus.HistogramVec
+}
+
+// New creates the service metrics in their own registry, along with the Go runtime
+// and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by method, route template and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		shedRequests: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "http_requests_shed_total",
+			Help: "Total requests rejected because the server was at its inflight limit.",
+		}),
+		dbOperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_operation_duration_seconds",
+			Help:    "Database operation latency by posts table operation.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation"}),
+	}
+	m.registry.MustRegister(
+		m.requestsTotal,
+		m.requestDuration,
+		m.shedRequests,
+		m.dbOperationDuration,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records a completed request. route must be a route template
+// (or procedure name), not the request path.
+func (m *Metrics) ObserveRequest(method, route, status string, duration time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+	m.requestsTotal.WithLabelValues(method, route, status).Inc()
+	m.requestDuration.WithLabelValues(method, route, status).Observe(duration.Seconds())
+}
+
+// ObserveShed records a request rejected by load shedding
+func (m *Metrics) ObserveShed() {
+	m.shedRequests.Inc()
+}
+
+// ObserveDBOperation records a completed database operation, failed or not. operation is the
+// posts table method, e.g. GetPostByID.
+func (m *Metrics) ObserveDBOperation(operation string, duration time.Duration) {
+	m.dbOperationDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the underlying ResponseWriter
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Flush implements http.Flusher, which streaming RPCs (e.g. gRPC server reflection) require
+func (r *statusRecorder) Flush() {
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+func statusLabel(status int) string {
+	return strconv.Itoa(status)
+}
+
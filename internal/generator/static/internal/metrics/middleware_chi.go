@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Middleware records request metrics labeled by the matched chi route pattern
+// (e.g. /posts/{post_id}) rather than the raw path
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// The route pattern is only known once the router has matched the request
+		var route string
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			route = rctx.RoutePattern()
+		}
+		m.ObserveRequest(r.Method, route, statusLabel(rec.status), time.Since(start))
+	})
+}
+
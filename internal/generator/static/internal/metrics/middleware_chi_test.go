@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouter(m *Metrics) http.Handler {
+	r := chi.NewRouter()
+	r.Use(m.Middleware)
+	r.Route("/posts", func(r chi.Router) {
+		r.Get("/{post_id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+	return r
+}
+
+func TestMiddleware_LabelsByRoutePattern(t *testing.T) {
+	t.Parallel()
+
+	m := New()
+	router := newTestRouter(m)
+
+	// Requests for different posts share one series labeled with the route template
+	for range 2 {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts/"+uuid.NewString(), nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	assert.Equal(t, 1, testutil.CollectAndCount(m.requestsTotal))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.requestsTotal.WithLabelValues(http.MethodGet, "/posts/{post_id}", "200")))
+}
+
+func TestMiddleware_UnmatchedRoutes(t *testing.T) {
+	t.Parallel()
+
+	m := New()
+	router := newTestRouter(m)
+
+	// Unknown paths are grouped together instead of creating a series per path
+	for _, path := range []string{"/unknown", "/also/unknown"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	}
+
+	assert.Equal(t, 1, testutil.CollectAndCount(m.requestsTotal))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.requestsTotal.WithLabelValues(http.MethodGet, unmatchedRoute, "404")))
+}
+
+
+func TestMiddleware_Flush(t *testing.T) {
+	t.Parallel()
+
+	// Streaming handlers need the wrapped ResponseWriter to stay flushable
+	handler := New().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok, "ResponseWriter should implement http.Flusher")
+		flusher.Flush()
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	assert.True(t, rec.Flushed)
+}
@@ -0,0 +1,29 @@
+//go:build ignore
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// Interceptor records request metrics labeled by RPC procedure
+// (e.g. /posts.v1.PostService/GetPost) and Connect status code
+func (m *Metrics) Interceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			status := "ok"
+			if err != nil {
+				status = connect.CodeOf(err).String()
+			}
+			m.ObserveRequest(req.HTTPMethod(), req.Spec().Procedure, status, time.Since(start))
+			return resp, err
+		}
+	}
+}
+
@@ -0,0 +1,37 @@
+//go:build ignore
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Middleware records request metrics labeled by the matched echo route
+// (e.g. /posts/:post_id) rather than the raw path
+func (m *Metrics) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			// Errors returned to echo haven't been written to the response yet
+			status := c.Response().Status
+			if err != nil {
+				status = http.StatusInternalServerError
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					status = httpErr.Code
+				}
+			}
+
+			// c.Path() is empty when no route matched
+			m.ObserveRequest(c.Request().Method, c.Path(), statusLabel(status), time.Since(start))
+			return err
+		}
+	}
+}
+
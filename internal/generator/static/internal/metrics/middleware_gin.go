@@ -0,0 +1,20 @@
+//go:build ignore
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware records request metrics labeled by the matched gin route
+// (e.g. /posts/:post_id) rather than the raw path
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		m.ObserveRequest(c.Request.Method, c.FullPath(), statusLabel(c.Writer.Status()), time.Since(start))
+	}
+}
+
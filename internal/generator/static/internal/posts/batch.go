@@ -0,0 +1,241 @@
+package posts
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMaxConcurrentWrites bounds concurrent table writes across all batches when not configured
+const DefaultMaxConcurrentWrites = 10
+
+// MaxBatchSize is the largest number of posts accepted in a single batch
+const MaxBatchSize = 100
+
+// PostInput is a single post to create in a batch
+type PostInput struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// BatchResult is the outcome of one batch item; results are returned in request order.
+// Duplicate is set, with no Post or Err, for an item skipped by deduplication.
+type BatchResult struct {
+	Index     int
+	Post      *Post
+	Err       error
+	Duplicate bool
+}
+
+// DedupeQueryParam is the POST /posts/batch query parameter (e.g. ?dedupe=true) that makes
+// re-running the same batch safe by skipping posts that were already created
+const DedupeQueryParam = "dedupe"
+
+// contentHashNamespace seeds the name-based post IDs derived from post content hashes
+var contentHashNamespace = uuid.MustParse("b3e0c1d4-7a92-4f5e-8c6b-1f4d2a9e7c53")
+
+// parseDedupe parses the dedupe query parameter; an empty value means no deduplication
+func parseDedupe(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// contentHashPostID derives the ID of a deduplicated post from a hash of its user, title and
+// content. Like idempotency keys, the hash to post mapping is the post ID itself, so tables
+// only need to refuse inserting an ID twice (see PutPostIfNotExists).
+func contentHashPostID(userID uuid.UUID, title, content string) uuid.UUID {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s", userID, title, content)
+	return uuid.NewSHA1(contentHashNamespace, hash.Sum(nil))
+}
+
+// batchWriteSize is how many posts CreatePosts hands to the table per PutPosts call,
+// matching DynamoDB's BatchWriteItem limit
+const batchWriteSize = 25
+
+// CreatePosts creates posts for userID with batched table writes of batchWriteSize posts,
+// waiting for one of the service's write slots before each write so that all concurrent
+// CreatePosts calls together run at most maxConcurrentWrites writes. Each batch succeeds or
+// fails independently so callers get partial results.
+// With dedupe set, each post's ID is derived from its content and posts are written one at a
+// time with PutPostIfNotExists, so a post created by an earlier deduplicating batch, or
+// repeated within this one, is skipped as a duplicate. A post
+// keeps its ID when edited, so its original content still counts as a duplicate until deleted.
+func (s *service) CreatePosts(ctx context.Context, userID uuid.UUID, inputs []PostInput, dedupe bool) ([]BatchResult, error) {
+	if len(inputs) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+
+	// Posts in a batch get increasing creation times so they keep request order and
+	// never share a (UserID, CreatedAt) key in DynamoDB
+	// Invalid items fail on their own with a *ValidationError; only valid ones are written
+	seen := make(map[uuid.UUID]bool)
+	now := time.Now()
+	var posts []*Post
+	var postIndexes []int
+	results := make([]BatchResult, len(inputs))
+	for i, input := range inputs {
+		results[i].Index = i
+		if err := s.validatePost(&input.Title, &input.Content); err != nil {
+			results[i].Err = err
+			continue
+		}
+		post := NewPost(userID, input.Title, input.Content)
+		if dedupe {
+			post.ID = contentHashPostID(userID, post.Title, post.Content)
+			if seen[post.ID] {
+				results[i].Duplicate = true
+				continue
+			}
+			seen[post.ID] = true
+		}
+		post.CreatedAt = now.Add(time.Duration(i) * time.Millisecond)
+		post.UpdatedAt = post.CreatedAt
+		posts = append(posts, post)
+		postIndexes = append(postIndexes, i)
+	}
+
+	batchSize, write := batchWriteSize, s.postTable.PutPosts
+	if dedupe {
+		batchSize = 1
+		write = func(ctx context.Context, batch []*Post) error {
+			return s.postTable.PutPostIfNotExists(ctx, batch[0])
+		}
+	}
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(posts); start += batchSize {
+		end := min(start+batchSize, len(posts))
+
+		// Block until a write slot frees up, applying backpressure to the batch
+		select {
+		case s.writeSlots <- struct{}{}:
+		case <-ctx.Done():
+			for _, i := range postIndexes[start:end] {
+				results[i].Err = ctx.Err()
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-s.writeSlots }()
+			// select picks at random when ctx is done and a slot is free too, so never
+			// start a write with a canceled context
+			if err := ctx.Err(); err != nil {
+				for _, i := range postIndexes[start:end] {
+					results[i].Err = err
+				}
+				return
+			}
+			err := write(ctx, posts[start:end])
+			if dedupe && errors.Is(err, ErrPostExists) {
+				results[postIndexes[start]].Duplicate = true
+				return
+			}
+			if err != nil {
+				slog.ErrorContext(ctx, "Service: failed to create posts", "error", err, "user_id", userID, "count", end-start)
+				for _, i := range postIndexes[start:end] {
+					results[i].Err = fmt.Errorf("failed to create post: %w", err)
+				}
+				return
+			}
+			for j, i := range postIndexes[start:end] {
+				results[i].Post = posts[start+j]
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// GetPostsByIDs returns the posts with the given IDs in request order. IDs that don't
+// match a post are skipped, so the result can be shorter than postIDs.
+func (s *service) GetPostsByIDs(ctx context.Context, postIDs []uuid.UUID) ([]Post, error) {
+	if len(postIDs) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+	if len(postIDs) == 0 {
+		return []Post{}, nil
+	}
+
+	found, err := s.postTable.GetPostsByIDs(ctx, postIDs)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: failed to get posts", "error", err, "count", len(postIDs))
+		return nil, fmt.Errorf("failed to get posts by IDs: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]Post, len(found))
+	for _, post := range found {
+		byID[post.ID] = post
+	}
+	posts := make([]Post, 0, len(found))
+	for _, postID := range postIDs {
+		if post, ok := byID[postID]; ok {
+			posts = append(posts, post)
+			// Return each post once even if its ID is repeated
+			delete(byID, postID)
+		}
+	}
+	return posts, nil
+}
+
+// CreatePostsRequest is the request body for POST /posts/batch
+type CreatePostsRequest struct {
+	Posts []PostInput `json:"posts"`
+}
+
+// BatchItemResponse reports the status of one item in a batch response
+type BatchItemResponse struct {
+	Index     int    `json:"index"`
+	Status    int    `json:"status"`
+	Post      *Post  `json:"post,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Duplicate bool   `json:"duplicate,omitempty"`
+}
+
+// CreatePostsResponse is the response body for POST /posts/batch
+type CreatePostsResponse struct {
+	Results []BatchItemResponse `json:"results"`
+	// DuplicatesSkipped counts the items deduplication skipped (see DedupeQueryParam)
+	DuplicatesSkipped int `json:"duplicates_skipped"`
+}
+
+// newCreatePostsResponse converts batch results into a response body and status code:
+// 200 when every item succeeded, 207 Multi-Status when some failed. Invalid items get 400,
+// and items skipped as duplicates get 200 with duplicate set.
+func newCreatePostsResponse(results []BatchResult) (CreatePostsResponse, int) {
+	resp := CreatePostsResponse{Results: make([]BatchItemResponse, len(results))}
+	statusCode := http.StatusOK
+	for i, result := range results {
+		item := BatchItemResponse{Index: result.Index, Status: http.StatusCreated, Post: result.Post}
+		if result.Duplicate {
+			item.Status = http.StatusOK
+			item.Duplicate = true
+			resp.DuplicatesSkipped++
+		} else if errors.Is(result.Err, ErrValidation) {
+			item.Status = http.StatusBadRequest
+			item.Error = result.Err.Error()
+			statusCode = http.StatusMultiStatus
+		} else if result.Err != nil {
+			item.Status = http.StatusInternalServerError
+			item.Error = "Failed to create post"
+			statusCode = http.StatusMultiStatus
+		}
+		resp.Results[i] = item
+	}
+	return resp, statusCode
+}
+
@@ -0,0 +1,318 @@
+package posts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrencyTrackingTable records the peak number of concurrent PutPosts calls
+type concurrencyTrackingTable struct {
+	PostTable
+	inflight  atomic.Int32
+	peak      atomic.Int32
+	batches   atomic.Int32
+	failTitle string
+}
+
+func (t *concurrencyTrackingTable) PutPosts(ctx context.Context, posts []*Post) error {
+	t.batches.Add(1)
+	n := t.inflight.Add(1)
+	defer t.inflight.Add(-1)
+	for {
+		peak := t.peak.Load()
+		if n <= peak || t.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	for _, post := range posts {
+		if post.Title == t.failTitle {
+			return errors.New("write failed")
+		}
+	}
+	return nil
+}
+
+// memoryPostTable keeps posts in memory and, like the real tables, refuses to insert an ID twice
+type memoryPostTable struct {
+	PostTable
+	mu    sync.Mutex
+	posts map[uuid.UUID]Post
+}
+
+func newMemoryPostTable() *memoryPostTable {
+	return &memoryPostTable{posts: make(map[uuid.UUID]Post)}
+}
+
+func (t *memoryPostTable) PutPosts(ctx context.Context, posts []*Post) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, post := range posts {
+		t.posts[post.ID] = *post
+	}
+	return nil
+}
+
+func (t *memoryPostTable) PutPost(ctx context.Context, post *Post) error {
+	return t.PutPosts(ctx, []*Post{post})
+}
+
+func (t *memoryPostTable) PutPostIfNotExists(ctx context.Context, post *Post) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.posts[post.ID]; ok {
+		return ErrPostExists
+	}
+	t.posts[post.ID] = *post
+	return nil
+}
+
+func (t *memoryPostTable) GetPostByID(ctx context.Context, postID uuid.UUID) (*Post, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	post, ok := t.posts[postID]
+	if !ok {
+		return nil, ErrPostNotFound
+	}
+	return &post, nil
+}
+
+func (t *memoryPostTable) DeletePost(ctx context.Context, post *Post) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.posts[post.ID]; !ok {
+		return ErrPostNotFound
+	}
+	delete(t.posts, post.ID)
+	return nil
+}
+
+func (t *memoryPostTable) ListPostsByUserID(ctx context.Context, userID uuid.UUID) ([]Post, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	posts := []Post{}
+	for _, post := range t.posts {
+		if post.UserID == userID {
+			posts = append(posts, post)
+		}
+	}
+	return posts, nil
+}
+
+func batchInputs(n int) []PostInput {
+	inputs := make([]PostInput, n)
+	for i := range inputs {
+		inputs[i] = PostInput{Title: fmt.Sprintf("post %d", i), Content: "content"}
+	}
+	return inputs
+}
+
+func TestService_CreatePostsBoundedConcurrency(t *testing.T) {
+	t.Parallel()
+
+	// Each request is MaxBatchSize/batchWriteSize table batches; every case writes more
+	// batches in total than its limit so the limit is reached
+	tests := []struct {
+		name                string
+		maxConcurrentWrites int
+		requests            int
+		expectedLimit       int
+	}{
+		{name: "serial writes", maxConcurrentWrites: 1, requests: 1, expectedLimit: 1},
+		{name: "configured limit", maxConcurrentWrites: 2, requests: 1, expectedLimit: 2},
+		{name: "limit shared across requests", maxConcurrentWrites: 3, requests: 3, expectedLimit: 3},
+		{name: "default limit", maxConcurrentWrites: 0, requests: 4, expectedLimit: DefaultMaxConcurrentWrites},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			table := &concurrencyTrackingTable{}
+			service := NewService(table, WithMaxConcurrentWrites(tt.maxConcurrentWrites))
+
+			var wg sync.WaitGroup
+			for range tt.requests {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					results, err := service.CreatePosts(context.Background(), uuid.New(), batchInputs(MaxBatchSize), false)
+					assert.NoError(t, err)
+					assert.Len(t, results, MaxBatchSize)
+				}()
+			}
+			wg.Wait()
+
+			batches := tt.requests * MaxBatchSize / batchWriteSize
+			require.Greater(t, batches, tt.expectedLimit, "the test must write more batches than the limit")
+			assert.LessOrEqual(t, int(table.peak.Load()), tt.expectedLimit, "concurrent writes exceeded the limit")
+			assert.Positive(t, table.peak.Load())
+			assert.Equal(t, int32(batches), table.batches.Load())
+		})
+	}
+}
+
+func TestService_CreatePostsPartialResults(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	inputs := batchInputs(2 * batchWriteSize)
+	// Fail the second table batch; the first is written independently
+	table := &concurrencyTrackingTable{failTitle: inputs[batchWriteSize+1].Title}
+	service := NewService(table, WithMaxConcurrentWrites(2))
+
+	results, err := service.CreatePosts(context.Background(), userID, inputs, false)
+	require.NoError(t, err)
+	require.Len(t, results, len(inputs))
+
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		if i >= batchWriteSize {
+			assert.Error(t, result.Err)
+			assert.Nil(t, result.Post)
+			continue
+		}
+		require.NoError(t, result.Err)
+		assert.Equal(t, inputs[i].Title, result.Post.Title)
+		assert.Equal(t, userID, result.Post.UserID)
+		if i > 0 {
+			assert.True(t, result.Post.CreatedAt.After(results[i-1].Post.CreatedAt), "posts in a batch should have increasing creation times")
+		}
+	}
+}
+
+func TestService_CreatePostsDedupe(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	inputs := batchInputs(3)
+	// The first post is repeated within the batch
+	inputs = append(inputs, inputs[0])
+	table := newMemoryPostTable()
+	service := NewService(table)
+
+	results, err := service.CreatePosts(context.Background(), userID, inputs, true)
+	require.NoError(t, err)
+	for i, result := range results[:3] {
+		require.NoError(t, result.Err)
+		assert.False(t, result.Duplicate)
+		assert.Equal(t, inputs[i].Title, result.Post.Title)
+	}
+	assert.True(t, results[3].Duplicate)
+	assert.Nil(t, results[3].Post)
+
+	// Re-running the batch creates nothing new
+	results, err = service.CreatePosts(context.Background(), userID, inputs, true)
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.True(t, result.Duplicate)
+	}
+
+	// Another user's identical post is not a duplicate
+	results, err = service.CreatePosts(context.Background(), uuid.New(), inputs[:1], true)
+	require.NoError(t, err)
+	assert.False(t, results[0].Duplicate)
+
+	// Without deduplication the same content is created again
+	results, err = service.CreatePosts(context.Background(), userID, inputs[:1], false)
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	assert.False(t, results[0].Duplicate)
+
+	posts, err := table.ListPostsByUserID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Len(t, posts, 4, "three deduplicated posts and one created without deduplication")
+}
+
+func TestService_CreatePostsTooLarge(t *testing.T) {
+	t.Parallel()
+
+	service := NewService(&concurrencyTrackingTable{})
+	_, err := service.CreatePosts(context.Background(), uuid.New(), batchInputs(MaxBatchSize+1), false)
+	assert.ErrorIs(t, err, ErrBatchTooLarge)
+}
+
+func TestService_CreatePostsCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	table := &concurrencyTrackingTable{}
+	service := NewService(table)
+
+	results, err := service.CreatePosts(ctx, uuid.New(), batchInputs(MaxBatchSize), false)
+	require.NoError(t, err)
+	require.Len(t, results, MaxBatchSize)
+	for _, result := range results {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+		assert.Nil(t, result.Post)
+	}
+	assert.Zero(t, table.batches.Load(), "no batch should be written with a canceled context")
+}
+
+func TestService_CreatePostsInvalidItems(t *testing.T) {
+	t.Parallel()
+
+	inputs := batchInputs(3)
+	inputs[1].Title = ""
+	table := &concurrencyTrackingTable{}
+	service := NewService(table)
+
+	results, err := service.CreatePosts(context.Background(), uuid.New(), inputs, false)
+	require.NoError(t, err)
+	require.Len(t, results, len(inputs))
+
+	assert.ErrorIs(t, results[1].Err, ErrValidation)
+	assert.Nil(t, results[1].Post)
+	for _, i := range []int{0, 2} {
+		require.NoError(t, results[i].Err)
+		assert.Equal(t, inputs[i].Title, results[i].Post.Title)
+	}
+}
+
+func TestNewCreatePostsResponse(t *testing.T) {
+	t.Parallel()
+
+	post := NewPost(uuid.New(), "title", "content")
+
+	resp, statusCode := newCreatePostsResponse([]BatchResult{{Index: 0, Post: post}})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, []BatchItemResponse{{Index: 0, Status: http.StatusCreated, Post: post}}, resp.Results)
+
+	resp, statusCode = newCreatePostsResponse([]BatchResult{{Index: 0, Post: post}, {Index: 1, Duplicate: true}})
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, []BatchItemResponse{
+		{Index: 0, Status: http.StatusCreated, Post: post},
+		{Index: 1, Status: http.StatusOK, Duplicate: true},
+	}, resp.Results)
+	assert.Equal(t, 1, resp.DuplicatesSkipped)
+
+	resp, statusCode = newCreatePostsResponse([]BatchResult{
+		{Index: 0, Post: post},
+		{Index: 1, Err: errors.New("write failed")},
+	})
+	assert.Equal(t, http.StatusMultiStatus, statusCode)
+	assert.Equal(t, []BatchItemResponse{
+		{Index: 0, Status: http.StatusCreated, Post: post},
+		{Index: 1, Status: http.StatusInternalServerError, Error: "Failed to create post"},
+	}, resp.Results)
+
+	invalid := &ValidationError{Fields: []FieldError{{Field: "/title", Message: "must not be empty"}}}
+	resp, statusCode = newCreatePostsResponse([]BatchResult{{Index: 0, Err: invalid}})
+	assert.Equal(t, http.StatusMultiStatus, statusCode)
+	assert.Equal(t, []BatchItemResponse{
+		{Index: 0, Status: http.StatusBadRequest, Error: "validation failed: /title: must not be empty"},
+	}, resp.Results)
+}
+
@@ -0,0 +1,56 @@
+package posts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// postETag returns a strong ETag for post, a hash of its content, so it changes whenever
+// any field of the response does
+func postETag(post *Post) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s\x00%s\x00%d\x00%d",
+		post.ID, post.UserID, post.Title, post.Content, post.CreatedAt.UnixNano(), post.UpdatedAt.UnixNano())
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// setCacheHeaders sets the ETag and Last-Modified validators for post on header and reports
+// whether the request's If-None-Match or If-Modified-Since shows the client already has this
+// version, in which case the handler responds 304 Not Modified without a body
+func setCacheHeaders(header http.Header, r *http.Request, post *Post) (notModified bool) {
+	etag := postETag(post)
+	header.Set("ETag", etag)
+	// Clients may keep the post but must revalidate it, which the validators make cheap
+	header.Set("Cache-Control", "no-cache")
+	if !post.UpdatedAt.IsZero() {
+		header.Set("Last-Modified", post.UpdatedAt.UTC().Format(http.TimeFormat))
+	}
+
+	// If-None-Match takes precedence, and If-Modified-Since is ignored when it is present
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, etag)
+	}
+	if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && !post.UpdatedAt.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		// HTTP dates have second precision, so compare the truncated modification time
+		return err == nil && !post.UpdatedAt.Truncate(time.Second).After(since)
+	}
+	return false
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag, using the weak
+// comparison GET requests call for
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
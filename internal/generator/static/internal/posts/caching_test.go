@@ -0,0 +1,86 @@
+package posts
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCacheHeaders(t *testing.T) {
+	t.Parallel()
+
+	post := &Post{
+		ID:        uuid.MustParse("00000000-0000-4000-8000-000000000101"),
+		UserID:    uuid.MustParse("00000000-0000-4000-8000-000000000001"),
+		Title:     "title",
+		Content:   "content",
+		CreatedAt: time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, time.January, 2, 12, 0, 0, 500, time.UTC),
+	}
+	etag := postETag(post)
+
+	tests := []struct {
+		name        string
+		post        *Post
+		header      map[string]string
+		notModified bool
+	}{
+		{name: "unconditional", post: post},
+		{name: "matching etag", post: post, header: map[string]string{"If-None-Match": etag}, notModified: true},
+		{name: "weak matching etag", post: post, header: map[string]string{"If-None-Match": "W/" + etag}, notModified: true},
+		{name: "etag in list", post: post, header: map[string]string{"If-None-Match": `"other", ` + etag}, notModified: true},
+		{name: "any etag", post: post, header: map[string]string{"If-None-Match": "*"}, notModified: true},
+		{name: "different etag", post: post, header: map[string]string{"If-None-Match": `"other"`}},
+		{name: "unmodified since", post: post, header: map[string]string{"If-Modified-Since": "Thu, 02 Jan 2025 12:00:00 GMT"}, notModified: true},
+		{name: "modified since", post: post, header: map[string]string{"If-Modified-Since": "Thu, 02 Jan 2025 11:59:59 GMT"}},
+		{name: "invalid date", post: post, header: map[string]string{"If-Modified-Since": "yesterday"}},
+		{
+			name:   "etag takes precedence over date",
+			post:   post,
+			header: map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": "Thu, 02 Jan 2025 12:00:00 GMT"},
+		},
+		{
+			name:   "no modification time",
+			post:   &Post{ID: post.ID, UserID: post.UserID, Title: post.Title},
+			header: map[string]string{"If-Modified-Since": "Thu, 02 Jan 2025 12:00:00 GMT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/"+tt.post.ID.String(), nil)
+			for name, value := range tt.header {
+				req.Header.Set(name, value)
+			}
+			header := http.Header{}
+
+			assert.Equal(t, tt.notModified, setCacheHeaders(header, req, tt.post))
+			assert.Equal(t, postETag(tt.post), header.Get("ETag"))
+			if tt.post.UpdatedAt.IsZero() {
+				assert.Empty(t, header.Get("Last-Modified"))
+			} else {
+				assert.Equal(t, "Thu, 02 Jan 2025 12:00:00 GMT", header.Get("Last-Modified"))
+			}
+		})
+	}
+}
+
+func TestPostETag(t *testing.T) {
+	t.Parallel()
+
+	post := &Post{ID: uuid.New(), UserID: uuid.New(), Title: "title", Content: "content"}
+	same := *post
+	edited := *post
+	edited.Content = "edited"
+
+	assert.Equal(t, postETag(post), postETag(&same))
+	assert.NotEqual(t, postETag(post), postETag(&edited))
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, postETag(post))
+}
+
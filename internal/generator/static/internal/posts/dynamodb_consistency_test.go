@@ -0,0 +1,97 @@
+package posts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLaggingGSIServer fakes a DynamoDB endpoint whose GSI_PostID index only returns post after
+// missedQueries queries, as happens right after a write while the index catches up
+func newLaggingGSIServer(t *testing.T, post *Post, missedQueries int64) (*dynamodb.Client, *atomic.Int64) {
+	t.Helper()
+
+	item := fmt.Sprintf(`{"UserID":{"S":%q},"CreatedAt":{"N":"%d"},"PostID":{"S":%q},"Title":{"S":%q},"Content":{"S":%q},"UpdatedAt":{"N":"%d"}}`,
+		post.UserID, post.CreatedAt.UnixMilli(), post.ID, post.Title, post.Content, post.UpdatedAt.UnixMilli())
+	var queries atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		switch r.Header.Get("X-Amz-Target") {
+		case "DynamoDB_20120810.DescribeTable":
+			fmt.Fprint(w, `{"Table":{"TableName":"posts","TableStatus":"ACTIVE"}}`)
+		case "DynamoDB_20120810.Query":
+			if queries.Add(1) <= missedQueries {
+				fmt.Fprint(w, `{"Items":[],"Count":0}`)
+				return
+			}
+			fmt.Fprintf(w, `{"Items":[%s],"Count":1}`, item)
+		default:
+			http.Error(w, "unexpected operation", http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := dynamodb.NewFromConfig(aws.Config{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("local", "local", ""),
+	})
+	return client, &queries
+}
+
+func TestDynamoDBPostTable_ReadAfterWrite(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	post := &Post{ID: uuid.New(), UserID: uuid.New(), Title: "title", Content: "content", CreatedAt: now, UpdatedAt: now}
+
+	tests := []struct {
+		name            string
+		wait            time.Duration
+		missedQueries   int64
+		expectedQueries int64
+		expectedErr     error
+	}{
+		{name: "indexed post", missedQueries: 0, expectedQueries: 1},
+		{name: "lagging index without wait", missedQueries: 2, expectedQueries: 1, expectedErr: ErrPostNotFound},
+		{name: "lagging index with wait", wait: 5 * time.Second, missedQueries: 2, expectedQueries: 3},
+		{name: "missing post waits then gives up", wait: 100 * time.Millisecond, missedQueries: 1000, expectedErr: ErrPostNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, queries := newLaggingGSIServer(t, post, tt.missedQueries)
+			table, err := NewDynamoDBPostTable(context.Background(), client, "posts", WithReadAfterWriteWait(tt.wait))
+			require.NoError(t, err)
+
+			got, err := table.GetPostByID(context.Background(), post.ID)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, post.ID, got.ID)
+				assert.Equal(t, post.Title, got.Title)
+				assert.True(t, post.CreatedAt.Equal(got.CreatedAt))
+			}
+			if tt.expectedQueries > 0 {
+				assert.Equal(t, tt.expectedQueries, queries.Load())
+			} else {
+				assert.Greater(t, queries.Load(), int64(1), "the lookup should be retried until the wait runs out")
+			}
+		})
+	}
+}
+
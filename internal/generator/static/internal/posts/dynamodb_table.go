@@ -2,7 +2,11 @@ package posts
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -11,20 +15,50 @@ import (
 	"github.com/google/uuid"
 )
 
-
-const PostTableName string = "PostTable"
 const PostIDGSI string = "GSI_PostID"
 
+// maxBatchWriteItems is DynamoDB's per-request BatchWriteItem limit
+const maxBatchWriteItems = 25
+
+// maxUnprocessedRetries bounds how often items DynamoDB leaves unprocessed are resubmitted
+const maxUnprocessedRetries = 5
+
+// maxConcurrentLookups bounds concurrent GSI queries in GetPostsByIDs
+const maxConcurrentLookups = 10
+
+// readAfterWriteBackoff is the first delay before GetPostByID queries the GSI again for a post
+// it didn't find (see WithReadAfterWriteWait); later delays double
+const readAfterWriteBackoff = 25 * time.Millisecond
+
 // DynamoDBPostTable is a repository for DynamoDB operations on posts
 type DynamoDBPostTable struct {
-	dynamoClient *dynamodb.Client
+	dynamoClient       *dynamodb.Client
+	tableName          string
+	readAfterWriteWait time.Duration
 }
 
-// CreatePostTableIfNotExists creates the DynamoDB table with all GSIs and LSIs if it doesn't exist
-func CreatePostTableIfNotExists(ctx context.Context, dynamoClient *dynamodb.Client) error {
+// DynamoDBTableOption configures a DynamoDBPostTable
+type DynamoDBTableOption func(*DynamoDBPostTable)
+
+// WithReadAfterWriteWait makes GetPostByID keep looking, with backoff, for up to wait before
+// returning ErrPostNotFound. Posts are found by ID through GSI_PostID, and GSIs only support
+// eventually consistent reads, so a post can be missing for a moment after it is written.
+// Waiting lets a read right after a create succeed, at the cost of delaying every not-found
+// response by wait. 0 (the default) reports a missing post immediately.
+func WithReadAfterWriteWait(wait time.Duration) DynamoDBTableOption {
+	return func(t *DynamoDBPostTable) {
+		if wait > 0 {
+			t.readAfterWriteWait = wait
+		}
+	}
+}
+
+// CreatePostTableIfNotExists creates the DynamoDB table named tableName with all GSIs and LSIs
+// if it doesn't exist
+func CreatePostTableIfNotExists(ctx context.Context, dynamoClient *dynamodb.Client, tableName string) error {
 	// Check if table already exists
 	_, err := dynamoClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{
-		TableName: aws.String(PostTableName),
+		TableName: aws.String(tableName),
 	})
 	if err == nil {
 		// Table exists, nothing to do
@@ -33,7 +67,7 @@ func CreatePostTableIfNotExists(ctx context.Context, dynamoClient *dynamodb.Clie
 
 	// Table doesn't exist, create it
 	_, err = dynamoClient.CreateTable(ctx, &dynamodb.CreateTableInput{
-		TableName: aws.String(PostTableName),
+		TableName: aws.String(tableName),
 		AttributeDefinitions: []types.AttributeDefinition{
 			{
 				AttributeName: aws.String("UserID"),
@@ -75,53 +109,157 @@ func CreatePostTableIfNotExists(ctx context.Context, dynamoClient *dynamodb.Clie
 		BillingMode: types.BillingModePayPerRequest,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create DynamoDB table %s: %w", PostTableName, err)
+		return fmt.Errorf("failed to create DynamoDB table %s: %w", tableName, err)
 	}
 	return nil
 }
 
-// NewDynamoDBPostTable creates a new posts table repository
-// It ensures the table exists (creates it if needed) and tests the connection
-func NewDynamoDBPostTable(ctx context.Context, dynamoClient *dynamodb.Client) (*DynamoDBPostTable, error) {
-	// Ensure table exists (create if it doesn't)
-	if err := CreatePostTableIfNotExists(ctx, dynamoClient); err != nil {
-		return nil, fmt.Errorf("failed to ensure DynamoDB table %s exists: %w", PostTableName, err)
-	}
-
+// NewDynamoDBPostTable creates a new posts table repository for the table named tableName
+// (TABLE_NAME), so deployments sharing an AWS account can each use their own table.
+// The table must already exist (see CreatePostTableIfNotExists); it is only described to test the connection
+func NewDynamoDBPostTable(ctx context.Context, dynamoClient *dynamodb.Client, tableName string, opts ...DynamoDBTableOption) (*DynamoDBPostTable, error) {
 	// Test connection by describing the table - fail fast if connection fails
 	_, err := dynamoClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{
-		TableName: aws.String(PostTableName),
+		TableName: aws.String(tableName),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to DynamoDB table %s: %w", PostTableName, err)
+		return nil, fmt.Errorf("failed to connect to DynamoDB table %s: %w", tableName, err)
 	}
 
-	return &DynamoDBPostTable{
+	table := &DynamoDBPostTable{
 		dynamoClient: dynamoClient,
-	}, nil
+		tableName:    tableName,
+	}
+	for _, opt := range opts {
+		opt(table)
+	}
+	return table, nil
 }
 
 func (t *DynamoDBPostTable) PutPost(ctx context.Context, post *Post) error {
 	storage := DynamoDBPostToStorage(post)
 	valueMap, err := attributevalue.MarshalMap(storage)
 	if err != nil {
-		return fmt.Errorf("error during PUT to %s: %w", PostTableName, err)
+		return fmt.Errorf("error during PUT to %s: %w", t.tableName, err)
 	}
 	
 	_, err = t.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
 		Item:      valueMap,
-		TableName: aws.String(PostTableName),
+		TableName: aws.String(t.tableName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put post: %w", err)
+	}
+	return nil
+}
+
+// PutPostIfNotExists writes post together with an item claiming its ID, in one transaction that
+// fails if the ID was already claimed. Posts are keyed by (UserID, CreatedAt), so a conditional
+// put of the post alone couldn't stop two posts sharing an ID.
+func (t *DynamoDBPostTable) PutPostIfNotExists(ctx context.Context, post *Post) error {
+	storage := DynamoDBPostToStorage(post)
+	valueMap, err := attributevalue.MarshalMap(storage)
+	if err != nil {
+		return fmt.Errorf("error during PUT to %s: %w", t.tableName, err)
+	}
+
+	_, err = t.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Put: &types.Put{
+					TableName:           aws.String(t.tableName),
+					Item:                postIDClaimKey(post.ID),
+					ConditionExpression: aws.String("attribute_not_exists(UserID)"),
+				},
+			},
+			{
+				Put: &types.Put{
+					TableName: aws.String(t.tableName),
+					Item:      valueMap,
+				},
+			},
+		},
 	})
+	if conditionFailed(err) {
+		return ErrPostExists
+	}
 	if err != nil {
 		return fmt.Errorf("failed to put post: %w", err)
 	}
 	return nil
 }
 
+// postIDClaimKey is the key of the item PutPostIfNotExists writes to claim a post ID. It has no
+// PostID attribute, so it stays out of GSI_PostID and never appears in a user's posts.
+func postIDClaimKey(postID uuid.UUID) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"UserID":    &types.AttributeValueMemberS{Value: "PostID#" + postID.String()},
+		"CreatedAt": &types.AttributeValueMemberN{Value: "0"},
+	}
+}
+
+// conditionFailed reports whether err is a transaction canceled because its first item's
+// condition check failed
+func conditionFailed(err error) bool {
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) || len(canceled.CancellationReasons) == 0 {
+		return false
+	}
+	return aws.ToString(canceled.CancellationReasons[0].Code) == "ConditionalCheckFailed"
+}
+
+// PutPosts writes posts with BatchWriteItem in chunks of maxBatchWriteItems. Chunks are not
+// atomic: if a later chunk fails, earlier chunks stay written.
+func (t *DynamoDBPostTable) PutPosts(ctx context.Context, posts []*Post) error {
+	for start := 0; start < len(posts); start += maxBatchWriteItems {
+		end := min(start+maxBatchWriteItems, len(posts))
+		requests := make([]types.WriteRequest, 0, end-start)
+		for _, post := range posts[start:end] {
+			valueMap, err := attributevalue.MarshalMap(DynamoDBPostToStorage(post))
+			if err != nil {
+				return fmt.Errorf("error during batch PUT to %s: %w", t.tableName, err)
+			}
+			requests = append(requests, types.WriteRequest{PutRequest: &types.PutRequest{Item: valueMap}})
+		}
+		if err := t.batchWrite(ctx, requests); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// batchWrite submits one BatchWriteItem request, resubmitting unprocessed items with exponential backoff
+func (t *DynamoDBPostTable) batchWrite(ctx context.Context, requests []types.WriteRequest) error {
+	backoff := 50 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		result, err := t.dynamoClient.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{t.tableName: requests},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to put posts: %w", err)
+		}
+
+		requests = result.UnprocessedItems[t.tableName]
+		if len(requests) == 0 {
+			return nil
+		}
+		if attempt == maxUnprocessedRetries {
+			return fmt.Errorf("failed to put posts: %d items left unprocessed", len(requests))
+		}
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // ListPostsByUserID returns all posts authored by the user with id userID
 func (t *DynamoDBPostTable) ListPostsByUserID(ctx context.Context, userID uuid.UUID) ([]Post, error) {
 	params := &dynamodb.QueryInput{
-		TableName: aws.String(PostTableName),
+		TableName: aws.String(t.tableName),
 		KeyConditionExpression: aws.String("UserID = :userID"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":userID": &types.AttributeValueMemberS{Value: userID.String()},
@@ -151,16 +289,80 @@ func (t *DynamoDBPostTable) ListPostsByUserID(ctx context.Context, userID uuid.U
 	return posts, nil
 }
 
-// GetPostByID retrieves a post by its ID using the GSI_PostID index
+// SearchPostsByUserID returns the user's posts whose title contains query
+// DynamoDB has no full-text search: this queries the user's partition and filters it with
+// contains(Title, :query), so it only searches one user's posts, matching is case-sensitive,
+// and every post in the partition is read (and billed) even when few match. Use a search
+// service such as OpenSearch for cross-user or case-insensitive search.
+func (t *DynamoDBPostTable) SearchPostsByUserID(ctx context.Context, userID uuid.UUID, query string) ([]Post, error) {
+	paginator := dynamodb.NewQueryPaginator(t.dynamoClient, &dynamodb.QueryInput{
+		TableName:              aws.String(t.tableName),
+		KeyConditionExpression: aws.String("UserID = :userID"),
+		FilterExpression:       aws.String("contains(Title, :query)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":userID": &types.AttributeValueMemberS{Value: userID.String()},
+			":query":  &types.AttributeValueMemberS{Value: query},
+		},
+		ScanIndexForward: aws.Bool(false), // Sort by CreatedAt descending
+	})
+
+	// Filters apply per page, so keep reading until the partition is exhausted
+	posts := []Post{}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search posts: %w", err)
+		}
+
+		var storageModels []DynamoDBPostStorageModel
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &storageModels); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal posts: %w", err)
+		}
+		for _, storage := range storageModels {
+			post, err := DynamoDBStorageToPost(&storage)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert storage to post: %w", err)
+			}
+			posts = append(posts, *post)
+		}
+	}
+
+	return posts, nil
+}
+
+// GetPostByID retrieves a post by its ID using the GSI_PostID index. The index is eventually
+// consistent; see WithReadAfterWriteWait for reading a post right after writing it.
 func (t *DynamoDBPostTable) GetPostByID(ctx context.Context, postID uuid.UUID) (*Post, error) {
+	post, err := t.queryPostByID(ctx, postID)
+	if !errors.Is(err, ErrPostNotFound) || t.readAfterWriteWait == 0 {
+		return post, err
+	}
+
+	deadline := time.Now().Add(t.readAfterWriteWait)
+	for backoff := readAfterWriteBackoff; time.Now().Before(deadline); backoff *= 2 {
+		select {
+		case <-time.After(min(backoff, time.Until(deadline))):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		post, err = t.queryPostByID(ctx, postID)
+		if !errors.Is(err, ErrPostNotFound) {
+			return post, err
+		}
+	}
+	return nil, ErrPostNotFound
+}
+
+// queryPostByID looks a post up once through the GSI_PostID index
+func (t *DynamoDBPostTable) queryPostByID(ctx context.Context, postID uuid.UUID) (*Post, error) {
 	params := &dynamodb.QueryInput{
-		TableName:              aws.String(PostTableName),
+		TableName:              aws.String(t.tableName),
 		IndexName:              aws.String(PostIDGSI),
 		KeyConditionExpression: aws.String("PostID = :postID"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":postID": &types.AttributeValueMemberS{Value: postID.String()},
 		},
-		ConsistentRead: aws.Bool(false),
+		ConsistentRead: aws.Bool(false), // GSIs don't support consistent reads
 	}
 
 	result, err := t.dynamoClient.Query(ctx, params)
@@ -189,24 +391,74 @@ func (t *DynamoDBPostTable) GetPostByID(ctx context.Context, postID uuid.UUID) (
 	return post, nil
 }
 
-// DeletePost removes a post by post ID
-func (t *DynamoDBPostTable) DeletePost(ctx context.Context, postID uuid.UUID) error {
-	// First get the post to find its primary key
-	post, err := t.GetPostByID(ctx, postID)
-	if err != nil {
-		return fmt.Errorf("failed to find post with ID %s for deletion: %w", postID.String(), err)
+// GetPostsByIDs retrieves the posts whose IDs are in postIDs
+// BatchGetItem needs each post's primary key (UserID, CreatedAt), which a post ID doesn't
+// carry, so posts are looked up through the GSI_PostID index with bounded concurrency instead
+func (t *DynamoDBPostTable) GetPostsByIDs(ctx context.Context, postIDs []uuid.UUID) ([]Post, error) {
+	found := make([]*Post, len(postIDs))
+	errs := make([]error, len(postIDs))
+	sem := make(chan struct{}, maxConcurrentLookups)
+	var wg sync.WaitGroup
+	for i, postID := range postIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			// Missing posts are left out rather than waited for (see WithReadAfterWriteWait)
+			found[i], errs[i] = t.queryPostByID(ctx, postID)
+		}()
 	}
+	wg.Wait()
 
-	// Delete from table using primary key (UserID, CreatedAt)
-	params := &dynamodb.DeleteItemInput{
-		TableName: aws.String(PostTableName),
-		Key: map[string]types.AttributeValue{
-			"UserID":    &types.AttributeValueMemberS{Value: post.UserID.String()},
-			"CreatedAt": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", post.CreatedAt.UnixMilli())},
-		},
+	posts := []Post{}
+	for i, post := range found {
+		if errors.Is(errs[i], ErrPostNotFound) {
+			continue
+		}
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		posts = append(posts, *post)
 	}
+	return posts, nil
+}
 
-	_, err = t.dynamoClient.DeleteItem(ctx, params)
+// DeletePost removes post, using the primary key (UserID, CreatedAt) it was read with so no GSI
+// lookup is needed. The delete is conditional on the item still holding this post: the owner is
+// part of the key and cannot change, but a concurrent delete may have removed the post, or a new
+// post created in the same millisecond may have re-used its key since it was read. Either way the
+// item is left alone and ErrPostNotFound is returned, so when deletes race exactly one succeeds.
+// Any claim on the post ID (see PutPostIfNotExists) is deleted in the same transaction so the ID
+// can be used again.
+func (t *DynamoDBPostTable) DeletePost(ctx context.Context, post *Post) error {
+	storage := DynamoDBPostToStorage(post)
+	_, err := t.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Delete: &types.Delete{
+					TableName: aws.String(t.tableName),
+					Key: map[string]types.AttributeValue{
+						"UserID":    &types.AttributeValueMemberS{Value: storage.UserID},
+						"CreatedAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(storage.CreatedAt, 10)},
+					},
+					ConditionExpression: aws.String("attribute_exists(PostID) AND PostID = :postID"),
+					ExpressionAttributeValues: map[string]types.AttributeValue{
+						":postID": &types.AttributeValueMemberS{Value: storage.PostID},
+					},
+				},
+			},
+			{
+				Delete: &types.Delete{
+					TableName: aws.String(t.tableName),
+					Key:       postIDClaimKey(post.ID),
+				},
+			},
+		},
+	})
+	if conditionFailed(err) {
+		return ErrPostNotFound
+	}
 	if err != nil {
 		return fmt.Errorf("failed to delete post: %w", err)
 	}
@@ -1,7 +1,10 @@
+//go:build integration
+
 package posts
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,6 +18,8 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
+const testTableName = "posts-test"
+
 func TestDynamoDBPostTable_Serialization(t *testing.T) {
 	ctx := context.Background()
 
@@ -46,14 +51,18 @@ func TestDynamoDBPostTable_Serialization(t *testing.T) {
 	}
 	dynamoClient := dynamodb.NewFromConfig(cfg)
 
-	// Create table instance (table will be created automatically if it doesn't exist)
-	table, err := NewDynamoDBPostTable(ctx, dynamoClient)
+	// The repository expects the table to exist rather than creating it
+	_, err = NewDynamoDBPostTable(ctx, dynamoClient, testTableName)
+	require.Error(t, err)
+
+	require.NoError(t, CreatePostTableIfNotExists(ctx, dynamoClient, testTableName))
+	table, err := NewDynamoDBPostTable(ctx, dynamoClient, testTableName)
 	require.NoError(t, err)
 
 	// Wait for table to be active (in case it was just created)
 	waiter := dynamodb.NewTableExistsWaiter(dynamoClient)
 	err = waiter.Wait(ctx, &dynamodb.DescribeTableInput{
-		TableName: aws.String(PostTableName),
+		TableName: aws.String(testTableName),
 	}, 30*time.Second)
 	require.NoError(t, err)
 
@@ -155,7 +164,7 @@ func TestDynamoDBPostTable_Serialization(t *testing.T) {
 				require.NoError(t, err)
 
 				// Delete post
-				err = table.DeletePost(ctx, deletePostID)
+				err = table.DeletePost(ctx, post)
 				require.NoError(t, err)
 
 				// Verify it's gone
@@ -164,6 +173,181 @@ func TestDynamoDBPostTable_Serialization(t *testing.T) {
 				assert.Equal(t, ErrPostNotFound, err)
 			},
 		},
+		{
+			name: "SearchPostsByUserID",
+			fn: func(t *testing.T, table PostTable, userID uuid.UUID, now time.Time) {
+				// Use a fresh user so posts from other subtests don't match
+				searchUserID := uuid.New()
+				titles := []string{"Go Generics Explained", "Learning go", "Rust ownership", "100% coverage"}
+				for i, title := range titles {
+					require.NoError(t, table.PutPost(ctx, &Post{
+						ID:        uuid.New(),
+						UserID:    searchUserID,
+						Title:     title,
+						Content:   "content",
+						CreatedAt: now.Add(time.Duration(i) * time.Second),
+						UpdatedAt: now.Add(time.Duration(i) * time.Second),
+					}))
+				}
+				// Another user's matching post must not be returned
+				require.NoError(t, table.PutPost(ctx, &Post{ID: uuid.New(), UserID: uuid.New(), Title: "Go elsewhere", CreatedAt: now, UpdatedAt: now}))
+
+				titlesOf := func(posts []Post) []string {
+					result := make([]string, len(posts))
+					for i, post := range posts {
+						result[i] = post.Title
+					}
+					return result
+				}
+
+				// contains() is case-sensitive, newest first
+				posts, err := table.SearchPostsByUserID(ctx, searchUserID, "Go")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"Go Generics Explained"}, titlesOf(posts))
+
+				posts, err = table.SearchPostsByUserID(ctx, searchUserID, "o")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"100% coverage", "Rust ownership", "Learning go", "Go Generics Explained"}, titlesOf(posts))
+
+				posts, err = table.SearchPostsByUserID(ctx, searchUserID, "python")
+				require.NoError(t, err)
+				assert.Empty(t, posts)
+			},
+		},
+		{
+			name: "DeletePost of a missing post",
+			fn: func(t *testing.T, table PostTable, userID uuid.UUID, now time.Time) {
+				err := table.DeletePost(ctx, &Post{ID: uuid.New(), UserID: userID, CreatedAt: now, UpdatedAt: now})
+				assert.ErrorIs(t, err, ErrPostNotFound)
+			},
+		},
+		{
+			name: "concurrent DeletePost",
+			fn: func(t *testing.T, table PostTable, userID uuid.UUID, now time.Time) {
+				deletePostID := uuid.New()
+				post := &Post{
+					ID:        deletePostID,
+					UserID:    userID,
+					Title:     "Deleted Concurrently",
+					Content:   "Only one delete wins",
+					CreatedAt: now.Add(time.Second),
+					UpdatedAt: now.Add(time.Second),
+				}
+				require.NoError(t, table.PutPost(ctx, post))
+
+				const deletes = 5
+				var wg sync.WaitGroup
+				errs := make(chan error, deletes)
+				for range deletes {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						errs <- table.DeletePost(ctx, post)
+					}()
+				}
+				wg.Wait()
+				close(errs)
+
+				// Exactly one delete succeeds; the rest see the post as already gone
+				succeeded := 0
+				for err := range errs {
+					if err == nil {
+						succeeded++
+						continue
+					}
+					assert.ErrorIs(t, err, ErrPostNotFound)
+				}
+				assert.Equal(t, 1, succeeded)
+			},
+		},
+		{
+			name: "DeletePost after the key was re-used",
+			fn: func(t *testing.T, table PostTable, userID uuid.UUID, now time.Time) {
+				post := &Post{
+					ID:        uuid.New(),
+					UserID:    userID,
+					Title:     "Deleted Then Replaced",
+					Content:   "Read, deleted, then its key is taken by another post",
+					CreatedAt: now.Add(2 * time.Second),
+					UpdatedAt: now.Add(2 * time.Second),
+				}
+				require.NoError(t, table.PutPost(ctx, post))
+
+				read, err := table.GetPostByID(ctx, post.ID)
+				require.NoError(t, err)
+				require.NoError(t, table.DeletePost(ctx, post))
+
+				// A new post created in the same millisecond gets the same (UserID, CreatedAt) key
+				replacement := &Post{
+					ID:        uuid.New(),
+					UserID:    userID,
+					Title:     "Replacement",
+					CreatedAt: post.CreatedAt,
+					UpdatedAt: post.UpdatedAt,
+				}
+				require.NoError(t, table.PutPost(ctx, replacement))
+
+				// A stale delete of the first post must leave the replacement in place
+				err = table.DeletePost(ctx, read)
+				assert.ErrorIs(t, err, ErrPostNotFound)
+
+				current, err := table.GetPostByID(ctx, replacement.ID)
+				require.NoError(t, err)
+				assert.Equal(t, replacement.Title, current.Title)
+			},
+		},
+		{
+			name: "PutPostIfNotExists - concurrent inserts of one ID",
+			fn: func(t *testing.T, table PostTable, userID uuid.UUID, now time.Time) {
+				postID := uuid.New()
+
+				// Each insert is a separate attempt with its own timestamps, as retried requests are
+				const inserts = 5
+				var wg sync.WaitGroup
+				errs := make(chan error, inserts)
+				for i := range inserts {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						createdAt := now.Add(time.Duration(i+10) * time.Second)
+						errs <- table.PutPostIfNotExists(ctx, &Post{
+							ID:        postID,
+							UserID:    userID,
+							Title:     "Idempotent Post",
+							Content:   "Only one insert wins",
+							CreatedAt: createdAt,
+							UpdatedAt: createdAt,
+						})
+					}()
+				}
+				wg.Wait()
+				close(errs)
+
+				succeeded := 0
+				for err := range errs {
+					if err == nil {
+						succeeded++
+						continue
+					}
+					assert.ErrorIs(t, err, ErrPostExists)
+				}
+				assert.Equal(t, 1, succeeded)
+
+				// Deleting the post frees its ID again
+				inserted, err := table.GetPostByID(ctx, postID)
+				require.NoError(t, err)
+				require.NoError(t, table.DeletePost(ctx, inserted))
+				reinserted := &Post{
+					ID:        postID,
+					UserID:    userID,
+					Title:     "Idempotent Post",
+					CreatedAt: now.Add(time.Minute),
+					UpdatedAt: now.Add(time.Minute),
+				}
+				require.NoError(t, table.PutPostIfNotExists(ctx, reinserted))
+				require.NoError(t, table.DeletePost(ctx, reinserted))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1,5 +1,136 @@
 package posts
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var ErrPostNotFound error = errors.New("post not found")
+
+// ErrUnauthenticated is returned when a request carries no user identity
+var ErrUnauthenticated error = errors.New("unauthenticated")
+
+// ErrForbidden is returned when a user acts on a post owned by someone else
+var ErrForbidden error = errors.New("forbidden")
+
+// ErrInvalidUserID is returned when a supplied user ID is not a valid UUID
+var ErrInvalidUserID error = errors.New("invalid user id")
+
+// ErrBatchTooLarge is returned when a batch exceeds MaxBatchSize items
+var ErrBatchTooLarge error = errors.New("batch too large")
+
+// ErrEmptySearchQuery is returned when a search query is empty or only whitespace
+var ErrEmptySearchQuery error = errors.New("empty search query")
+
+// ErrPostExists is returned by PostTable.PutPostIfNotExists when a post with the ID already exists
+var ErrPostExists error = errors.New("post already exists")
+
+// ErrInvalidIdempotencyKey is returned when an idempotency key is longer than MaxIdempotencyKeyLength
+var ErrInvalidIdempotencyKey error = errors.New("invalid idempotency key")
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is sent again with a different post
+var ErrIdempotencyKeyReused error = errors.New("idempotency key reused with a different request")
+
+// ErrIdempotencyKeyInUse is returned when a post is still being created for an idempotency key
+var ErrIdempotencyKeyInUse error = errors.New("idempotency key in use by a concurrent request")
+
+// ErrValidation matches every *ValidationError, for callers that only need to know input was invalid
+var ErrValidation error = errors.New("validation failed")
+
+// FieldError describes why one field of a request body is invalid
+type FieldError struct {
+	Field   string `json:"field,omitempty"` // JSON pointer to the field, empty for the body itself
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when a request body or the post fields passed to the service
+// fail validation. It reports every invalid field at once rather than stopping at the first.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Message
+		if field.Field != "" {
+			messages[i] = field.Field + ": " + field.Message
+		}
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Is reports whether target is ErrValidation, so errors.Is(err, ErrValidation) matches any ValidationError
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ErrorCode is a stable, machine-readable identifier for a REST error response. Clients should
+// branch on the code rather than the message, which is meant for people and may change. The
+// comment on each code names the ConnectRPC code the RPC API returns for the same failure.
+type ErrorCode string
+
+const (
+	CodeInvalidRequest        ErrorCode = "INVALID_REQUEST"         // invalid_argument
+	CodeValidationFailed      ErrorCode = "VALIDATION_FAILED"       // invalid_argument
+	CodeInvalidPostID         ErrorCode = "INVALID_POST_ID"         // invalid_argument
+	CodeInvalidUserID         ErrorCode = "INVALID_USER_ID"         // invalid_argument
+	CodeBatchTooLarge         ErrorCode = "BATCH_TOO_LARGE"         // invalid_argument
+	CodeEmptySearchQuery      ErrorCode = "EMPTY_SEARCH_QUERY"      // invalid_argument
+	CodeIdempotencyKeyInvalid ErrorCode = "IDEMPOTENCY_KEY_INVALID" // invalid_argument
+	CodeIdempotencyKeyReused  ErrorCode = "IDEMPOTENCY_KEY_REUSED"  // failed_precondition
+	CodeIdempotencyKeyInUse   ErrorCode = "IDEMPOTENCY_KEY_IN_USE"  // aborted
+	CodeRequestTooLarge       ErrorCode = "REQUEST_TOO_LARGE"       // resource_exhausted
+	CodeUnauthenticated       ErrorCode = "UNAUTHENTICATED"         // unauthenticated
+	CodeForbidden             ErrorCode = "FORBIDDEN"               // permission_denied
+	CodePostNotFound          ErrorCode = "POST_NOT_FOUND"          // not_found
+	CodeRateLimited           ErrorCode = "RATE_LIMITED"            // resource_exhausted
+	CodeOverloaded            ErrorCode = "OVERLOADED"              // unavailable
+	CodeInternal              ErrorCode = "INTERNAL"                // internal
+)
+
+// ErrorResponse is the JSON body of an error response:
+// {"error": {"code": "POST_NOT_FOUND", "message": "Post not found"}}
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes what went wrong, with the invalid fields when a request failed validation
+type ErrorBody struct {
+	Code    ErrorCode    `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// NewErrorResponse returns the response body for an error with code and message
+func NewErrorResponse(code ErrorCode, message string) ErrorResponse {
+	return ErrorResponse{Error: ErrorBody{Code: code, Message: message}}
+}
+
+// newRequestErrorResponse converts a decodeRequest error into a response body (sent with
+// requestErrorStatus), including the field errors when the body failed validation
+func newRequestErrorResponse(err error) ErrorResponse {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return NewErrorResponse(CodeRequestTooLarge, "Request body too large")
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		resp := NewErrorResponse(CodeValidationFailed, "Invalid request body")
+		resp.Error.Details = validationErr.Fields
+		return resp
+	}
+	return NewErrorResponse(CodeInvalidRequest, "Invalid request body")
+}
+
+// requestErrorStatus returns the status for a decodeRequest error: 413 when the body
+// exceeded the server's size limit, 400 otherwise
+func requestErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
 
-var ErrPostNotFound error = errors.New("post not found")
\ No newline at end of file
@@ -0,0 +1,58 @@
+package posts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader is the request header that makes POST /posts safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// MaxIdempotencyKeyLength is the longest idempotency key accepted, in bytes
+const MaxIdempotencyKeyLength = 255
+
+// idempotencyNamespace seeds the name-based UUIDs derived from idempotency keys
+var idempotencyNamespace = uuid.MustParse("6f1d9a52-4b8e-4c37-9e0a-2d5c7f3b8a41")
+
+type idempotencyKeyContextKey struct{}
+
+// ContextWithIdempotencyKey returns a copy of ctx carrying the client's idempotency key.
+// Handlers call this with the Idempotency-Key header so CreatePost can recognize retries.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key stored in ctx, if any
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key, ok
+}
+
+// idempotentPostID derives the ID of the post created for a user's idempotency key. The key to
+// post mapping is the post ID itself, so tables only need to refuse inserting an ID twice and
+// keys are scoped to the user that sent them.
+func idempotentPostID(userID uuid.UUID, key string) uuid.UUID {
+	return uuid.NewSHA1(idempotencyNamespace, []byte(userID.String()+":"+key))
+}
+
+// idempotencyErrorStatus returns the status, code and message for the idempotency errors
+// CreatePost returns, and false for any other error
+func idempotencyErrorStatus(err error) (int, ErrorCode, string, bool) {
+	switch {
+	case errors.Is(err, ErrInvalidIdempotencyKey):
+		return http.StatusBadRequest, CodeIdempotencyKeyInvalid, fmt.Sprintf("Idempotency-Key must be at most %d bytes", MaxIdempotencyKeyLength), true
+	case errors.Is(err, ErrIdempotencyKeyReused):
+		return http.StatusUnprocessableEntity, CodeIdempotencyKeyReused, "Idempotency-Key was already used for a different post", true
+	case errors.Is(err, ErrIdempotencyKeyInUse):
+		return http.StatusConflict, CodeIdempotencyKeyInUse, "A request with this Idempotency-Key is still in progress", true
+	}
+	return 0, "", "", false
+}
+
@@ -0,0 +1,43 @@
+package posts
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type userIDContextKey struct{}
+
+// ContextWithUserID returns a copy of ctx carrying the authenticated user ID.
+// Auth middleware should call this so handlers use the verified identity
+// instead of the X-User-ID header.
+func ContextWithUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIDContextKey{}, userID)
+}
+
+// UserIDFromContext returns the authenticated user ID stored in ctx, if any
+func UserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(userIDContextKey{}).(uuid.UUID)
+	return userID, ok
+}
+
+// resolveUserID returns the caller's user ID, preferring the authenticated identity in ctx
+// over the X-User-ID header value (which is only consulted when no auth middleware set one).
+// Returns ErrUnauthenticated if neither is present and ErrInvalidUserID if the header is not a UUID.
+func resolveUserID(ctx context.Context, header string) (uuid.UUID, error) {
+	if userID, ok := UserIDFromContext(ctx); ok {
+		return userID, nil
+	}
+
+	if header == "" {
+		return uuid.Nil, ErrUnauthenticated
+	}
+
+	userID, err := uuid.Parse(header)
+	if err != nil {
+		return uuid.Nil, ErrInvalidUserID
+	}
+
+	return userID, nil
+}
+
@@ -0,0 +1,66 @@
+package posts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveUserID(t *testing.T) {
+	authedUserID := uuid.New()
+	headerUserID := uuid.New()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		header      string
+		expectedID  uuid.UUID
+		expectedErr error
+	}{
+		{
+			name:       "authenticated identity",
+			ctx:        ContextWithUserID(context.Background(), authedUserID),
+			expectedID: authedUserID,
+		},
+		{
+			name:       "authenticated identity takes precedence over header",
+			ctx:        ContextWithUserID(context.Background(), authedUserID),
+			header:     headerUserID.String(),
+			expectedID: authedUserID,
+		},
+		{
+			name:       "authenticated identity ignores invalid header",
+			ctx:        ContextWithUserID(context.Background(), authedUserID),
+			header:     "not-a-uuid",
+			expectedID: authedUserID,
+		},
+		{
+			name:       "header identity without auth",
+			ctx:        context.Background(),
+			header:     headerUserID.String(),
+			expectedID: headerUserID,
+		},
+		{
+			name:        "no identity is unauthenticated",
+			ctx:         context.Background(),
+			expectedErr: ErrUnauthenticated,
+		},
+		{
+			name:        "invalid header user ID",
+			ctx:         context.Background(),
+			header:      "not-a-uuid",
+			expectedErr: ErrInvalidUserID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, err := resolveUserID(tt.ctx, tt.header)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedID, userID)
+		})
+	}
+}
+
@@ -0,0 +1,65 @@
+package posts
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// observedPostTable times every call to the wrapped PostTable
+type observedPostTable struct {
+	table   PostTable
+	observe func(operation string, duration time.Duration)
+}
+
+// NewObservedPostTable wraps table so observe is called with the method name and duration of
+// every operation, e.g. to export database latency as a metric
+func NewObservedPostTable(table PostTable, observe func(operation string, duration time.Duration)) PostTable {
+	return &observedPostTable{table: table, observe: observe}
+}
+
+// track reports the time since start for operation; call it deferred at the top of a method
+func (t *observedPostTable) track(operation string, start time.Time) {
+	t.observe(operation, time.Since(start))
+}
+
+func (t *observedPostTable) PutPost(ctx context.Context, post *Post) error {
+	defer t.track("PutPost", time.Now())
+	return t.table.PutPost(ctx, post)
+}
+
+func (t *observedPostTable) PutPostIfNotExists(ctx context.Context, post *Post) error {
+	defer t.track("PutPostIfNotExists", time.Now())
+	return t.table.PutPostIfNotExists(ctx, post)
+}
+
+func (t *observedPostTable) GetPostByID(ctx context.Context, postID uuid.UUID) (*Post, error) {
+	defer t.track("GetPostByID", time.Now())
+	return t.table.GetPostByID(ctx, postID)
+}
+
+func (t *observedPostTable) ListPostsByUserID(ctx context.Context, userID uuid.UUID) ([]Post, error) {
+	defer t.track("ListPostsByUserID", time.Now())
+	return t.table.ListPostsByUserID(ctx, userID)
+}
+
+func (t *observedPostTable) DeletePost(ctx context.Context, post *Post) error {
+	defer t.track("DeletePost", time.Now())
+	return t.table.DeletePost(ctx, post)
+}
+
+func (t *observedPostTable) PutPosts(ctx context.Context, posts []*Post) error {
+	defer t.track("PutPosts", time.Now())
+	return t.table.PutPosts(ctx, posts)
+}
+
+func (t *observedPostTable) GetPostsByIDs(ctx context.Context, postIDs []uuid.UUID) ([]Post, error) {
+	defer t.track("GetPostsByIDs", time.Now())
+	return t.table.GetPostsByIDs(ctx, postIDs)
+}
+
+func (t *observedPostTable) SearchPostsByUserID(ctx context.Context, userID uuid.UUID, query string) ([]Post, error) {
+	defer t.track("SearchPostsByUserID", time.Now())
+	return t.table.SearchPostsByUserID(ctx, userID, query)
+}
@@ -0,0 +1,36 @@
+package posts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservedPostTable(t *testing.T) {
+	t.Parallel()
+
+	post := NewPost(uuid.New(), "title", "content")
+	mockTable := NewMockPostTable(t)
+	mockTable.On("GetPostByID", mock.Anything, post.ID).Return(post, nil)
+	mockTable.On("DeletePost", mock.Anything, post).Return(ErrPostNotFound)
+
+	var operations []string
+	table := NewObservedPostTable(mockTable, func(operation string, duration time.Duration) {
+		operations = append(operations, operation)
+		assert.GreaterOrEqual(t, duration, time.Duration(0))
+	})
+
+	found, err := table.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, post, found)
+
+	// Failed operations are timed too, and their errors passed through unchanged
+	assert.ErrorIs(t, table.DeletePost(context.Background(), post), ErrPostNotFound)
+
+	assert.Equal(t, []string{"GetPostByID", "DeletePost"}, operations)
+}
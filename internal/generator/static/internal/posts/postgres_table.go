@@ -3,11 +3,48 @@ package posts
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// postColumns lists the posts columns in the order queries select and insert them
+const postColumns = `id, user_id, title, content, created_at, updated_at`
+
+// The posts table queries, defined once so every call sends the same SQL text and the pool's
+// statement cache (see database.NewPostgres) prepares each on a connection only once
+const (
+	insertPostSQL = `INSERT INTO posts (` + postColumns + `) VALUES `
+	// upsertPostSQL updates the mutable columns of a post that already exists
+	upsertPostSQL = `
+		ON CONFLICT (id) DO UPDATE SET
+			title = EXCLUDED.title,
+			content = EXCLUDED.content,
+			updated_at = EXCLUDED.updated_at`
+	putPostSQL            = insertPostSQL + `($1, $2, $3, $4, $5, $6)` + upsertPostSQL
+	putPostIfNotExistsSQL = insertPostSQL + `($1, $2, $3, $4, $5, $6) ON CONFLICT (id) DO NOTHING`
+	listPostsByUserIDSQL  = `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+	searchPostsByUserIDSQL = `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE user_id = $1 AND title ILIKE '%' || $2 || '%' ESCAPE '\'
+		ORDER BY created_at DESC`
+	getPostByIDSQL = `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE id = $1`
+	getPostsByIDsSQL = `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE id = ANY($1)`
+	deletePostSQL = `DELETE FROM posts WHERE id = $1 AND user_id = $2`
+)
+
 // PostgresPostTable is a repository for PostgreSQL operations on posts
 type PostgresPostTable struct {
 	db *pgxpool.Pool
@@ -26,15 +63,7 @@ func NewPostgresPostTable(ctx context.Context, db *pgxpool.Pool) (*PostgresPostT
 }
 
 func (t *PostgresPostTable) PutPost(ctx context.Context, post *Post) error {
-	query := `
-		INSERT INTO posts (id, user_id, title, content, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (id) DO UPDATE SET
-			title = EXCLUDED.title,
-			content = EXCLUDED.content,
-			updated_at = EXCLUDED.updated_at`
-
-	_, err := t.db.Exec(ctx, query,
+	_, err := t.db.Exec(ctx, putPostSQL,
 		post.ID, post.UserID, post.Title, post.Content, post.CreatedAt, post.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to save post: %w", err)
@@ -42,15 +71,45 @@ func (t *PostgresPostTable) PutPost(ctx context.Context, post *Post) error {
 	return nil
 }
 
+// PutPostIfNotExists inserts post, leaving an existing post with the same ID untouched
+func (t *PostgresPostTable) PutPostIfNotExists(ctx context.Context, post *Post) error {
+	result, err := t.db.Exec(ctx, putPostIfNotExistsSQL,
+		post.ID, post.UserID, post.Title, post.Content, post.CreatedAt, post.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert post: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrPostExists
+	}
+	return nil
+}
+
+// PutPosts upserts posts with a single multi-row INSERT, so either every post is saved or none is
+func (t *PostgresPostTable) PutPosts(ctx context.Context, posts []*Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	const columns = 6
+	values := make([]string, len(posts))
+	args := make([]any, 0, len(posts)*columns)
+	for i, post := range posts {
+		n := i * columns
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6)
+		args = append(args, post.ID, post.UserID, post.Title, post.Content, post.CreatedAt, post.UpdatedAt)
+	}
+	// The statement text depends only on the number of posts, so each batch size is cached once
+	query := insertPostSQL + strings.Join(values, ", ") + upsertPostSQL
+
+	if _, err := t.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save posts: %w", err)
+	}
+	return nil
+}
+
 // ListPostsByUserID returns all posts authored by the user with id userID
 func (t *PostgresPostTable) ListPostsByUserID(ctx context.Context, userID uuid.UUID) ([]Post, error) {
-	query := `
-		SELECT id, user_id, title, content, created_at, updated_at
-		FROM posts
-		WHERE user_id = $1
-		ORDER BY created_at DESC`
-
-	rows, err := t.db.Query(ctx, query, userID)
+	rows, err := t.db.Query(ctx, listPostsByUserIDSQL, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query posts: %w", err)
 	}
@@ -73,15 +132,38 @@ func (t *PostgresPostTable) ListPostsByUserID(ctx context.Context, userID uuid.U
 	return posts, nil
 }
 
+// likeEscaper escapes LIKE wildcards so search queries match literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchPostsByUserID returns the user's posts whose title contains query (case-insensitive)
+func (t *PostgresPostTable) SearchPostsByUserID(ctx context.Context, userID uuid.UUID, query string) ([]Post, error) {
+	rows, err := t.db.Query(ctx, searchPostsByUserIDSQL, userID, likeEscaper.Replace(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search posts: %w", err)
+	}
+	defer rows.Close()
+
+	posts := []Post{}
+	for rows.Next() {
+		var post Post
+		err := rows.Scan(&post.ID, &post.UserID, &post.Title, &post.Content, &post.CreatedAt, &post.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan post: %w", err)
+		}
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating posts: %w", err)
+	}
+
+	return posts, nil
+}
+
 // GetPostByID retrieves a post by its ID
 func (t *PostgresPostTable) GetPostByID(ctx context.Context, postID uuid.UUID) (*Post, error) {
-	query := `
-		SELECT id, user_id, title, content, created_at, updated_at
-		FROM posts
-		WHERE id = $1`
-
 	var post Post
-	err := t.db.QueryRow(ctx, query, postID).Scan(
+	err := t.db.QueryRow(ctx, getPostByIDSQL, postID).Scan(
 		&post.ID, &post.UserID, &post.Title, &post.Content, &post.CreatedAt, &post.UpdatedAt)
 	if err != nil {
 		if err.Error() == "no rows in result set" {
@@ -93,11 +175,34 @@ func (t *PostgresPostTable) GetPostByID(ctx context.Context, postID uuid.UUID) (
 	return &post, nil
 }
 
-// DeletePost removes a post by its ID
-func (t *PostgresPostTable) DeletePost(ctx context.Context, postID uuid.UUID) error {
-	query := `DELETE FROM posts WHERE id = $1`
+// GetPostsByIDs retrieves the posts whose IDs are in postIDs
+func (t *PostgresPostTable) GetPostsByIDs(ctx context.Context, postIDs []uuid.UUID) ([]Post, error) {
+	rows, err := t.db.Query(ctx, getPostsByIDsSQL, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query posts by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	posts := []Post{}
+	for rows.Next() {
+		var post Post
+		err := rows.Scan(&post.ID, &post.UserID, &post.Title, &post.Content, &post.CreatedAt, &post.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan post: %w", err)
+		}
+		posts = append(posts, post)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating posts: %w", err)
+	}
+
+	return posts, nil
+}
 
-	result, err := t.db.Exec(ctx, query, postID)
+// DeletePost removes post, provided it still belongs to the user it was read with
+func (t *PostgresPostTable) DeletePost(ctx context.Context, post *Post) error {
+	result, err := t.db.Exec(ctx, deletePostSQL, post.ID, post.UserID)
 	if err != nil {
 		return fmt.Errorf("failed to delete post: %w", err)
 	}
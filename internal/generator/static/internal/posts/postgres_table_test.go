@@ -1,7 +1,12 @@
+//go:build integration
+
 package posts
 
 import (
 	"context"
+	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -41,19 +46,41 @@ func TestPostgresPostTable_Serialization(t *testing.T) {
 	require.NoError(t, err)
 	defer pool.Close()
 
-	// Create table
-	_, err = pool.Exec(ctx, `
-		CREATE TABLE IF NOT EXISTS posts (
-			id UUID PRIMARY KEY,
-			user_id UUID NOT NULL,
-			title TEXT NOT NULL,
-			content TEXT NOT NULL,
-			created_at TIMESTAMP NOT NULL,
-			updated_at TIMESTAMP NOT NULL
-		)
-	`)
+	// Create table and indexes from the project schema
+	schema, err := os.ReadFile("../../schema.sql")
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, string(schema))
 	require.NoError(t, err)
 
+	// ListPostsByUserID relies on this index to avoid a sort over the user's posts
+	var indexDef string
+	err = pool.QueryRow(ctx,
+		`SELECT indexdef FROM pg_indexes WHERE tablename = 'posts' AND indexname = 'idx_posts_user_id_created_at'`,
+	).Scan(&indexDef)
+	require.NoError(t, err, "schema.sql should create idx_posts_user_id_created_at")
+	assert.Contains(t, indexDef, "(user_id, created_at DESC)")
+
+	// The planner scans the index in order rather than sorting. Sequential scans are disabled
+	// because the empty test table would otherwise be read directly.
+	tx, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+	_, err = tx.Exec(ctx, "SET LOCAL enable_seqscan = off")
+	require.NoError(t, err)
+	rows, err := tx.Query(ctx, "EXPLAIN "+listPostsByUserIDSQL, uuid.New())
+	require.NoError(t, err)
+	var plan []string
+	for rows.Next() {
+		var line string
+		require.NoError(t, rows.Scan(&line))
+		plan = append(plan, line)
+	}
+	require.NoError(t, rows.Err())
+	planText := strings.Join(plan, "\n")
+	assert.Contains(t, planText, "idx_posts_user_id_created_at")
+	assert.NotContains(t, planText, "Sort", "listing should read the index in order")
+	require.NoError(t, tx.Rollback(ctx))
+
 	// Create table instance
 	table, err := NewPostgresPostTable(ctx, pool)
 	require.NoError(t, err)
@@ -159,7 +186,7 @@ func TestPostgresPostTable_Serialization(t *testing.T) {
 				require.NoError(t, err)
 
 				// Delete post
-				err = table.DeletePost(ctx, deletePostID)
+				err = table.DeletePost(ctx, post)
 				require.NoError(t, err)
 
 				// Verify it's gone
@@ -168,6 +195,176 @@ func TestPostgresPostTable_Serialization(t *testing.T) {
 				assert.Equal(t, ErrPostNotFound, err)
 			},
 		},
+		{
+			name: "SearchPostsByUserID",
+			fn: func(t *testing.T, table PostTable, userID uuid.UUID, now time.Time) {
+				// Use a fresh user so posts from other subtests don't match
+				searchUserID := uuid.New()
+				titles := []string{"Go Generics Explained", "Learning go", "Rust ownership", "100% coverage"}
+				for i, title := range titles {
+					require.NoError(t, table.PutPost(ctx, &Post{
+						ID:        uuid.New(),
+						UserID:    searchUserID,
+						Title:     title,
+						Content:   "content",
+						CreatedAt: now.Add(time.Duration(i) * time.Second),
+						UpdatedAt: now.Add(time.Duration(i) * time.Second),
+					}))
+				}
+				// Another user's matching post must not be returned
+				require.NoError(t, table.PutPost(ctx, &Post{ID: uuid.New(), UserID: uuid.New(), Title: "Go elsewhere", CreatedAt: now, UpdatedAt: now}))
+
+				titlesOf := func(posts []Post) []string {
+					result := make([]string, len(posts))
+					for i, post := range posts {
+						result[i] = post.Title
+					}
+					return result
+				}
+
+				// Matching is case-insensitive, newest first
+				posts, err := table.SearchPostsByUserID(ctx, searchUserID, "go")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"Learning go", "Go Generics Explained"}, titlesOf(posts))
+
+				// LIKE wildcards in the query match literally
+				posts, err = table.SearchPostsByUserID(ctx, searchUserID, "0%")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"100% coverage"}, titlesOf(posts))
+
+				posts, err = table.SearchPostsByUserID(ctx, searchUserID, "python")
+				require.NoError(t, err)
+				assert.Empty(t, posts)
+			},
+		},
+		{
+			name: "DeletePost of a missing post",
+			fn: func(t *testing.T, table PostTable, userID uuid.UUID, now time.Time) {
+				err := table.DeletePost(ctx, &Post{ID: uuid.New(), UserID: userID, CreatedAt: now, UpdatedAt: now})
+				assert.ErrorIs(t, err, ErrPostNotFound)
+			},
+		},
+		{
+			name: "concurrent DeletePost",
+			fn: func(t *testing.T, table PostTable, userID uuid.UUID, now time.Time) {
+				deletePostID := uuid.New()
+				post := &Post{
+					ID:        deletePostID,
+					UserID:    userID,
+					Title:     "Deleted Concurrently",
+					Content:   "Only one delete wins",
+					CreatedAt: now.Add(time.Second),
+					UpdatedAt: now.Add(time.Second),
+				}
+				require.NoError(t, table.PutPost(ctx, post))
+
+				const deletes = 5
+				var wg sync.WaitGroup
+				errs := make(chan error, deletes)
+				for range deletes {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						errs <- table.DeletePost(ctx, post)
+					}()
+				}
+				wg.Wait()
+				close(errs)
+
+				// Exactly one delete succeeds; the rest see the post as already gone
+				succeeded := 0
+				for err := range errs {
+					if err == nil {
+						succeeded++
+						continue
+					}
+					assert.ErrorIs(t, err, ErrPostNotFound)
+				}
+				assert.Equal(t, 1, succeeded)
+			},
+		},
+		{
+			name: "DeletePost after the owner changed",
+			fn: func(t *testing.T, table PostTable, userID uuid.UUID, now time.Time) {
+				post := &Post{
+					ID:        uuid.New(),
+					UserID:    userID,
+					Title:     "Changes Owner",
+					Content:   "Read by one owner, then moved to another",
+					CreatedAt: now.Add(2 * time.Second),
+					UpdatedAt: now.Add(2 * time.Second),
+				}
+				require.NoError(t, table.PutPost(ctx, post))
+
+				// The service checks ownership against the post as read
+				read, err := table.GetPostByID(ctx, post.ID)
+				require.NoError(t, err)
+
+				newOwnerID := uuid.New()
+				_, err = pool.Exec(ctx, "UPDATE posts SET user_id = $1 WHERE id = $2", newOwnerID, post.ID)
+				require.NoError(t, err)
+
+				// The delete must not remove the post from its new owner
+				err = table.DeletePost(ctx, read)
+				assert.ErrorIs(t, err, ErrPostNotFound)
+
+				current, err := table.GetPostByID(ctx, post.ID)
+				require.NoError(t, err)
+				assert.Equal(t, newOwnerID, current.UserID)
+			},
+		},
+		{
+			name: "PutPostIfNotExists - concurrent inserts of one ID",
+			fn: func(t *testing.T, table PostTable, userID uuid.UUID, now time.Time) {
+				postID := uuid.New()
+
+				// Each insert is a separate attempt with its own timestamps, as retried requests are
+				const inserts = 5
+				var wg sync.WaitGroup
+				errs := make(chan error, inserts)
+				for i := range inserts {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						createdAt := now.Add(time.Duration(i+10) * time.Second)
+						errs <- table.PutPostIfNotExists(ctx, &Post{
+							ID:        postID,
+							UserID:    userID,
+							Title:     "Idempotent Post",
+							Content:   "Only one insert wins",
+							CreatedAt: createdAt,
+							UpdatedAt: createdAt,
+						})
+					}()
+				}
+				wg.Wait()
+				close(errs)
+
+				succeeded := 0
+				for err := range errs {
+					if err == nil {
+						succeeded++
+						continue
+					}
+					assert.ErrorIs(t, err, ErrPostExists)
+				}
+				assert.Equal(t, 1, succeeded)
+
+				// Deleting the post frees its ID again
+				inserted, err := table.GetPostByID(ctx, postID)
+				require.NoError(t, err)
+				require.NoError(t, table.DeletePost(ctx, inserted))
+				reinserted := &Post{
+					ID:        postID,
+					UserID:    userID,
+					Title:     "Idempotent Post",
+					CreatedAt: now.Add(time.Minute),
+					UpdatedAt: now.Add(time.Minute),
+				}
+				require.NoError(t, table.PutPostIfNotExists(ctx, reinserted))
+				require.NoError(t, table.DeletePost(ctx, reinserted))
+			},
+		},
 	}
 
 	for _, tt := range tests {
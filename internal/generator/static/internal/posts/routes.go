@@ -3,6 +3,8 @@ package posts
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -13,11 +15,19 @@ import (
 // RegisterRoutes registers all post routes with the given service
 func RegisterRoutes(service Service, r chi.Router) {
 	r.Route("/posts", func(r chi.Router) {
-		r.Post("/", createPost(service))
 		r.Get("/", listPosts(service))
 		r.Get("/{post_id}", getPost(service))
-		r.Put("/{post_id}", updatePost(service))
-		r.Delete("/{post_id}", deletePost(service))
+
+		// Routes that act as the caller get its user ID from UserIDMiddleware
+		r.Group(func(r chi.Router) {
+			r.Use(UserIDMiddleware)
+			r.Post("/", createPost(service))
+			r.Post("/batch", createPosts(service))
+			r.Get("/search", searchPosts(service))
+			r.Put("/{post_id}", replacePost(service))
+			r.Patch("/{post_id}", updatePost(service))
+			r.Delete("/{post_id}", deletePost(service))
+		})
 	})
 }
 
@@ -26,48 +36,64 @@ type CreatePostRequest struct {
 	Content string `json:"content"`
 }
 
+// UpdatePostRequest is a partial update: omitted fields are left unchanged
 type UpdatePostRequest struct {
-	Title   string `json:"title,omitempty"`
-	Content string `json:"content,omitempty"`
+	Title   *string `json:"title,omitempty"`
+	Content *string `json:"content,omitempty"`
 }
 
-// getUserIDFromHeader extracts and validates the user ID from the X-User-ID header
-func getUserIDFromHeader(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
-	userIDStr := r.Header.Get("X-User-ID")
-	if userIDStr == "" {
-		jsonError(w, "Missing X-User-ID header", http.StatusBadRequest)
-		return uuid.Nil, false
-	}
+// UserIDMiddleware resolves the caller's user ID from the authenticated identity or the X-User-ID
+// header and stores it in the request context, where handlers read it with UserIDFromContext.
+// Responds 401 if the request is unauthenticated and 400 if the user ID is invalid. Swap it for
+// auth middleware that calls ContextWithUserID to change how callers are identified.
+func UserIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, err := resolveUserID(r.Context(), r.Header.Get("X-User-ID"))
+		if errors.Is(err, ErrUnauthenticated) {
+			jsonError(w, CodeUnauthenticated, "Authentication required", http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			slog.Error("Invalid user ID", "error", err, "user_id", r.Header.Get("X-User-ID"))
+			jsonError(w, CodeInvalidUserID, "Invalid user ID", http.StatusBadRequest)
+			return
+		}
 
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		slog.Error("Invalid user ID", "error", err, "user_id", userIDStr)
-		jsonError(w, "Invalid user ID", http.StatusBadRequest)
-		return uuid.Nil, false
-	}
+		next.ServeHTTP(w, r.WithContext(ContextWithUserID(r.Context(), userID)))
+	})
+}
 
-	return userID, true
+// callerUserID returns the user ID UserIDMiddleware stored in the request context
+func callerUserID(r *http.Request) uuid.UUID {
+	userID, _ := UserIDFromContext(r.Context())
+	return userID
 }
 
 // createPost handles POST /posts
 func createPost(service Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID, ok := getUserIDFromHeader(w, r)
-		if !ok {
-			return
-		}
+		userID := callerUserID(r)
 
 		var req CreatePostRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err := decodeRequest(r.Body, createPostRequestSchema, &req); err != nil {
 			slog.Error("Failed to decode request body", "error", err)
-			jsonError(w, "Invalid request body", http.StatusBadRequest)
+			jsonResponse(w, newRequestErrorResponse(err), requestErrorStatus(err))
 			return
 		}
 
-		post, err := service.CreatePost(r.Context(), userID, req.Title, req.Content)
+		ctx := ContextWithIdempotencyKey(r.Context(), r.Header.Get(IdempotencyKeyHeader))
+		post, err := service.CreatePost(ctx, userID, req.Title, req.Content)
+		if errors.Is(err, ErrValidation) {
+			jsonResponse(w, newRequestErrorResponse(err), http.StatusBadRequest)
+			return
+		}
+		if status, code, message, ok := idempotencyErrorStatus(err); ok {
+			jsonError(w, code, message, status)
+			return
+		}
 		if err != nil {
 			slog.Error("Failed to create post", "error", err)
-			jsonError(w, "Failed to create post", http.StatusInternalServerError)
+			jsonError(w, CodeInternal, "Failed to create post", http.StatusInternalServerError)
 			return
 		}
 
@@ -75,6 +101,44 @@ func createPost(service Service) http.HandlerFunc {
 	}
 }
 
+// createPosts handles POST /posts/batch
+func createPosts(service Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := callerUserID(r)
+
+		var req CreatePostsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			slog.Error("Failed to decode request body", "error", err)
+			jsonResponse(w, newRequestErrorResponse(err), requestErrorStatus(err))
+			return
+		}
+		if len(req.Posts) == 0 {
+			jsonError(w, CodeInvalidRequest, "No posts provided", http.StatusBadRequest)
+			return
+		}
+
+		dedupe, err := parseDedupe(r.URL.Query().Get(DedupeQueryParam))
+		if err != nil {
+			jsonError(w, CodeInvalidRequest, "dedupe must be true or false", http.StatusBadRequest)
+			return
+		}
+
+		results, err := service.CreatePosts(r.Context(), userID, req.Posts, dedupe)
+		if errors.Is(err, ErrBatchTooLarge) {
+			jsonError(w, CodeBatchTooLarge, fmt.Sprintf("Too many posts (max %d)", MaxBatchSize), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to create posts", "error", err)
+			jsonError(w, CodeInternal, "Failed to create posts", http.StatusInternalServerError)
+			return
+		}
+
+		resp, statusCode := newCreatePostsResponse(results)
+		jsonResponse(w, resp, statusCode)
+	}
+}
+
 // getPost handles GET /posts/{post_id}
 func getPost(service Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -82,48 +146,78 @@ func getPost(service Service) http.HandlerFunc {
 		postID, err := uuid.Parse(postIDStr)
 		if err != nil {
 			slog.Error("Invalid post_id", "error", err, "post_id", postIDStr)
-			jsonError(w, "Invalid post_id", http.StatusBadRequest)
+			jsonError(w, CodeInvalidPostID, "Invalid post_id", http.StatusBadRequest)
 			return
 		}
 
 		post, err := service.GetPost(r.Context(), postID)
-		if err == ErrPostNotFound {
-			jsonError(w, "Post not found", http.StatusNotFound)
+		if errors.Is(err, ErrPostNotFound) {
+			jsonError(w, CodePostNotFound, "Post not found", http.StatusNotFound)
 			return
 		}
 		if err != nil {
 			slog.Error("Failed to get post", "error", err)
-			jsonError(w, "Failed to get post", http.StatusInternalServerError)
+			jsonError(w, CodeInternal, "Failed to get post", http.StatusInternalServerError)
 			return
 		}
 
+		if setCacheHeaders(w.Header(), r, post) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
 		jsonResponse(w, post, http.StatusOK)
 	}
 }
 
 // listPosts handles GET /posts
 func listPosts(service Service) http.HandlerFunc {
+	// Without an explicit user_id, list the caller's own posts
+	listOwnPosts := UserIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listUserPosts(w, r, service, callerUserID(r))
+	}))
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		userIDStr := r.URL.Query().Get("user_id")
 		if userIDStr == "" {
-			userIDStr = r.Header.Get("X-User-ID")
-		}
-		if userIDStr == "" {
-			jsonError(w, "Missing user_id parameter or X-User-ID header", http.StatusBadRequest)
+			listOwnPosts.ServeHTTP(w, r)
 			return
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
 			slog.Error("Invalid user ID", "error", err, "user_id", userIDStr)
-			jsonError(w, "Invalid user ID", http.StatusBadRequest)
+			jsonError(w, CodeInvalidUserID, "Invalid user ID", http.StatusBadRequest)
 			return
 		}
+		listUserPosts(w, r, service, userID)
+	}
+}
+
+// listUserPosts responds with userID's posts
+func listUserPosts(w http.ResponseWriter, r *http.Request, service Service, userID uuid.UUID) {
+	postList, err := service.ListUserPosts(r.Context(), userID)
+	if err != nil {
+		slog.Error("Failed to list posts", "error", err, "user_id", userID)
+		jsonError(w, CodeInternal, "Failed to list posts", http.StatusInternalServerError)
+		return
+	}
+
+	jsonResponse(w, postList, http.StatusOK)
+}
+
+// searchPosts handles GET /posts/search?q=, searching the caller's own posts by title
+func searchPosts(service Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := callerUserID(r)
 
-		postList, err := service.ListUserPosts(r.Context(), userID)
+		postList, err := service.SearchPosts(r.Context(), userID, r.URL.Query().Get("q"))
+		if errors.Is(err, ErrEmptySearchQuery) {
+			jsonError(w, CodeEmptySearchQuery, "Missing search query q", http.StatusBadRequest)
+			return
+		}
 		if err != nil {
-			slog.Error("Failed to list posts", "error", err, "user_id", userID)
-			jsonError(w, "Failed to list posts", http.StatusInternalServerError)
+			slog.Error("Failed to search posts", "error", err, "user_id", userID)
+			jsonError(w, CodeInternal, "Failed to search posts", http.StatusInternalServerError)
 			return
 		}
 
@@ -131,37 +225,86 @@ func listPosts(service Service) http.HandlerFunc {
 	}
 }
 
-// updatePost handles PUT /posts/{post_id}
-func updatePost(service Service) http.HandlerFunc {
+// replacePost handles PUT /posts/{post_id}, replacing the whole post: like POST /posts it
+// requires both title and content
+func replacePost(service Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID, ok := getUserIDFromHeader(w, r)
-		if !ok {
+		userID := callerUserID(r)
+
+		postIDStr := chi.URLParam(r, "post_id")
+		postID, err := uuid.Parse(postIDStr)
+		if err != nil {
+			slog.Error("Invalid post_id", "error", err, "post_id", postIDStr)
+			jsonError(w, CodeInvalidPostID, "Invalid post_id", http.StatusBadRequest)
+			return
+		}
+
+		var req CreatePostRequest
+		if err := decodeRequest(r.Body, createPostRequestSchema, &req); err != nil {
+			slog.Error("Failed to decode request body", "error", err)
+			jsonResponse(w, newRequestErrorResponse(err), requestErrorStatus(err))
+			return
+		}
+
+		post, err := service.UpdatePost(r.Context(), userID, postID, &req.Title, &req.Content)
+		if errors.Is(err, ErrValidation) {
+			jsonResponse(w, newRequestErrorResponse(err), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, ErrPostNotFound) {
+			jsonError(w, CodePostNotFound, "Post not found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, ErrForbidden) {
+			jsonError(w, CodeForbidden, "Post belongs to another user", http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to update post", "error", err, "user_id", userID, "post_id", postID)
+			jsonError(w, CodeInternal, "Failed to update post", http.StatusInternalServerError)
+			return
+		}
+
+		jsonResponse(w, post, http.StatusOK)
+	}
+}
+
+// updatePost handles PATCH /posts/{post_id}
+func updatePost(service Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := callerUserID(r)
 
 		postIDStr := chi.URLParam(r, "post_id")
 		postID, err := uuid.Parse(postIDStr)
 		if err != nil {
 			slog.Error("Invalid post_id", "error", err, "post_id", postIDStr)
-			jsonError(w, "Invalid post_id", http.StatusBadRequest)
+			jsonError(w, CodeInvalidPostID, "Invalid post_id", http.StatusBadRequest)
 			return
 		}
 
 		var req UpdatePostRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err := decodeRequest(r.Body, updatePostRequestSchema, &req); err != nil {
 			slog.Error("Failed to decode request body", "error", err)
-			jsonError(w, "Invalid request body", http.StatusBadRequest)
+			jsonResponse(w, newRequestErrorResponse(err), requestErrorStatus(err))
 			return
 		}
 
-		post, err := service.UpdatePost(r.Context(), postID, req.Title, req.Content)
-		if err == ErrPostNotFound {
-			jsonError(w, "Post not found", http.StatusNotFound)
+		post, err := service.UpdatePost(r.Context(), userID, postID, req.Title, req.Content)
+		if errors.Is(err, ErrValidation) {
+			jsonResponse(w, newRequestErrorResponse(err), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, ErrPostNotFound) {
+			jsonError(w, CodePostNotFound, "Post not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrForbidden) {
+			jsonError(w, CodeForbidden, "Post belongs to another user", http.StatusForbidden)
 			return
 		}
 		if err != nil {
 			slog.Error("Failed to update post", "error", err, "user_id", userID, "post_id", postID)
-			jsonError(w, "Failed to update post", http.StatusInternalServerError)
+			jsonError(w, CodeInternal, "Failed to update post", http.StatusInternalServerError)
 			return
 		}
 
@@ -172,27 +315,28 @@ func updatePost(service Service) http.HandlerFunc {
 // deletePost handles DELETE /posts/{post_id}
 func deletePost(service Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID, ok := getUserIDFromHeader(w, r)
-		if !ok {
-			return
-		}
+		userID := callerUserID(r)
 
 		postIDStr := chi.URLParam(r, "post_id")
 		postID, err := uuid.Parse(postIDStr)
 		if err != nil {
 			slog.Error("Invalid post_id", "error", err, "post_id", postIDStr)
-			jsonError(w, "Invalid post_id", http.StatusBadRequest)
+			jsonError(w, CodeInvalidPostID, "Invalid post_id", http.StatusBadRequest)
 			return
 		}
 
-		err = service.DeletePost(r.Context(), postID)
-		if err == ErrPostNotFound {
-			jsonError(w, "Post not found", http.StatusNotFound)
+		err = service.DeletePost(r.Context(), userID, postID)
+		if errors.Is(err, ErrPostNotFound) {
+			jsonError(w, CodePostNotFound, "Post not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrForbidden) {
+			jsonError(w, CodeForbidden, "Post belongs to another user", http.StatusForbidden)
 			return
 		}
 		if err != nil {
 			slog.Error("Failed to delete post", "error", err, "user_id", userID, "post_id", postID)
-			jsonError(w, "Failed to delete post", http.StatusInternalServerError)
+			jsonError(w, CodeInternal, "Failed to delete post", http.StatusInternalServerError)
 			return
 		}
 
@@ -209,10 +353,8 @@ func jsonResponse(w http.ResponseWriter, data interface{}, statusCode int) {
 	}
 }
 
-// jsonError writes a JSON error response
-func jsonError(w http.ResponseWriter, message string, statusCode int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(map[string]string{"error": message})
+// jsonError writes a JSON error response with a stable code
+func jsonError(w http.ResponseWriter, code ErrorCode, message string, statusCode int) {
+	jsonResponse(w, NewErrorResponse(code, message), statusCode)
 }
 
@@ -0,0 +1,328 @@
+//go:build ignore
+
+package posts
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// groupRouter is satisfied by both *echo.Echo and *echo.Group, so the posts
+// routes can be mounted at the root or under an API prefix
+type groupRouter interface {
+	Group(prefix string, m ...echo.MiddlewareFunc) *echo.Group
+}
+
+// RegisterRoutes registers all post routes with the given service,
+// applying middleware (e.g. authentication) to the posts group
+func RegisterRoutes(service Service, e groupRouter, middleware ...echo.MiddlewareFunc) {
+	g := e.Group("/posts", middleware...)
+	g.POST("", createPost(service))
+	g.POST("/batch", createPosts(service))
+	g.GET("", listPosts(service))
+	g.GET("/search", searchPosts(service))
+	g.GET("/:post_id", getPost(service))
+	g.PUT("/:post_id", replacePost(service))
+	g.PATCH("/:post_id", updatePost(service))
+	g.DELETE("/:post_id", deletePost(service))
+}
+
+type CreatePostRequest struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// UpdatePostRequest is a partial update: omitted fields are left unchanged
+type UpdatePostRequest struct {
+	Title   *string `json:"title,omitempty"`
+	Content *string `json:"content,omitempty"`
+}
+
+// getUserID resolves the caller's user ID from the authenticated identity or the X-User-ID header.
+// Responds 401 if the request is unauthenticated and 400 if the user ID is invalid.
+func getUserID(c echo.Context) (uuid.UUID, bool) {
+	header := c.Request().Header.Get("X-User-ID")
+	userID, err := resolveUserID(c.Request().Context(), header)
+	if errors.Is(err, ErrUnauthenticated) {
+		jsonError(c, CodeUnauthenticated, "Authentication required", http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+	if err != nil {
+		slog.Error("Invalid user ID", "error", err, "user_id", header)
+		jsonError(c, CodeInvalidUserID, "Invalid user ID", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// getPostIDFromPath extracts and validates the post ID from the URL path
+func getPostIDFromPath(c echo.Context) (uuid.UUID, bool) {
+	postIDStr := c.Param("post_id")
+	postID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		slog.Error("Invalid post_id", "error", err, "post_id", postIDStr)
+		jsonError(c, CodeInvalidPostID, "Invalid post_id", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return postID, true
+}
+
+// createPost handles POST /posts
+func createPost(service Service) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, ok := getUserID(c)
+		if !ok {
+			return nil
+		}
+
+		var req CreatePostRequest
+		if err := decodeRequest(c.Request().Body, createPostRequestSchema, &req); err != nil {
+			slog.Error("Failed to decode request body", "error", err)
+			return c.JSON(requestErrorStatus(err), newRequestErrorResponse(err))
+		}
+
+		ctx := ContextWithIdempotencyKey(c.Request().Context(), c.Request().Header.Get(IdempotencyKeyHeader))
+		post, err := service.CreatePost(ctx, userID, req.Title, req.Content)
+		if errors.Is(err, ErrValidation) {
+			return c.JSON(http.StatusBadRequest, newRequestErrorResponse(err))
+		}
+		if status, code, message, ok := idempotencyErrorStatus(err); ok {
+			return jsonError(c, code, message, status)
+		}
+		if err != nil {
+			slog.Error("Failed to create post", "error", err)
+			return jsonError(c, CodeInternal, "Failed to create post", http.StatusInternalServerError)
+		}
+
+		return c.JSON(http.StatusCreated, post)
+	}
+}
+
+// createPosts handles POST /posts/batch
+func createPosts(service Service) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, ok := getUserID(c)
+		if !ok {
+			return nil
+		}
+
+		var req CreatePostsRequest
+		if err := c.Bind(&req); err != nil {
+			slog.Error("Failed to decode request body", "error", err)
+			return jsonError(c, CodeInvalidRequest, "Invalid request body", http.StatusBadRequest)
+		}
+		if len(req.Posts) == 0 {
+			return jsonError(c, CodeInvalidRequest, "No posts provided", http.StatusBadRequest)
+		}
+
+		dedupe, err := parseDedupe(c.QueryParam(DedupeQueryParam))
+		if err != nil {
+			return jsonError(c, CodeInvalidRequest, "dedupe must be true or false", http.StatusBadRequest)
+		}
+
+		results, err := service.CreatePosts(c.Request().Context(), userID, req.Posts, dedupe)
+		if errors.Is(err, ErrBatchTooLarge) {
+			return jsonError(c, CodeBatchTooLarge, fmt.Sprintf("Too many posts (max %d)", MaxBatchSize), http.StatusBadRequest)
+		}
+		if err != nil {
+			slog.Error("Failed to create posts", "error", err)
+			return jsonError(c, CodeInternal, "Failed to create posts", http.StatusInternalServerError)
+		}
+
+		resp, statusCode := newCreatePostsResponse(results)
+		return c.JSON(statusCode, resp)
+	}
+}
+
+// getPost handles GET /posts/:post_id
+func getPost(service Service) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		postID, ok := getPostIDFromPath(c)
+		if !ok {
+			return nil
+		}
+
+		post, err := service.GetPost(c.Request().Context(), postID)
+		if errors.Is(err, ErrPostNotFound) {
+			return jsonError(c, CodePostNotFound, "Post not found", http.StatusNotFound)
+		}
+		if err != nil {
+			slog.Error("Failed to get post", "error", err)
+			return jsonError(c, CodeInternal, "Failed to get post", http.StatusInternalServerError)
+		}
+
+		if setCacheHeaders(c.Response().Header(), c.Request(), post) {
+			return c.NoContent(http.StatusNotModified)
+		}
+		return c.JSON(http.StatusOK, post)
+	}
+}
+
+// listPosts handles GET /posts
+func listPosts(service Service) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		// An explicit user_id lists that user's posts, otherwise the caller's own
+		var userID uuid.UUID
+		if userIDStr := c.QueryParam("user_id"); userIDStr != "" {
+			parsed, err := uuid.Parse(userIDStr)
+			if err != nil {
+				slog.Error("Invalid user ID", "error", err, "user_id", userIDStr)
+				return jsonError(c, CodeInvalidUserID, "Invalid user ID", http.StatusBadRequest)
+			}
+			userID = parsed
+		} else {
+			var ok bool
+			userID, ok = getUserID(c)
+			if !ok {
+				return nil
+			}
+		}
+
+		postList, err := service.ListUserPosts(c.Request().Context(), userID)
+		if err != nil {
+			slog.Error("Failed to list posts", "error", err, "user_id", userID)
+			return jsonError(c, CodeInternal, "Failed to list posts", http.StatusInternalServerError)
+		}
+
+		return c.JSON(http.StatusOK, postList)
+	}
+}
+
+// searchPosts handles GET /posts/search?q=, searching the caller's own posts by title
+func searchPosts(service Service) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, ok := getUserID(c)
+		if !ok {
+			return nil
+		}
+
+		postList, err := service.SearchPosts(c.Request().Context(), userID, c.QueryParam("q"))
+		if errors.Is(err, ErrEmptySearchQuery) {
+			return jsonError(c, CodeEmptySearchQuery, "Missing search query q", http.StatusBadRequest)
+		}
+		if err != nil {
+			slog.Error("Failed to search posts", "error", err, "user_id", userID)
+			return jsonError(c, CodeInternal, "Failed to search posts", http.StatusInternalServerError)
+		}
+
+		return c.JSON(http.StatusOK, postList)
+	}
+}
+
+// replacePost handles PUT /posts/:post_id, replacing the whole post: like POST /posts it
+// requires both title and content
+func replacePost(service Service) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, ok := getUserID(c)
+		if !ok {
+			return nil
+		}
+
+		postID, ok := getPostIDFromPath(c)
+		if !ok {
+			return nil
+		}
+
+		var req CreatePostRequest
+		if err := decodeRequest(c.Request().Body, createPostRequestSchema, &req); err != nil {
+			slog.Error("Failed to decode request body", "error", err)
+			return c.JSON(requestErrorStatus(err), newRequestErrorResponse(err))
+		}
+
+		post, err := service.UpdatePost(c.Request().Context(), userID, postID, &req.Title, &req.Content)
+		if errors.Is(err, ErrValidation) {
+			return c.JSON(http.StatusBadRequest, newRequestErrorResponse(err))
+		}
+		if errors.Is(err, ErrPostNotFound) {
+			return jsonError(c, CodePostNotFound, "Post not found", http.StatusNotFound)
+		}
+		if errors.Is(err, ErrForbidden) {
+			return jsonError(c, CodeForbidden, "Post belongs to another user", http.StatusForbidden)
+		}
+		if err != nil {
+			slog.Error("Failed to update post", "error", err, "user_id", userID, "post_id", postID)
+			return jsonError(c, CodeInternal, "Failed to update post", http.StatusInternalServerError)
+		}
+
+		return c.JSON(http.StatusOK, post)
+	}
+}
+
+// updatePost handles PATCH /posts/:post_id
+func updatePost(service Service) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, ok := getUserID(c)
+		if !ok {
+			return nil
+		}
+
+		postID, ok := getPostIDFromPath(c)
+		if !ok {
+			return nil
+		}
+
+		var req UpdatePostRequest
+		if err := decodeRequest(c.Request().Body, updatePostRequestSchema, &req); err != nil {
+			slog.Error("Failed to decode request body", "error", err)
+			return c.JSON(requestErrorStatus(err), newRequestErrorResponse(err))
+		}
+
+		post, err := service.UpdatePost(c.Request().Context(), userID, postID, req.Title, req.Content)
+		if errors.Is(err, ErrValidation) {
+			return c.JSON(http.StatusBadRequest, newRequestErrorResponse(err))
+		}
+		if errors.Is(err, ErrPostNotFound) {
+			return jsonError(c, CodePostNotFound, "Post not found", http.StatusNotFound)
+		}
+		if errors.Is(err, ErrForbidden) {
+			return jsonError(c, CodeForbidden, "Post belongs to another user", http.StatusForbidden)
+		}
+		if err != nil {
+			slog.Error("Failed to update post", "error", err, "user_id", userID, "post_id", postID)
+			return jsonError(c, CodeInternal, "Failed to update post", http.StatusInternalServerError)
+		}
+
+		return c.JSON(http.StatusOK, post)
+	}
+}
+
+// deletePost handles DELETE /posts/:post_id
+func deletePost(service Service) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, ok := getUserID(c)
+		if !ok {
+			return nil
+		}
+
+		postID, ok := getPostIDFromPath(c)
+		if !ok {
+			return nil
+		}
+
+		err := service.DeletePost(c.Request().Context(), userID, postID)
+		if errors.Is(err, ErrPostNotFound) {
+			return jsonError(c, CodePostNotFound, "Post not found", http.StatusNotFound)
+		}
+		if errors.Is(err, ErrForbidden) {
+			return jsonError(c, CodeForbidden, "Post belongs to another user", http.StatusForbidden)
+		}
+		if err != nil {
+			slog.Error("Failed to delete post", "error", err, "user_id", userID, "post_id", postID)
+			return jsonError(c, CodeInternal, "Failed to delete post", http.StatusInternalServerError)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// jsonError writes a JSON error response with a stable code
+func jsonError(c echo.Context, code ErrorCode, message string, statusCode int) error {
+	return c.JSON(statusCode, NewErrorResponse(code, message))
+}
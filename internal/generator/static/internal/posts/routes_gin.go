@@ -0,0 +1,349 @@
+//go:build ignore
+
+package posts
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RegisterRoutes registers all post routes with the given service
+func RegisterRoutes(service Service, r gin.IRouter) {
+	g := r.Group("/posts")
+	g.POST("", createPost(service))
+	g.POST("/batch", createPosts(service))
+	g.GET("", listPosts(service))
+	g.GET("/search", searchPosts(service))
+	g.GET("/:post_id", getPost(service))
+	g.PUT("/:post_id", replacePost(service))
+	g.PATCH("/:post_id", updatePost(service))
+	g.DELETE("/:post_id", deletePost(service))
+}
+
+type CreatePostRequest struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// UpdatePostRequest is a partial update: omitted fields are left unchanged
+type UpdatePostRequest struct {
+	Title   *string `json:"title,omitempty"`
+	Content *string `json:"content,omitempty"`
+}
+
+// getUserID resolves the caller's user ID from the authenticated identity or the X-User-ID header.
+// Responds 401 if the request is unauthenticated and 400 if the user ID is invalid.
+func getUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := resolveUserID(c.Request.Context(), c.GetHeader("X-User-ID"))
+	if errors.Is(err, ErrUnauthenticated) {
+		jsonError(c, CodeUnauthenticated, "Authentication required", http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+	if err != nil {
+		slog.Error("Invalid user ID", "error", err, "user_id", c.GetHeader("X-User-ID"))
+		jsonError(c, CodeInvalidUserID, "Invalid user ID", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// getPostIDFromPath extracts and validates the post ID from the URL path
+func getPostIDFromPath(c *gin.Context) (uuid.UUID, bool) {
+	postIDStr := c.Param("post_id")
+	postID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		slog.Error("Invalid post_id", "error", err, "post_id", postIDStr)
+		jsonError(c, CodeInvalidPostID, "Invalid post_id", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return postID, true
+}
+
+// createPost handles POST /posts
+func createPost(service Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := getUserID(c)
+		if !ok {
+			return
+		}
+
+		var req CreatePostRequest
+		if err := decodeRequest(c.Request.Body, createPostRequestSchema, &req); err != nil {
+			slog.Error("Failed to decode request body", "error", err)
+			c.JSON(requestErrorStatus(err), newRequestErrorResponse(err))
+			return
+		}
+
+		ctx := ContextWithIdempotencyKey(c.Request.Context(), c.GetHeader(IdempotencyKeyHeader))
+		post, err := service.CreatePost(ctx, userID, req.Title, req.Content)
+		if errors.Is(err, ErrValidation) {
+			c.JSON(http.StatusBadRequest, newRequestErrorResponse(err))
+			return
+		}
+		if status, code, message, ok := idempotencyErrorStatus(err); ok {
+			jsonError(c, code, message, status)
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to create post", "error", err)
+			jsonError(c, CodeInternal, "Failed to create post", http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusCreated, post)
+	}
+}
+
+// createPosts handles POST /posts/batch
+func createPosts(service Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := getUserID(c)
+		if !ok {
+			return
+		}
+
+		var req CreatePostsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			slog.Error("Failed to decode request body", "error", err)
+			jsonError(c, CodeInvalidRequest, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if len(req.Posts) == 0 {
+			jsonError(c, CodeInvalidRequest, "No posts provided", http.StatusBadRequest)
+			return
+		}
+
+		dedupe, err := parseDedupe(c.Query(DedupeQueryParam))
+		if err != nil {
+			jsonError(c, CodeInvalidRequest, "dedupe must be true or false", http.StatusBadRequest)
+			return
+		}
+
+		results, err := service.CreatePosts(c.Request.Context(), userID, req.Posts, dedupe)
+		if errors.Is(err, ErrBatchTooLarge) {
+			jsonError(c, CodeBatchTooLarge, fmt.Sprintf("Too many posts (max %d)", MaxBatchSize), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to create posts", "error", err)
+			jsonError(c, CodeInternal, "Failed to create posts", http.StatusInternalServerError)
+			return
+		}
+
+		resp, statusCode := newCreatePostsResponse(results)
+		c.JSON(statusCode, resp)
+	}
+}
+
+// getPost handles GET /posts/:post_id
+func getPost(service Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		postID, ok := getPostIDFromPath(c)
+		if !ok {
+			return
+		}
+
+		post, err := service.GetPost(c.Request.Context(), postID)
+		if errors.Is(err, ErrPostNotFound) {
+			jsonError(c, CodePostNotFound, "Post not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to get post", "error", err)
+			jsonError(c, CodeInternal, "Failed to get post", http.StatusInternalServerError)
+			return
+		}
+
+		if setCacheHeaders(c.Writer.Header(), c.Request, post) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+		c.JSON(http.StatusOK, post)
+	}
+}
+
+// listPosts handles GET /posts
+func listPosts(service Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// An explicit user_id lists that user's posts, otherwise the caller's own
+		var userID uuid.UUID
+		if userIDStr := c.Query("user_id"); userIDStr != "" {
+			parsed, err := uuid.Parse(userIDStr)
+			if err != nil {
+				slog.Error("Invalid user ID", "error", err, "user_id", userIDStr)
+				jsonError(c, CodeInvalidUserID, "Invalid user ID", http.StatusBadRequest)
+				return
+			}
+			userID = parsed
+		} else {
+			var ok bool
+			userID, ok = getUserID(c)
+			if !ok {
+				return
+			}
+		}
+
+		postList, err := service.ListUserPosts(c.Request.Context(), userID)
+		if err != nil {
+			slog.Error("Failed to list posts", "error", err, "user_id", userID)
+			jsonError(c, CodeInternal, "Failed to list posts", http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, postList)
+	}
+}
+
+// searchPosts handles GET /posts/search?q=, searching the caller's own posts by title
+func searchPosts(service Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := getUserID(c)
+		if !ok {
+			return
+		}
+
+		postList, err := service.SearchPosts(c.Request.Context(), userID, c.Query("q"))
+		if errors.Is(err, ErrEmptySearchQuery) {
+			jsonError(c, CodeEmptySearchQuery, "Missing search query q", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to search posts", "error", err, "user_id", userID)
+			jsonError(c, CodeInternal, "Failed to search posts", http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, postList)
+	}
+}
+
+// replacePost handles PUT /posts/:post_id, replacing the whole post: like POST /posts it
+// requires both title and content
+func replacePost(service Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := getUserID(c)
+		if !ok {
+			return
+		}
+
+		postID, ok := getPostIDFromPath(c)
+		if !ok {
+			return
+		}
+
+		var req CreatePostRequest
+		if err := decodeRequest(c.Request.Body, createPostRequestSchema, &req); err != nil {
+			slog.Error("Failed to decode request body", "error", err)
+			c.JSON(requestErrorStatus(err), newRequestErrorResponse(err))
+			return
+		}
+
+		post, err := service.UpdatePost(c.Request.Context(), userID, postID, &req.Title, &req.Content)
+		if errors.Is(err, ErrValidation) {
+			c.JSON(http.StatusBadRequest, newRequestErrorResponse(err))
+			return
+		}
+		if errors.Is(err, ErrPostNotFound) {
+			jsonError(c, CodePostNotFound, "Post not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrForbidden) {
+			jsonError(c, CodeForbidden, "Post belongs to another user", http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to update post", "error", err, "user_id", userID, "post_id", postID)
+			jsonError(c, CodeInternal, "Failed to update post", http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, post)
+	}
+}
+
+// updatePost handles PATCH /posts/:post_id
+func updatePost(service Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := getUserID(c)
+		if !ok {
+			return
+		}
+
+		postID, ok := getPostIDFromPath(c)
+		if !ok {
+			return
+		}
+
+		var req UpdatePostRequest
+		if err := decodeRequest(c.Request.Body, updatePostRequestSchema, &req); err != nil {
+			slog.Error("Failed to decode request body", "error", err)
+			c.JSON(requestErrorStatus(err), newRequestErrorResponse(err))
+			return
+		}
+
+		post, err := service.UpdatePost(c.Request.Context(), userID, postID, req.Title, req.Content)
+		if errors.Is(err, ErrValidation) {
+			c.JSON(http.StatusBadRequest, newRequestErrorResponse(err))
+			return
+		}
+		if errors.Is(err, ErrPostNotFound) {
+			jsonError(c, CodePostNotFound, "Post not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrForbidden) {
+			jsonError(c, CodeForbidden, "Post belongs to another user", http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to update post", "error", err, "user_id", userID, "post_id", postID)
+			jsonError(c, CodeInternal, "Failed to update post", http.StatusInternalServerError)
+			return
+		}
+
+		c.JSON(http.StatusOK, post)
+	}
+}
+
+// deletePost handles DELETE /posts/:post_id
+func deletePost(service Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := getUserID(c)
+		if !ok {
+			return
+		}
+
+		postID, ok := getPostIDFromPath(c)
+		if !ok {
+			return
+		}
+
+		err := service.DeletePost(c.Request.Context(), userID, postID)
+		if errors.Is(err, ErrPostNotFound) {
+			jsonError(c, CodePostNotFound, "Post not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, ErrForbidden) {
+			jsonError(c, CodeForbidden, "Post belongs to another user", http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			slog.Error("Failed to delete post", "error", err, "user_id", userID, "post_id", postID)
+			jsonError(c, CodeInternal, "Failed to delete post", http.StatusInternalServerError)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// jsonError writes a JSON error response with a stable code and aborts the handler chain
+func jsonError(c *gin.Context, code ErrorCode, message string, statusCode int) {
+	c.AbortWithStatusJSON(statusCode, NewErrorResponse(code, message))
+}
@@ -0,0 +1,626 @@
+package posts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubService records the user ID handlers pass to the service
+type stubService struct {
+	Service
+	userID uuid.UUID
+}
+
+func (s *stubService) CreatePost(ctx context.Context, userID uuid.UUID, title, content string) (*Post, error) {
+	s.userID = userID
+	return NewPost(userID, title, content), nil
+}
+
+func (s *stubService) ListUserPosts(ctx context.Context, userID uuid.UUID) ([]Post, error) {
+	s.userID = userID
+	return []Post{}, nil
+}
+
+func (s *stubService) SearchPosts(ctx context.Context, userID uuid.UUID, query string) ([]Post, error) {
+	s.userID = userID
+	if strings.TrimSpace(query) == "" {
+		return nil, ErrEmptySearchQuery
+	}
+	return []Post{*NewPost(userID, query, "content")}, nil
+}
+
+func (s *stubService) DeletePost(ctx context.Context, userID, postID uuid.UUID) error {
+	s.userID = userID
+	return nil
+}
+
+func TestRoutes_UserIdentity(t *testing.T) {
+	authedUserID := uuid.New()
+	headerUserID := uuid.New()
+	postPath := "/posts/" + uuid.NewString()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		header         string
+		authedUserID   uuid.UUID
+		expectedStatus int
+		expectedError  string
+		expectedCode   ErrorCode
+		expectedUserID uuid.UUID
+	}{
+		{
+			name:           "create with authenticated identity",
+			method:         http.MethodPost,
+			path:           "/posts",
+			body:           `{"title":"t","content":"c"}`,
+			header:         headerUserID.String(),
+			authedUserID:   authedUserID,
+			expectedStatus: http.StatusCreated,
+			expectedUserID: authedUserID,
+		},
+		{
+			name:           "create with header identity",
+			method:         http.MethodPost,
+			path:           "/posts",
+			body:           `{"title":"t","content":"c"}`,
+			header:         headerUserID.String(),
+			expectedStatus: http.StatusCreated,
+			expectedUserID: headerUserID,
+		},
+		{
+			name:           "create unauthenticated",
+			method:         http.MethodPost,
+			path:           "/posts",
+			body:           `{"title":"t","content":"c"}`,
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "Authentication required",
+			expectedCode:   CodeUnauthenticated,
+		},
+		{
+			name:           "create with invalid user ID",
+			method:         http.MethodPost,
+			path:           "/posts",
+			body:           `{"title":"t","content":"c"}`,
+			header:         "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid user ID",
+			expectedCode:   CodeInvalidUserID,
+		},
+		{
+			name:           "list with authenticated identity",
+			method:         http.MethodGet,
+			path:           "/posts",
+			authedUserID:   authedUserID,
+			expectedStatus: http.StatusOK,
+			expectedUserID: authedUserID,
+		},
+		{
+			name:           "list unauthenticated",
+			method:         http.MethodGet,
+			path:           "/posts",
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "Authentication required",
+			expectedCode:   CodeUnauthenticated,
+		},
+		{
+			name:           "list with invalid user_id parameter",
+			method:         http.MethodGet,
+			path:           "/posts?user_id=not-a-uuid",
+			authedUserID:   authedUserID,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid user ID",
+			expectedCode:   CodeInvalidUserID,
+		},
+		{
+			name:           "search with authenticated identity",
+			method:         http.MethodGet,
+			path:           "/posts/search?q=hello",
+			authedUserID:   authedUserID,
+			expectedStatus: http.StatusOK,
+			expectedUserID: authedUserID,
+		},
+		{
+			name:           "search without query",
+			method:         http.MethodGet,
+			path:           "/posts/search",
+			authedUserID:   authedUserID,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Missing search query q",
+			expectedCode:   CodeEmptySearchQuery,
+			expectedUserID: authedUserID,
+		},
+		{
+			name:           "search unauthenticated",
+			method:         http.MethodGet,
+			path:           "/posts/search?q=hello",
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "Authentication required",
+			expectedCode:   CodeUnauthenticated,
+		},
+		{
+			name:           "update unauthenticated",
+			method:         http.MethodPatch,
+			path:           postPath,
+			body:           `{"title":"t"}`,
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "Authentication required",
+			expectedCode:   CodeUnauthenticated,
+		},
+		{
+			name:           "delete with authenticated identity",
+			method:         http.MethodDelete,
+			path:           postPath,
+			authedUserID:   authedUserID,
+			expectedStatus: http.StatusNoContent,
+			expectedUserID: authedUserID,
+		},
+		{
+			name:           "delete unauthenticated",
+			method:         http.MethodDelete,
+			path:           postPath,
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "Authentication required",
+			expectedCode:   CodeUnauthenticated,
+		},
+		{
+			name:           "delete with invalid user ID",
+			method:         http.MethodDelete,
+			path:           postPath,
+			header:         "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid user ID",
+			expectedCode:   CodeInvalidUserID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &stubService{}
+			r := chi.NewRouter()
+			RegisterRoutes(service, r)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.header != "" {
+				req.Header.Set("X-User-ID", tt.header)
+			}
+			if tt.authedUserID != uuid.Nil {
+				req = req.WithContext(ContextWithUserID(req.Context(), tt.authedUserID))
+			}
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedError != "" {
+				var resp ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedCode, resp.Error.Code)
+				assert.Equal(t, tt.expectedError, resp.Error.Message)
+			}
+			assert.Equal(t, tt.expectedUserID, service.userID)
+		})
+	}
+}
+
+func TestUserIDMiddleware(t *testing.T) {
+	authedUserID := uuid.New()
+	headerUserID := uuid.New()
+
+	tests := []struct {
+		name           string
+		header         string
+		authedUserID   uuid.UUID
+		expectedStatus int
+		expectedError  string
+		expectedCode   ErrorCode
+		expectedUserID uuid.UUID
+	}{
+		{
+			name:           "header identity",
+			header:         headerUserID.String(),
+			expectedStatus: http.StatusOK,
+			expectedUserID: headerUserID,
+		},
+		{
+			name:           "authenticated identity takes precedence over header",
+			header:         headerUserID.String(),
+			authedUserID:   authedUserID,
+			expectedStatus: http.StatusOK,
+			expectedUserID: authedUserID,
+		},
+		{
+			name:           "missing user ID",
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "Authentication required",
+			expectedCode:   CodeUnauthenticated,
+		},
+		{
+			name:           "malformed user ID",
+			header:         "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid user ID",
+			expectedCode:   CodeInvalidUserID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			var userID uuid.UUID
+			handler := UserIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				userID, _ = UserIDFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/search", nil)
+			if tt.header != "" {
+				req.Header.Set("X-User-ID", tt.header)
+			}
+			if tt.authedUserID != uuid.Nil {
+				req = req.WithContext(ContextWithUserID(req.Context(), tt.authedUserID))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedError != "" {
+				assert.False(t, called, "next handler should not run")
+				var resp ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedCode, resp.Error.Code)
+				assert.Equal(t, tt.expectedError, resp.Error.Message)
+			}
+			assert.Equal(t, tt.expectedUserID, userID)
+		})
+	}
+}
+
+func TestRoutes_APIPrefix(t *testing.T) {
+	userID := uuid.NewString()
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "under prefix",
+			path:           "/api/v1/posts",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "without prefix",
+			path:           "/posts",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiRouter := chi.NewRouter()
+			RegisterRoutes(&stubService{}, apiRouter)
+			r := chi.NewRouter()
+			r.Mount("/api/v1", apiRouter)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-User-ID", userID)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestRoutes_BodyTooLarge(t *testing.T) {
+	const maxBytes = 64
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "create", path: "/posts"},
+		{name: "batch create", path: "/posts/batch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := chi.NewRouter()
+			RegisterRoutes(&stubService{}, r)
+
+			body := `{"title":"t","content":"` + strings.Repeat("a", maxBytes) + `"}`
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(body))
+			req.Header.Set("X-User-ID", uuid.NewString())
+			rec := httptest.NewRecorder()
+			// As the server's body limit middleware does
+			req.Body = http.MaxBytesReader(rec, req.Body, maxBytes)
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+			var resp ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, CodeRequestTooLarge, resp.Error.Code)
+			assert.Equal(t, "Request body too large", resp.Error.Message)
+		})
+	}
+}
+
+
+func TestRoutes_BatchDedupe(t *testing.T) {
+	table := newMemoryPostTable()
+	r := chi.NewRouter()
+	RegisterRoutes(NewService(table), r)
+	userID := uuid.New()
+
+	importPosts := func(query string) (*httptest.ResponseRecorder, CreatePostsResponse) {
+		body := `{"posts":[{"title":"first","content":"a"},{"title":"second","content":"b"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/posts/batch"+query, strings.NewReader(body))
+		req.Header.Set("X-User-ID", userID.String())
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		var resp CreatePostsResponse
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		}
+		return rec, resp
+	}
+
+	rec, resp := importPosts("?dedupe=true")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 0, resp.DuplicatesSkipped)
+
+	// Re-running the import creates nothing new
+	rec, resp = importPosts("?dedupe=true")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, resp.DuplicatesSkipped)
+	for _, item := range resp.Results {
+		assert.Equal(t, http.StatusOK, item.Status)
+		assert.True(t, item.Duplicate)
+	}
+	posts, err := table.ListPostsByUserID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+
+	rec, _ = importPosts("?dedupe=maybe")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRoutes_UpdateVerbs(t *testing.T) {
+	table := newMemoryPostTable()
+	r := chi.NewRouter()
+	RegisterRoutes(NewService(table), r)
+	userID := uuid.New()
+	post := NewPost(userID, "Title", "Content")
+	require.NoError(t, table.PutPost(context.Background(), post))
+
+	update := func(method, body string) Post {
+		req := httptest.NewRequest(method, "/posts/"+post.ID.String(), strings.NewReader(body))
+		req.Header.Set("X-User-ID", userID.String())
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var updated Post
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &updated))
+		return updated
+	}
+
+	// PUT replaces both fields
+	updated := update(http.MethodPut, `{"title":"Replaced","content":"Replaced content"}`)
+	assert.Equal(t, "Replaced", updated.Title)
+	assert.Equal(t, "Replaced content", updated.Content)
+
+	// PATCH changes only the fields sent
+	updated = update(http.MethodPatch, `{"content":"Patched content"}`)
+	assert.Equal(t, "Replaced", updated.Title)
+	assert.Equal(t, "Patched content", updated.Content)
+
+	stored, err := table.GetPostByID(context.Background(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Replaced", stored.Title)
+	assert.Equal(t, "Patched content", stored.Content)
+}
+
+func TestRoutes_InvalidPost(t *testing.T) {
+	// Validation fails before the table is used, so the service needs none. The title passes
+	// the request schema (when enabled) but not the service's limit.
+	r := chi.NewRouter()
+	RegisterRoutes(NewService(nil, WithMaxTitleLength(3)), r)
+
+	req := httptest.NewRequest(http.MethodPost, "/posts", strings.NewReader(`{"title":"Too long","content":"content"}`))
+	req.Header.Set("X-User-ID", uuid.NewString())
+	rec := httptest.NewRecorder()
+
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, CodeValidationFailed, resp.Error.Code)
+	assert.Equal(t, []FieldError{{Field: "/title", Message: "must be at most 3 characters"}}, resp.Error.Details)
+}
+
+// memoryTable stores posts in a map, implementing the calls idempotent creates make
+type memoryTable struct {
+	PostTable
+	mu    sync.Mutex
+	posts map[uuid.UUID]*Post
+}
+
+func (t *memoryTable) PutPostIfNotExists(ctx context.Context, post *Post) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.posts[post.ID]; ok {
+		return ErrPostExists
+	}
+	t.posts[post.ID] = post
+	return nil
+}
+
+func (t *memoryTable) GetPostByID(ctx context.Context, postID uuid.UUID) (*Post, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	post, ok := t.posts[postID]
+	if !ok {
+		return nil, ErrPostNotFound
+	}
+	return post, nil
+}
+
+func TestRoutes_IdempotentCreate(t *testing.T) {
+	table := &memoryTable{posts: make(map[uuid.UUID]*Post)}
+	r := chi.NewRouter()
+	RegisterRoutes(NewService(table), r)
+
+	userID := uuid.NewString()
+	create := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/posts", strings.NewReader(body))
+		req.Header.Set("X-User-ID", userID)
+		req.Header.Set(IdempotencyKeyHeader, key)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	var ids []uuid.UUID
+	for range 2 {
+		rec := create("key-1", `{"title":"title","content":"content"}`)
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var post Post
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &post))
+		ids = append(ids, post.ID)
+	}
+	assert.Equal(t, ids[0], ids[1], "a retry should return the original post")
+	assert.Len(t, table.posts, 1)
+
+	rec := create("key-1", `{"title":"title","content":"other content"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var errResp ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errResp))
+	assert.Equal(t, CodeIdempotencyKeyReused, errResp.Error.Code)
+
+	rec = create("key-2", `{"title":"title","content":"content"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Len(t, table.posts, 2, "a new key should create a new post")
+}
+
+func TestRoutes_ConditionalGet(t *testing.T) {
+	table := &memoryTable{posts: make(map[uuid.UUID]*Post)}
+	post := &Post{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Title:     "title",
+		Content:   "content",
+		CreatedAt: time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2025, time.January, 2, 12, 0, 0, 0, time.UTC),
+	}
+	table.posts[post.ID] = post
+	r := chi.NewRouter()
+	RegisterRoutes(NewService(table), r)
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/posts/"+post.ID.String(), nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, "Thu, 02 Jan 2025 12:00:00 GMT", rec.Header().Get("Last-Modified"))
+
+	tests := []struct {
+		name     string
+		header   string
+		value    string
+		expected int
+	}{
+		{name: "matching etag", header: "If-None-Match", value: etag, expected: http.StatusNotModified},
+		{name: "stale etag", header: "If-None-Match", value: `"stale"`, expected: http.StatusOK},
+		{name: "not modified since", header: "If-Modified-Since", value: rec.Header().Get("Last-Modified"), expected: http.StatusNotModified},
+		{name: "modified since", header: "If-Modified-Since", value: "Wed, 01 Jan 2025 12:00:00 GMT", expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(tt.header, tt.value)
+			assert.Equal(t, tt.expected, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			if tt.expected == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+
+	// Updating the post changes its ETag, so the client's copy is no longer current
+	table.posts[post.ID] = &Post{ID: post.ID, UserID: post.UserID, Title: "new title", Content: post.Content,
+		CreatedAt: post.CreatedAt, UpdatedAt: post.UpdatedAt.Add(time.Hour)}
+	rec = get("If-None-Match", etag)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+}
+
+func TestRoutes_ErrorCodes(t *testing.T) {
+	r := chi.NewRouter()
+	RegisterRoutes(NewService(&memoryTable{posts: make(map[uuid.UUID]*Post)}), r)
+
+	tests := []struct {
+		name            string
+		path            string
+		expectedStatus  int
+		expectedCode    ErrorCode
+		expectedMessage string
+	}{
+		{
+			name:            "missing post",
+			path:            "/posts/" + uuid.NewString(),
+			expectedStatus:  http.StatusNotFound,
+			expectedCode:    CodePostNotFound,
+			expectedMessage: "Post not found",
+		},
+		{
+			name:            "invalid post ID",
+			path:            "/posts/not-a-uuid",
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    CodeInvalidPostID,
+			expectedMessage: "Invalid post_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			var resp ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, ErrorResponse{Error: ErrorBody{Code: tt.expectedCode, Message: tt.expectedMessage}}, resp)
+		})
+	}
+}
+
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/Oudwins/zog"
 	"github.com/google/uuid"
 )
 
@@ -15,23 +18,129 @@ type Service interface {
 	CreatePost(ctx context.Context, userID uuid.UUID, title, content string) (*Post, error)
 	GetPost(ctx context.Context, postID uuid.UUID) (*Post, error)
 	ListUserPosts(ctx context.Context, userID uuid.UUID) ([]Post, error)
-	UpdatePost(ctx context.Context, postID uuid.UUID, title, content string) (*Post, error)
-	DeletePost(ctx context.Context, postID uuid.UUID) error
+	SearchPosts(ctx context.Context, userID uuid.UUID, query string) ([]Post, error)
+	UpdatePost(ctx context.Context, userID, postID uuid.UUID, title, content *string) (*Post, error)
+	DeletePost(ctx context.Context, userID, postID uuid.UUID) error
+	CreatePosts(ctx context.Context, userID uuid.UUID, inputs []PostInput, dedupe bool) ([]BatchResult, error)
+	GetPostsByIDs(ctx context.Context, postIDs []uuid.UUID) ([]Post, error)
 }
 
+// DefaultMaxTitleLength is the longest post title, in characters, accepted when not configured
+const DefaultMaxTitleLength = 200
+
+// DefaultMaxContentLength is the longest post content, in characters, accepted when not configured
+const DefaultMaxContentLength = 10000
+
 // service implements the Service interface
 type service struct {
-	postTable PostTable
+	postTable           PostTable
+	maxConcurrentWrites int
+	writeSlots          chan struct{}
+	maxTitleLength      int
+	maxContentLength    int
+	titleSchema         *zog.StringSchema[string]
+	contentSchema       *zog.StringSchema[string]
+}
+
+// ServiceOption configures optional service behavior
+type ServiceOption func(*service)
+
+// WithMaxConcurrentWrites bounds how many table batch writes CreatePosts runs at once, across
+// all requests
+// Values below 1 keep DefaultMaxConcurrentWrites
+func WithMaxConcurrentWrites(n int) ServiceOption {
+	return func(s *service) {
+		if n > 0 {
+			s.maxConcurrentWrites = n
+		}
+	}
+}
+
+// WithMaxTitleLength sets the longest post title, in characters, CreatePost and UpdatePost accept
+// Values below 1 keep DefaultMaxTitleLength
+func WithMaxTitleLength(n int) ServiceOption {
+	return func(s *service) {
+		if n > 0 {
+			s.maxTitleLength = n
+		}
+	}
+}
+
+// WithMaxContentLength sets the longest post content, in characters, CreatePost and UpdatePost accept
+// Values below 1 keep DefaultMaxContentLength
+func WithMaxContentLength(n int) ServiceOption {
+	return func(s *service) {
+		if n > 0 {
+			s.maxContentLength = n
+		}
+	}
 }
 
 // NewService creates a new posts service
-func NewService(postTable PostTable) Service {
-	return &service{postTable: postTable}
+func NewService(postTable PostTable, opts ...ServiceOption) Service {
+	s := &service{
+		postTable:           postTable,
+		maxConcurrentWrites: DefaultMaxConcurrentWrites,
+		maxTitleLength:      DefaultMaxTitleLength,
+		maxContentLength:    DefaultMaxContentLength,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.writeSlots = make(chan struct{}, s.maxConcurrentWrites)
+
+	// Titles are required; content may be empty. Lengths count characters, matching the
+	// request schemas' maxLength, rather than bytes.
+	s.titleSchema = zog.String().
+		Required(zog.Message("must not be empty")).
+		TestFunc(func(title *string, ctx zog.Ctx) bool {
+			return strings.TrimSpace(*title) != ""
+		}, zog.Message("must not be empty")).
+		TestFunc(func(title *string, ctx zog.Ctx) bool {
+			return utf8.RuneCountInString(*title) <= s.maxTitleLength
+		}, zog.Message(fmt.Sprintf("must be at most %d characters", s.maxTitleLength)))
+	s.contentSchema = zog.String().
+		TestFunc(func(content *string, ctx zog.Ctx) bool {
+			return utf8.RuneCountInString(*content) <= s.maxContentLength
+		}, zog.Message(fmt.Sprintf("must be at most %d characters", s.maxContentLength)))
+	return s
 }
 
-// CreatePost creates a new post
+// validatePost checks the post fields being written, skipping nil ones (fields an update
+// leaves unchanged). Every invalid field is reported in the returned *ValidationError.
+func (s *service) validatePost(title, content *string) error {
+	var fields []FieldError
+	check := func(field string, schema *zog.StringSchema[string], value *string) {
+		if value == nil {
+			return
+		}
+		// Validate a copy so schema processing never changes the caller's value
+		v := *value
+		for _, issue := range schema.Validate(&v) {
+			fields = append(fields, FieldError{Field: field, Message: issue.Message})
+		}
+	}
+	check("/title", s.titleSchema, title)
+	check("/content", s.contentSchema, content)
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// CreatePost creates a new post. An empty title or a field over its length limit returns a
+// *ValidationError, which matches ErrValidation.
+// When ctx carries an idempotency key (see ContextWithIdempotencyKey), repeating the request
+// with the same key returns the post the first request created instead of creating another.
 func (s *service) CreatePost(ctx context.Context, userID uuid.UUID, title, content string) (*Post, error) {
+	if err := s.validatePost(&title, &content); err != nil {
+		return nil, err
+	}
+
 	post := NewPost(userID, title, content)
+	if key, ok := IdempotencyKeyFromContext(ctx); ok {
+		return s.createIdempotentPost(ctx, post, key)
+	}
 	if err := s.postTable.PutPost(ctx, post); err != nil {
 		slog.ErrorContext(ctx, "Service: failed to create post", "error", err, "user_id", userID, "title", title)
 		return nil, fmt.Errorf("failed to create post: %w", err)
@@ -39,6 +148,41 @@ func (s *service) CreatePost(ctx context.Context, userID uuid.UUID, title, conte
 	return post, nil
 }
 
+// createIdempotentPost saves post under the ID derived from the idempotency key. If that post
+// already exists the request is a retry, and the existing post is returned as long as it was
+// created with the same title and content.
+func (s *service) createIdempotentPost(ctx context.Context, post *Post, key string) (*Post, error) {
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	post.ID = idempotentPostID(post.UserID, key)
+	err := s.postTable.PutPostIfNotExists(ctx, post)
+	if err == nil {
+		return post, nil
+	}
+	if !errors.Is(err, ErrPostExists) {
+		slog.ErrorContext(ctx, "Service: failed to create post", "error", err, "user_id", post.UserID, "title", post.Title)
+		return nil, fmt.Errorf("failed to create post: %w", err)
+	}
+
+	existing, err := s.postTable.GetPostByID(ctx, post.ID)
+	if errors.Is(err, ErrPostNotFound) {
+		// The post exists but can't be read yet, e.g. behind DynamoDB's eventually consistent GSI
+		slog.WarnContext(ctx, "Service: idempotent post not readable yet", "post_id", post.ID)
+		return nil, ErrIdempotencyKeyInUse
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: failed to get idempotent post", "error", err, "post_id", post.ID)
+		return nil, fmt.Errorf("failed to get post created for idempotency key: %w", err)
+	}
+	if existing.Title != post.Title || existing.Content != post.Content {
+		slog.WarnContext(ctx, "Service: idempotency key reused with a different post", "post_id", post.ID, "user_id", post.UserID)
+		return nil, ErrIdempotencyKeyReused
+	}
+	return existing, nil
+}
+
 // GetPost retrieves a post by its ID
 func (s *service) GetPost(ctx context.Context, postID uuid.UUID) (*Post, error) {
 	post, err := s.postTable.GetPostByID(ctx, postID)
@@ -63,8 +207,29 @@ func (s *service) ListUserPosts(ctx context.Context, userID uuid.UUID) ([]Post,
 	return posts, nil
 }
 
-// UpdatePost updates an existing post
-func (s *service) UpdatePost(ctx context.Context, postID uuid.UUID, title, content string) (*Post, error) {
+// SearchPosts returns the user's posts whose title contains query, newest first
+// Matching is case-insensitive on Postgres and case-sensitive on DynamoDB (see the table implementations)
+func (s *service) SearchPosts(ctx context.Context, userID uuid.UUID, query string) ([]Post, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	posts, err := s.postTable.SearchPostsByUserID(ctx, userID, query)
+	if err != nil {
+		slog.ErrorContext(ctx, "Service: failed to search posts", "error", err, "user_id", userID, "query", query)
+		return nil, fmt.Errorf("failed to search posts for user %s: %w", userID, err)
+	}
+	return posts, nil
+}
+
+// UpdatePost partially updates a post owned by userID: only non-nil fields are changed,
+// so a pointer to "" clears the content while nil leaves a field as is. Titles can't be cleared.
+func (s *service) UpdatePost(ctx context.Context, userID, postID uuid.UUID, title, content *string) (*Post, error) {
+	if err := s.validatePost(title, content); err != nil {
+		return nil, err
+	}
+
 	existingPost, err := s.postTable.GetPostByID(ctx, postID)
 	if err != nil {
 		if errors.Is(err, ErrPostNotFound) {
@@ -74,13 +239,17 @@ func (s *service) UpdatePost(ctx context.Context, postID uuid.UUID, title, conte
 		}
 		return nil, fmt.Errorf("failed to find post to update with ID %v: %w", postID, err)
 	}
+	if existingPost.UserID != userID {
+		slog.WarnContext(ctx, "Service: update of post owned by another user", "post_id", postID, "user_id", userID)
+		return nil, fmt.Errorf("user %s cannot update post %v: %w", userID, postID, ErrForbidden)
+	}
 
-	// Update fields if provided
-	if title != "" {
-		existingPost.Title = title
+	// Update only the fields present in the request
+	if title != nil {
+		existingPost.Title = *title
 	}
-	if content != "" {
-		existingPost.Content = content
+	if content != nil {
+		existingPost.Content = *content
 	}
 	existingPost.UpdatedAt = time.Now()
 
@@ -91,9 +260,33 @@ func (s *service) UpdatePost(ctx context.Context, postID uuid.UUID, title, conte
 	return existingPost, nil
 }
 
-// DeletePost deletes a post by its ID
-func (s *service) DeletePost(ctx context.Context, postID uuid.UUID) error {
-	if err := s.postTable.DeletePost(ctx, postID); err != nil {
+// DeletePost deletes a post owned by userID. The post is read first because callers only know
+// its ID: DynamoDB deletes by the primary key (UserID, CreatedAt), which GSI_PostID already
+// projects but cannot delete through, and the same read supplies the owner for the ownership
+// check. That makes a delete one GSI query plus one conditional delete, and the condition closes
+// the race window: of two racing deletes, the one that loses returns ErrPostNotFound.
+//
+// Deletes are idempotent in effect, since repeating one leaves the table unchanged, but a repeat
+// reports ErrPostNotFound rather than success: a missing post cannot be checked for ownership,
+// and answering success would hide a mistyped ID from the caller.
+func (s *service) DeletePost(ctx context.Context, userID, postID uuid.UUID) error {
+	post, err := s.postTable.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, ErrPostNotFound) {
+			slog.WarnContext(ctx, "Service: post not found for delete", "post_id", postID)
+		} else {
+			slog.ErrorContext(ctx, "Service: failed to find post to delete", "error", err, "post_id", postID)
+		}
+		return fmt.Errorf("failed to find post to delete with ID %v: %w", postID, err)
+	}
+	if post.UserID != userID {
+		slog.WarnContext(ctx, "Service: delete of post owned by another user", "post_id", postID, "user_id", userID)
+		return fmt.Errorf("user %s cannot delete post %v: %w", userID, postID, ErrForbidden)
+	}
+
+	// Deleting the post as read means a concurrent delete that got there first is reported, not
+	// repeated
+	if err := s.postTable.DeletePost(ctx, post); err != nil {
 		if errors.Is(err, ErrPostNotFound) {
 			slog.WarnContext(ctx, "Service: post not found for delete", "post_id", postID)
 		} else {
@@ -103,4 +296,3 @@ func (s *service) DeletePost(ctx context.Context, postID uuid.UUID) error {
 	}
 	return nil
 }
-
@@ -5,12 +5,15 @@ package posts
 import (
 	"context"
 	"errors"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewService(t *testing.T) {
@@ -68,6 +71,48 @@ func TestService_CreatePost(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name:        "empty title",
+			userID:      uuid.New(),
+			title:       "",
+			content:     "Test Content",
+			setupMock:   func(m *MockPostTable) {},
+			expectedErr: true,
+		},
+		{
+			name:        "blank title",
+			userID:      uuid.New(),
+			title:       "   ",
+			content:     "Test Content",
+			setupMock:   func(m *MockPostTable) {},
+			expectedErr: true,
+		},
+		{
+			name:        "title too long",
+			userID:      uuid.New(),
+			title:       strings.Repeat("a", DefaultMaxTitleLength+1),
+			content:     "Test Content",
+			setupMock:   func(m *MockPostTable) {},
+			expectedErr: true,
+		},
+		{
+			name:        "content too long",
+			userID:      uuid.New(),
+			title:       "Test Post",
+			content:     strings.Repeat("a", DefaultMaxContentLength+1),
+			setupMock:   func(m *MockPostTable) {},
+			expectedErr: true,
+		},
+		{
+			name:    "limits count characters, not bytes",
+			userID:  uuid.New(),
+			title:   strings.Repeat("é", DefaultMaxTitleLength),
+			content: "",
+			setupMock: func(m *MockPostTable) {
+				m.On("PutPost", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -94,6 +139,109 @@ func TestService_CreatePost(t *testing.T) {
 	}
 }
 
+func TestService_CreatePostValidation(t *testing.T) {
+	t.Parallel()
+
+	service := NewService(NewMockPostTable(t), WithMaxTitleLength(5), WithMaxContentLength(10))
+
+	_, err := service.CreatePost(context.Background(), uuid.New(), "", strings.Repeat("a", 11))
+	assert.ErrorIs(t, err, ErrValidation)
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{
+		{Field: "/title", Message: "must not be empty"},
+		{Field: "/content", Message: "must be at most 10 characters"},
+	}, validationErr.Fields)
+
+	_, err = service.CreatePost(context.Background(), uuid.New(), "Longer", "")
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{{Field: "/title", Message: "must be at most 5 characters"}}, validationErr.Fields)
+}
+
+func TestService_CreatePostIdempotent(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	postID := idempotentPostID(userID, "key-1")
+	original := &Post{ID: postID, UserID: userID, Title: "Test Post", Content: "Test Content"}
+
+	tests := []struct {
+		name        string
+		key         string
+		content     string
+		setupMock   func(*MockPostTable)
+		expectedErr error
+	}{
+		{
+			name:    "first request creates the post",
+			key:     "key-1",
+			content: "Test Content",
+			setupMock: func(m *MockPostTable) {
+				m.On("PutPostIfNotExists", mock.Anything, mock.MatchedBy(func(post *Post) bool {
+					return post.ID == postID
+				})).Return(nil)
+			},
+		},
+		{
+			name:    "retry returns the original post",
+			key:     "key-1",
+			content: "Test Content",
+			setupMock: func(m *MockPostTable) {
+				m.On("PutPostIfNotExists", mock.Anything, mock.Anything).Return(ErrPostExists)
+				m.On("GetPostByID", mock.Anything, postID).Return(original, nil)
+			},
+		},
+		{
+			name:    "key reused with different content",
+			key:     "key-1",
+			content: "Other Content",
+			setupMock: func(m *MockPostTable) {
+				m.On("PutPostIfNotExists", mock.Anything, mock.Anything).Return(ErrPostExists)
+				m.On("GetPostByID", mock.Anything, postID).Return(original, nil)
+			},
+			expectedErr: ErrIdempotencyKeyReused,
+		},
+		{
+			name:    "existing post not readable yet",
+			key:     "key-1",
+			content: "Test Content",
+			setupMock: func(m *MockPostTable) {
+				m.On("PutPostIfNotExists", mock.Anything, mock.Anything).Return(ErrPostExists)
+				m.On("GetPostByID", mock.Anything, postID).Return(nil, ErrPostNotFound)
+			},
+			expectedErr: ErrIdempotencyKeyInUse,
+		},
+		{
+			name:        "key too long",
+			key:         strings.Repeat("k", MaxIdempotencyKeyLength+1),
+			content:     "Test Content",
+			setupMock:   func(m *MockPostTable) {},
+			expectedErr: ErrInvalidIdempotencyKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTable := NewMockPostTable(t)
+			tt.setupMock(mockTable)
+			service := NewService(mockTable)
+
+			ctx := ContextWithIdempotencyKey(context.Background(), tt.key)
+			post, err := service.CreatePost(ctx, userID, "Test Post", tt.content)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, post)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, postID, post.ID)
+				assert.Equal(t, "Test Content", post.Content)
+			}
+			mockTable.AssertExpectations(t)
+		})
+	}
+}
+
 func TestService_GetPost(t *testing.T) {
 	t.Parallel()
 
@@ -234,51 +382,133 @@ func TestService_UpdatePost(t *testing.T) {
 
 	postID := uuid.New()
 	userID := uuid.New()
-	existingPost := &Post{
-		ID:        postID,
-		UserID:    userID,
-		Title:     "Old Title",
-		Content:   "Old Content",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	// existingPost returns a fresh copy, since UpdatePost modifies the post it loads
+	existingPost := func() *Post {
+		return &Post{
+			ID:        postID,
+			UserID:    userID,
+			Title:     "Old Title",
+			Content:   "Old Content",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
 	}
+	ptr := func(s string) *string { return &s }
 
 	tests := []struct {
-		name        string
-		postID      uuid.UUID
-		title       string
-		content     string
-		setupMock   func(*MockPostTable)
-		expectedErr bool
+		name            string
+		userID          uuid.UUID
+		postID          uuid.UUID
+		title           *string
+		content         *string
+		setupMock       func(*MockPostTable)
+		expectedTitle   string
+		expectedContent string
+		expectedErr     bool
+		expectedErrIs   error
 	}{
 		{
 			name:    "successful update",
+			userID:  userID,
 			postID:  postID,
-			title:   "New Title",
-			content: "New Content",
+			title:   ptr("New Title"),
+			content: ptr("New Content"),
 			setupMock: func(m *MockPostTable) {
-				m.On("GetPostByID", mock.Anything, postID).Return(existingPost, nil)
+				m.On("GetPostByID", mock.Anything, postID).Return(existingPost(), nil)
 				m.On("PutPost", mock.Anything, mock.MatchedBy(func(post *Post) bool {
 					return post.ID == postID && post.Title == "New Title" && post.Content == "New Content"
 				})).Return(nil)
 			},
-			expectedErr: false,
+			expectedTitle:   "New Title",
+			expectedContent: "New Content",
+		},
+		{
+			name:   "update title only",
+			userID: userID,
+			postID: postID,
+			title:  ptr("New Title"),
+			setupMock: func(m *MockPostTable) {
+				m.On("GetPostByID", mock.Anything, postID).Return(existingPost(), nil)
+				m.On("PutPost", mock.Anything, mock.MatchedBy(func(post *Post) bool {
+					return post.Title == "New Title" && post.Content == "Old Content"
+				})).Return(nil)
+			},
+			expectedTitle:   "New Title",
+			expectedContent: "Old Content",
+		},
+		{
+			name:    "update content only",
+			userID:  userID,
+			postID:  postID,
+			content: ptr("New Content"),
+			setupMock: func(m *MockPostTable) {
+				m.On("GetPostByID", mock.Anything, postID).Return(existingPost(), nil)
+				m.On("PutPost", mock.Anything, mock.MatchedBy(func(post *Post) bool {
+					return post.Title == "Old Title" && post.Content == "New Content"
+				})).Return(nil)
+			},
+			expectedTitle:   "Old Title",
+			expectedContent: "New Content",
+		},
+		{
+			name:    "empty string clears the field",
+			userID:  userID,
+			postID:  postID,
+			content: ptr(""),
+			setupMock: func(m *MockPostTable) {
+				m.On("GetPostByID", mock.Anything, postID).Return(existingPost(), nil)
+				m.On("PutPost", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedTitle:   "Old Title",
+			expectedContent: "",
+		},
+		{
+			name:          "empty title",
+			userID:        userID,
+			postID:        postID,
+			title:         ptr(""),
+			setupMock:     func(m *MockPostTable) {},
+			expectedErr:   true,
+			expectedErrIs: ErrValidation,
+		},
+		{
+			name:          "content too long",
+			userID:        userID,
+			postID:        postID,
+			content:       ptr(strings.Repeat("a", DefaultMaxContentLength+1)),
+			setupMock:     func(m *MockPostTable) {},
+			expectedErr:   true,
+			expectedErrIs: ErrValidation,
 		},
 		{
 			name:    "post not found",
+			userID:  userID,
 			postID:  postID,
-			title:   "New Title",
-			content: "New Content",
+			title:   ptr("New Title"),
+			content: ptr("New Content"),
 			setupMock: func(m *MockPostTable) {
 				m.On("GetPostByID", mock.Anything, postID).Return(nil, ErrPostNotFound)
 			},
 			expectedErr: true,
 		},
+		{
+			name:    "post owned by another user",
+			userID:  uuid.New(),
+			postID:  postID,
+			title:   ptr("New Title"),
+			content: ptr("New Content"),
+			setupMock: func(m *MockPostTable) {
+				m.On("GetPostByID", mock.Anything, postID).Return(existingPost(), nil)
+			},
+			expectedErr:   true,
+			expectedErrIs: ErrForbidden,
+		},
 		{
 			name:    "table error on get",
+			userID:  userID,
 			postID:  postID,
-			title:   "New Title",
-			content: "New Content",
+			title:   ptr("New Title"),
+			content: ptr("New Content"),
 			setupMock: func(m *MockPostTable) {
 				m.On("GetPostByID", mock.Anything, postID).Return(nil, errors.New("table error"))
 			},
@@ -292,16 +522,19 @@ func TestService_UpdatePost(t *testing.T) {
 			tt.setupMock(mockTable)
 			service := NewService(mockTable)
 
-			post, err := service.UpdatePost(context.Background(), tt.postID, tt.title, tt.content)
+			post, err := service.UpdatePost(context.Background(), tt.userID, tt.postID, tt.title, tt.content)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
 				assert.Nil(t, post)
+				if tt.expectedErrIs != nil {
+					assert.ErrorIs(t, err, tt.expectedErrIs)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, post)
-				assert.Equal(t, tt.title, post.Title)
-				assert.Equal(t, tt.content, post.Content)
+				assert.Equal(t, tt.expectedTitle, post.Title)
+				assert.Equal(t, tt.expectedContent, post.Content)
 			}
 			mockTable.AssertExpectations(t)
 		})
@@ -312,34 +545,65 @@ func TestService_DeletePost(t *testing.T) {
 	t.Parallel()
 
 	postID := uuid.New()
+	userID := uuid.New()
+	post := &Post{ID: postID, UserID: userID, Title: "Title", Content: "Content"}
 
 	tests := []struct {
-		name        string
-		postID      uuid.UUID
-		setupMock   func(*MockPostTable)
-		expectedErr bool
+		name          string
+		userID        uuid.UUID
+		postID        uuid.UUID
+		setupMock     func(*MockPostTable)
+		expectedErr   bool
+		expectedErrIs error
 	}{
 		{
 			name:   "successful deletion",
+			userID: userID,
 			postID: postID,
 			setupMock: func(m *MockPostTable) {
-				m.On("DeletePost", mock.Anything, postID).Return(nil)
+				m.On("GetPostByID", mock.Anything, postID).Return(post, nil)
+				m.On("DeletePost", mock.Anything, post).Return(nil)
 			},
 			expectedErr: false,
 		},
 		{
 			name:   "post not found",
+			userID: userID,
 			postID: postID,
 			setupMock: func(m *MockPostTable) {
-				m.On("DeletePost", mock.Anything, postID).Return(ErrPostNotFound)
+				m.On("GetPostByID", mock.Anything, postID).Return(nil, ErrPostNotFound)
 			},
-			expectedErr: true,
+			expectedErr:   true,
+			expectedErrIs: ErrPostNotFound,
+		},
+		{
+			name:   "post owned by another user",
+			userID: uuid.New(),
+			postID: postID,
+			setupMock: func(m *MockPostTable) {
+				m.On("GetPostByID", mock.Anything, postID).Return(post, nil)
+			},
+			expectedErr:   true,
+			expectedErrIs: ErrForbidden,
+		},
+		{
+			name:   "post deleted after it was read",
+			userID: userID,
+			postID: postID,
+			setupMock: func(m *MockPostTable) {
+				m.On("GetPostByID", mock.Anything, postID).Return(post, nil)
+				m.On("DeletePost", mock.Anything, post).Return(ErrPostNotFound)
+			},
+			expectedErr:   true,
+			expectedErrIs: ErrPostNotFound,
 		},
 		{
 			name:   "table error",
+			userID: userID,
 			postID: postID,
 			setupMock: func(m *MockPostTable) {
-				m.On("DeletePost", mock.Anything, postID).Return(errors.New("table error"))
+				m.On("GetPostByID", mock.Anything, postID).Return(post, nil)
+				m.On("DeletePost", mock.Anything, post).Return(errors.New("table error"))
 			},
 			expectedErr: true,
 		},
@@ -351,10 +615,13 @@ func TestService_DeletePost(t *testing.T) {
 			tt.setupMock(mockTable)
 			service := NewService(mockTable)
 
-			err := service.DeletePost(context.Background(), tt.postID)
+			err := service.DeletePost(context.Background(), tt.userID, tt.postID)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
+				if tt.expectedErrIs != nil {
+					assert.ErrorIs(t, err, tt.expectedErrIs)
+				}
 			} else {
 				assert.NoError(t, err)
 			}
@@ -363,3 +630,257 @@ func TestService_DeletePost(t *testing.T) {
 	}
 }
 
+// readBarrierTable holds every GetPostByID until all expected reads have happened, so
+// concurrent deletes all pass the ownership check before any of them deletes
+type readBarrierTable struct {
+	*memoryPostTable
+	reads sync.WaitGroup
+}
+
+func (t *readBarrierTable) GetPostByID(ctx context.Context, postID uuid.UUID) (*Post, error) {
+	post, err := t.memoryPostTable.GetPostByID(ctx, postID)
+	t.reads.Done()
+	t.reads.Wait()
+	return post, err
+}
+
+func TestService_DeletePostConcurrent(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	post := &Post{ID: uuid.New(), UserID: userID, Title: "Title", Content: "Content"}
+	table := &readBarrierTable{memoryPostTable: newMemoryPostTable()}
+	require.NoError(t, table.PutPosts(context.Background(), []*Post{post}))
+	service := NewService(table)
+
+	const deletes = 5
+	table.reads.Add(deletes)
+	var wg sync.WaitGroup
+	errs := make(chan error, deletes)
+	for range deletes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- service.DeletePost(context.Background(), userID, post.ID)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// Every delete read the post, but only one removes it; the rest find it gone
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, ErrPostNotFound)
+	}
+	assert.Equal(t, 1, succeeded)
+
+	// Repeating the delete changes nothing and still reports the post as missing
+	table.reads.Add(1)
+	assert.ErrorIs(t, service.DeletePost(context.Background(), userID, post.ID), ErrPostNotFound)
+}
+
+func TestService_CreatePostsBatchWrites(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	inputs := []PostInput{{Title: "First", Content: "one"}, {Title: "Second", Content: "two"}}
+
+	tests := []struct {
+		name        string
+		setupMock   func(*MockPostTable)
+		expectedErr bool
+	}{
+		{
+			name: "writes the batch in one call",
+			setupMock: func(m *MockPostTable) {
+				m.On("PutPosts", mock.Anything, mock.MatchedBy(func(posts []*Post) bool {
+					return len(posts) == 2 && posts[0].Title == "First" && posts[1].Title == "Second" &&
+						posts[0].UserID == userID && posts[1].UserID == userID
+				})).Return(nil).Once()
+			},
+			expectedErr: false,
+		},
+		{
+			name: "table error fails every item in the batch",
+			setupMock: func(m *MockPostTable) {
+				m.On("PutPosts", mock.Anything, mock.Anything).Return(errors.New("table error")).Once()
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTable := NewMockPostTable(t)
+			tt.setupMock(mockTable)
+			service := NewService(mockTable)
+
+			results, err := service.CreatePosts(context.Background(), userID, inputs, false)
+			assert.NoError(t, err)
+			assert.Len(t, results, len(inputs))
+			for i, result := range results {
+				assert.Equal(t, i, result.Index)
+				if tt.expectedErr {
+					assert.Error(t, result.Err)
+					assert.Nil(t, result.Post)
+				} else {
+					assert.NoError(t, result.Err)
+					assert.Equal(t, inputs[i].Title, result.Post.Title)
+				}
+			}
+			mockTable.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_GetPostsByIDs(t *testing.T) {
+	t.Parallel()
+
+	first := Post{ID: uuid.New(), UserID: uuid.New(), Title: "First"}
+	second := Post{ID: uuid.New(), UserID: uuid.New(), Title: "Second"}
+	missingID := uuid.New()
+
+	tests := []struct {
+		name          string
+		postIDs       []uuid.UUID
+		setupMock     func(*MockPostTable)
+		expectedErrIs error
+		expectedErr   bool
+		expectedPosts []Post
+	}{
+		{
+			name:    "returns posts in request order",
+			postIDs: []uuid.UUID{second.ID, first.ID},
+			setupMock: func(m *MockPostTable) {
+				m.On("GetPostsByIDs", mock.Anything, []uuid.UUID{second.ID, first.ID}).Return([]Post{first, second}, nil)
+			},
+			expectedPosts: []Post{second, first},
+		},
+		{
+			name:    "skips missing and repeated IDs",
+			postIDs: []uuid.UUID{first.ID, missingID, first.ID},
+			setupMock: func(m *MockPostTable) {
+				m.On("GetPostsByIDs", mock.Anything, []uuid.UUID{first.ID, missingID, first.ID}).Return([]Post{first}, nil)
+			},
+			expectedPosts: []Post{first},
+		},
+		{
+			name:          "no IDs skips the table",
+			postIDs:       []uuid.UUID{},
+			setupMock:     func(m *MockPostTable) {},
+			expectedPosts: []Post{},
+		},
+		{
+			name:          "too many IDs",
+			postIDs:       make([]uuid.UUID, MaxBatchSize+1),
+			setupMock:     func(m *MockPostTable) {},
+			expectedErr:   true,
+			expectedErrIs: ErrBatchTooLarge,
+		},
+		{
+			name:    "table error",
+			postIDs: []uuid.UUID{first.ID},
+			setupMock: func(m *MockPostTable) {
+				m.On("GetPostsByIDs", mock.Anything, []uuid.UUID{first.ID}).Return(nil, errors.New("table error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTable := NewMockPostTable(t)
+			tt.setupMock(mockTable)
+			service := NewService(mockTable)
+
+			posts, err := service.GetPostsByIDs(context.Background(), tt.postIDs)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				if tt.expectedErrIs != nil {
+					assert.ErrorIs(t, err, tt.expectedErrIs)
+				}
+				assert.Nil(t, posts)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPosts, posts)
+			}
+			mockTable.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_SearchPosts(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	match := Post{ID: uuid.New(), UserID: userID, Title: "Hello world"}
+
+	tests := []struct {
+		name          string
+		query         string
+		setupMock     func(*MockPostTable)
+		expectedErrIs error
+		expectedErr   bool
+		expectedPosts []Post
+	}{
+		{
+			name:  "returns matching posts",
+			query: "hello",
+			setupMock: func(m *MockPostTable) {
+				m.On("SearchPostsByUserID", mock.Anything, userID, "hello").Return([]Post{match}, nil)
+			},
+			expectedPosts: []Post{match},
+		},
+		{
+			name:  "trims the query",
+			query: "  hello  ",
+			setupMock: func(m *MockPostTable) {
+				m.On("SearchPostsByUserID", mock.Anything, userID, "hello").Return([]Post{match}, nil)
+			},
+			expectedPosts: []Post{match},
+		},
+		{
+			name:          "blank query skips the table",
+			query:         "   ",
+			setupMock:     func(m *MockPostTable) {},
+			expectedErr:   true,
+			expectedErrIs: ErrEmptySearchQuery,
+		},
+		{
+			name:  "table error",
+			query: "hello",
+			setupMock: func(m *MockPostTable) {
+				m.On("SearchPostsByUserID", mock.Anything, userID, "hello").Return(nil, errors.New("table error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTable := NewMockPostTable(t)
+			tt.setupMock(mockTable)
+			service := NewService(mockTable)
+
+			posts, err := service.SearchPosts(context.Background(), userID, tt.query)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				if tt.expectedErrIs != nil {
+					assert.ErrorIs(t, err, tt.expectedErrIs)
+				}
+				assert.Nil(t, posts)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPosts, posts)
+			}
+			mockTable.AssertExpectations(t)
+		})
+	}
+}
+
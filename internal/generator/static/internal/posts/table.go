@@ -12,8 +12,18 @@ import (
 // This interface is implemented by both Postgres and DynamoDB table implementations
 type PostTable interface {
 	PutPost(ctx context.Context, post *Post) error
+	// PutPostIfNotExists inserts post, returning ErrPostExists if a post with its ID already exists
+	PutPostIfNotExists(ctx context.Context, post *Post) error
 	GetPostByID(ctx context.Context, postID uuid.UUID) (*Post, error)
 	ListPostsByUserID(ctx context.Context, userID uuid.UUID) ([]Post, error)
-	DeletePost(ctx context.Context, postID uuid.UUID) error
+	// DeletePost deletes post, a post previously read from the table, returning ErrPostNotFound
+	// if it is no longer there, as when a concurrent delete removed it first
+	DeletePost(ctx context.Context, post *Post) error
+	// PutPosts writes posts in bulk; callers pass at most MaxBatchSize posts
+	PutPosts(ctx context.Context, posts []*Post) error
+	// GetPostsByIDs returns the posts that exist among postIDs, in no particular order
+	GetPostsByIDs(ctx context.Context, postIDs []uuid.UUID) ([]Post, error)
+	// SearchPostsByUserID returns the user's posts whose title contains query, newest first
+	SearchPostsByUserID(ctx context.Context, userID uuid.UUID, query string) ([]Post, error)
 }
 
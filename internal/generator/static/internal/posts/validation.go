@@ -0,0 +1,88 @@
+package posts
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// Request body schemas, embedded so they are shared by the handlers and openapi.yaml
+//
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+const (
+	createPostRequestSchema = "schemas/create_post_request.json"
+	updatePostRequestSchema = "schemas/update_post_request.json"
+)
+
+var requestSchemas = mustCompileSchemas(createPostRequestSchema, updatePostRequestSchema)
+
+// mustCompileSchemas compiles the embedded schemas at startup; an invalid schema is a programming error
+func mustCompileSchemas(names ...string) map[string]*jsonschema.Schema {
+	compiler := jsonschema.NewCompiler()
+	schemas := make(map[string]*jsonschema.Schema, len(names))
+	for _, name := range names {
+		data, err := schemaFS.ReadFile(name)
+		if err != nil {
+			panic(fmt.Sprintf("read schema %s: %v", name, err))
+		}
+		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+		if err != nil {
+			panic(fmt.Sprintf("parse schema %s: %v", name, err))
+		}
+		if err := compiler.AddResource(name, doc); err != nil {
+			panic(fmt.Sprintf("add schema %s: %v", name, err))
+		}
+		schema, err := compiler.Compile(name)
+		if err != nil {
+			panic(fmt.Sprintf("compile schema %s: %v", name, err))
+		}
+		schemas[name] = schema
+	}
+	return schemas
+}
+
+// decodeRequest validates a JSON request body against the named schema and decodes it into v.
+// Validation failures are returned as a *ValidationError listing every invalid field.
+func decodeRequest(body io.Reader, schemaName string, v any) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("read request body: %w", err)
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("parse request body: %w", err)
+	}
+	if err := requestSchemas[schemaName].Validate(instance); err != nil {
+		validationErr, ok := err.(*jsonschema.ValidationError)
+		if !ok {
+			return err
+		}
+		return newValidationError(validationErr)
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// newValidationError flattens a schema validation error into one FieldError per failure
+func newValidationError(err *jsonschema.ValidationError) *ValidationError {
+	var fields []FieldError
+	for _, unit := range err.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		fields = append(fields, FieldError{
+			Field:   strings.TrimPrefix(unit.InstanceLocation, "/"),
+			Message: unit.Error.String(),
+		})
+	}
+	return &ValidationError{Fields: fields}
+}
+
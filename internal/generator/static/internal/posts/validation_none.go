@@ -0,0 +1,20 @@
+//go:build ignore
+
+package posts
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	createPostRequestSchema = "create_post_request"
+	updatePostRequestSchema = "update_post_request"
+)
+
+// decodeRequest decodes a JSON request body into v. Request validation is not enabled
+// (generate with --with-request-validation to validate bodies against JSON schemas).
+func decodeRequest(body io.Reader, schemaName string, v any) error {
+	return json.NewDecoder(body).Decode(v)
+}
+
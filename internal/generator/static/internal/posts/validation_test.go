@@ -0,0 +1,87 @@
+package posts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		schema         string
+		body           string
+		expectedFields []string // Fields with errors, empty when the body is valid
+	}{
+		{name: "valid create", schema: createPostRequestSchema, body: `{"title": "Hello", "content": "World"}`},
+		{name: "valid partial update", schema: updatePostRequestSchema, body: `{"title": "Hello"}`},
+		{
+			name:           "create reports every invalid field",
+			schema:         createPostRequestSchema,
+			body:           `{"title": "", "content": 42}`,
+			expectedFields: []string{"content", "title"},
+		},
+		{
+			name:           "create missing fields",
+			schema:         createPostRequestSchema,
+			body:           `{}`,
+			expectedFields: []string{""},
+		},
+		{
+			name:           "update with unknown field and oversized title",
+			schema:         updatePostRequestSchema,
+			body:           `{"title": "` + strings.Repeat("a", 201) + `", "author": "me"}`,
+			expectedFields: []string{"", "title"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var req CreatePostRequest
+			err := decodeRequest(strings.NewReader(tt.body), tt.schema, &req)
+			if len(tt.expectedFields) == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, "Hello", req.Title)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			var fields []string
+			for _, field := range validationErr.Fields {
+				assert.NotEmpty(t, field.Message)
+				fields = append(fields, field.Field)
+			}
+			assert.ElementsMatch(t, tt.expectedFields, fields)
+		})
+	}
+}
+
+func TestDecodeRequest_MalformedJSON(t *testing.T) {
+	t.Parallel()
+
+	err := decodeRequest(strings.NewReader(`{"title":`), createPostRequestSchema, &CreatePostRequest{})
+	require.Error(t, err)
+
+	var validationErr *ValidationError
+	assert.NotErrorAs(t, err, &validationErr)
+	assert.Empty(t, newRequestErrorResponse(err).Error.Details)
+}
+
+func TestNewRequestErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	err := decodeRequest(strings.NewReader(`{"title": "", "content": ""}`), createPostRequestSchema, &CreatePostRequest{})
+	resp := newRequestErrorResponse(err)
+
+	assert.Equal(t, CodeValidationFailed, resp.Error.Code)
+	assert.Equal(t, "Invalid request body", resp.Error.Message)
+	assert.Len(t, resp.Error.Details, 2, "both invalid fields should be reported at once")
+}
+
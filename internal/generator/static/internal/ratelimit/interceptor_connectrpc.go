@@ -0,0 +1,28 @@
+//go:build ignore
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"connectrpc.com/connect"
+)
+
+// Interceptor rejects RPCs from clients over the limit with CodeResourceExhausted and a
+// Retry-After header on the error
+func (l *Limiter) Interceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			allowed, retryAfter := l.allow(ctx, req.Peer().Addr, req.Header())
+			if !allowed {
+				err := connect.NewError(connect.CodeResourceExhausted, errors.New("too many requests, retry later"))
+				err.Meta().Set("Retry-After", strconv.Itoa(retryAfterSeconds(retryAfter)))
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
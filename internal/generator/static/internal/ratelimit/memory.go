@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps token buckets in process memory. Each instance limits clients on its
+// own, so with several instances a client gets the limit once per instance.
+type MemoryStore struct {
+	capacity float64
+	// rate is how many tokens are added back per second
+	rate float64
+	now  func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	window    time.Duration
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewMemoryStore creates a store allowing requests per window for each key
+func NewMemoryStore(requests int, window time.Duration) *MemoryStore {
+	return &MemoryStore{
+		capacity: float64(requests),
+		rate:     float64(requests) / window.Seconds(),
+		now:      time.Now,
+		buckets:  make(map[string]*bucket),
+		window:   window,
+	}
+}
+
+// Take removes a token from key's bucket, refilling it for the time since it was last used
+func (s *MemoryStore) Take(ctx context.Context, key string) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: s.capacity, last: now}
+		s.buckets[key] = b
+	}
+	b.tokens = min(s.capacity, b.tokens+now.Sub(b.last).Seconds()*s.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / s.rate * float64(time.Second))
+		return false, wait, nil
+	}
+	b.tokens--
+	return true, 0, nil
+}
+
+// sweep drops buckets idle long enough to have refilled, which behave like new ones, so
+// memory stays bounded by the clients seen in the last window. Runs at most once per window.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.window {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.Sub(b.last) >= s.window {
+			delete(s.buckets, key)
+		}
+	}
+}
+
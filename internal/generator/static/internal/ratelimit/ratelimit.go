@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Store tracks a token bucket per client key. Buckets hold up to the limit's requests and
+// refill completely over its window, so clients average requests per window with bursts.
+type Store interface {
+	// Take removes a token from key's bucket, reporting whether one was available and,
+	// if not, how long until the next one is
+	Take(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// KeyFunc identifies the client making a request from its remote address and headers
+type KeyFunc func(remoteAddr string, header http.Header) string
+
+// KeyByIP limits each client IP address. Behind a proxy the remote address is the proxy's
+// unless a middleware such as chi's RealIP has replaced it with the client's.
+func KeyByIP(remoteAddr string, header http.Header) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return "ip:" + remoteAddr
+	}
+	return "ip:" + host
+}
+
+// KeyByUserID limits each X-User-ID, falling back to the client IP for requests without one.
+// The header is sent by clients, so this only separates well-behaved users.
+func KeyByUserID(remoteAddr string, header http.Header) string {
+	if userID := header.Get("X-User-ID"); userID != "" {
+		return "user:" + userID
+	}
+	return KeyByIP(remoteAddr, header)
+}
+
+// Limiter rejects requests from clients that have used up their token bucket
+type Limiter struct {
+	store       Store
+	key         KeyFunc
+	exemptPaths map[string]bool
+}
+
+// Option configures optional limiter behavior
+type Option func(*Limiter)
+
+// WithKeyFunc sets how clients are identified (KeyByIP by default)
+func WithKeyFunc(key KeyFunc) Option {
+	return func(l *Limiter) {
+		l.key = key
+	}
+}
+
+// WithExemptPaths excludes paths (health checks, readiness, metrics) from rate limiting
+func WithExemptPaths(paths ...string) Option {
+	return func(l *Limiter) {
+		for _, path := range paths {
+			l.exemptPaths[path] = true
+		}
+	}
+}
+
+// New creates a limiter backed by store
+func New(store Store, opts ...Option) *Limiter {
+	l := &Limiter{
+		store:       store,
+		key:         KeyByIP,
+		exemptPaths: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// allow takes a token for the client, reporting false if its bucket is empty. Store errors
+// are logged and the request allowed, so an unavailable store doesn't take the API down with it.
+func (l *Limiter) allow(ctx context.Context, remoteAddr string, header http.Header) (bool, time.Duration) {
+	allowed, retryAfter, err := l.store.Take(ctx, l.key(remoteAddr, header))
+	if err != nil {
+		slog.ErrorContext(ctx, "rate limit store failed, allowing request", "error", err)
+		return true, 0
+	}
+	return allowed, retryAfter
+}
+
+// Middleware rejects requests from clients over the limit with 429 and a Retry-After header
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if l.exemptPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		allowed, retryAfter := l.allow(r.Context(), r.RemoteAddr, r.Header)
+		if !allowed {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(retryAfter)))
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(map[string]map[string]string{"error": {"code": "RATE_LIMITED", "message": "Too many requests, retry later"}})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// retryAfterSeconds rounds a wait up to whole seconds, as Retry-After requires, and never below 1
+func retryAfterSeconds(retryAfter time.Duration) int {
+	return max(1, int(math.Ceil(retryAfter.Seconds())))
+}
+
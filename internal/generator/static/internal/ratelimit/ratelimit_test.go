@@ -0,0 +1,139 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore returns a memory store whose clock only moves when advanced
+func newTestStore(requests int, window time.Duration) (*MemoryStore, func(time.Duration)) {
+	store := NewMemoryStore(requests, window)
+	now := time.Unix(1_700_000_000, 0)
+	store.now = func() time.Time { return now }
+	return store, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, advance := newTestStore(10, time.Minute)
+
+	for i := range 10 {
+		allowed, _, err := store.Take(ctx, "client")
+		require.NoError(t, err)
+		assert.True(t, allowed, "request %d should be allowed", i+1)
+	}
+	allowed, retryAfter, err := store.Take(ctx, "client")
+	require.NoError(t, err)
+	assert.False(t, allowed, "the 11th request in a window should be limited")
+	assert.Equal(t, 6*time.Second, retryAfter, "a token is added back every window/requests")
+
+	// Other clients have their own buckets
+	allowed, _, err = store.Take(ctx, "other")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// One token refills after window/requests
+	advance(6 * time.Second)
+	allowed, _, _ = store.Take(ctx, "client")
+	assert.True(t, allowed)
+	allowed, _, _ = store.Take(ctx, "client")
+	assert.False(t, allowed)
+}
+
+func TestMemoryStore_SweepsIdleBuckets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, advance := newTestStore(1, time.Minute)
+
+	store.Take(ctx, "idle")
+	advance(time.Minute)
+	store.Take(ctx, "active")
+
+	assert.NotContains(t, store.buckets, "idle")
+	assert.Contains(t, store.buckets, "active")
+}
+
+func TestLimiter_Middleware(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestStore(10, time.Minute)
+	limiter := New(store, WithExemptPaths("/health"))
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for range 10 {
+		assert.Equal(t, http.StatusOK, serve("/posts").Code)
+	}
+	rec := serve("/posts")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "6", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":{"code":"RATE_LIMITED","message":"Too many requests, retry later"}}`, rec.Body.String())
+
+	// Exempt paths are never limited
+	assert.Equal(t, http.StatusOK, serve("/health").Code)
+}
+
+// failingStore always fails, as an unreachable Redis would
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string) (bool, time.Duration, error) {
+	return false, 0, errors.New("store unavailable")
+}
+
+func TestLimiter_FailsOpen(t *testing.T) {
+	t.Parallel()
+
+	handler := New(failingStore{}).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestKeyFuncs(t *testing.T) {
+	t.Parallel()
+
+	withUser := http.Header{"X-User-Id": []string{"user-1"}}
+
+	tests := []struct {
+		name     string
+		key      KeyFunc
+		addr     string
+		header   http.Header
+		expected string
+	}{
+		{name: "ip drops the port", key: KeyByIP, addr: "192.0.2.1:1234", expected: "ip:192.0.2.1"},
+		{name: "ip without port", key: KeyByIP, addr: "192.0.2.1", expected: "ip:192.0.2.1"},
+		{name: "ip ignores user header", key: KeyByIP, addr: "192.0.2.1:1234", header: withUser, expected: "ip:192.0.2.1"},
+		{name: "user header", key: KeyByUserID, addr: "192.0.2.1:1234", header: withUser, expected: "user:user-1"},
+		{name: "user falls back to ip", key: KeyByUserID, addr: "[2001:db8::1]:1234", header: http.Header{}, expected: "ip:2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.key(tt.addr, tt.header))
+		})
+	}
+}
+
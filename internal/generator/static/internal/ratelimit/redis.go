@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript is the token bucket of MemoryStore run atomically in Redis, so every instance
+// shares one bucket per key. It uses the Redis clock so instances with skewed clocks agree.
+// KEYS[1] is the bucket; ARGV are the capacity, tokens added per millisecond and the TTL in
+// milliseconds. Returns {allowed, milliseconds until the next token}.
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'last')
+local tokens = tonumber(state[1]) or capacity
+local last = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - last) * rate)
+
+local allowed, wait = 0, 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'last', now)
+redis.call('PEXPIRE', KEYS[1], ttl)
+return {allowed, wait}
+`)
+
+// RedisStore keeps token buckets in Redis, so the limit applies across every instance
+type RedisStore struct {
+	client   redis.Scripter
+	prefix   string
+	capacity int
+	// rate is how many tokens are added back per millisecond
+	rate   float64
+	window time.Duration
+}
+
+// NewRedisStore creates a store allowing requests per window for each key. Buckets are
+// stored under "ratelimit:<key>" and expire once idle for a window.
+func NewRedisStore(client redis.Scripter, requests int, window time.Duration) *RedisStore {
+	return &RedisStore{
+		client:   client,
+		prefix:   "ratelimit:",
+		capacity: requests,
+		rate:     float64(requests) / float64(window.Milliseconds()),
+		window:   window,
+	}
+}
+
+// NewRedisClient connects to the Redis server at url (e.g. redis://localhost:6379/0)
+func NewRedisClient(ctx context.Context, url string) (*redis.Client, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis URL: %w", err)
+	}
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+	return client, nil
+}
+
+// Take removes a token from key's bucket
+func (s *RedisStore) Take(ctx context.Context, key string) (bool, time.Duration, error) {
+	result, err := takeScript.Run(ctx, s.client, []string{s.prefix + key}, s.capacity, s.rate, s.window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
+
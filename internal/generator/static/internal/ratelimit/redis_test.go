@@ -0,0 +1,63 @@
+//go:build integration
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestRedisStore_Take(t *testing.T) {
+	ctx := context.Background()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "redis:7-alpine",
+		ExposedPorts: []string{"6379/tcp"},
+		WaitingFor:   wait.ForLog("Ready to accept connections").WithStartupTimeout(30 * time.Second),
+	}
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { container.Terminate(context.Background()) })
+
+	endpoint, err := container.PortEndpoint(ctx, "6379/tcp", "redis")
+	require.NoError(t, err)
+	client, err := NewRedisClient(ctx, endpoint)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	// Two stores sharing the server behave like two instances sharing one limit
+	first := NewRedisStore(client, 10, time.Minute)
+	second := NewRedisStore(client, 10, time.Minute)
+	for i := range 10 {
+		store := first
+		if i%2 == 1 {
+			store = second
+		}
+		allowed, _, err := store.Take(ctx, "client")
+		require.NoError(t, err)
+		assert.True(t, allowed, "request %d should be allowed", i+1)
+	}
+
+	allowed, retryAfter, err := first.Take(ctx, "client")
+	require.NoError(t, err)
+	assert.False(t, allowed, "the 11th request in a window should be limited")
+	assert.InDelta(t, 6*time.Second, retryAfter, float64(time.Second))
+
+	allowed, _, err = second.Take(ctx, "other")
+	require.NoError(t, err)
+	assert.True(t, allowed, "other clients have their own buckets")
+
+	ttl, err := client.PTTL(ctx, "ratelimit:client").Result()
+	require.NoError(t, err)
+	assert.Positive(t, ttl, "idle buckets should expire")
+}
+
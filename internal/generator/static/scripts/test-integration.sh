@@ -0,0 +1,14 @@
+#!/bin/bash
+set -e
+
+# Run the integration tests (build tag "integration"). They start database containers
+# with testcontainers, so a running Docker daemon is required.
+if ! docker info >/dev/null 2>&1; then
+    echo "Error: Docker is not running. Integration tests start containers with testcontainers."
+    exit 1
+fi
+
+echo "Running integration tests..."
+go test -tags integration -count=1 "$@" ./...
+
+echo "✓ Integration tests passed"
@@ -2,7 +2,11 @@ package generator
 
 import (
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"text/template"
 )
 
@@ -48,10 +52,42 @@ func (l *EmbeddedTemplateLoader) LoadTemplate(path string) (*template.Template,
 	}
 
 	// Parse template
-	tmpl, err := template.New(path).Parse(string(data))
+	tmpl, err := template.New(path).Funcs(templateFuncs).Parse(string(data))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
 	}
 
 	return tmpl, nil
 }
+
+// DirTemplateLoader loads templates from a user directory, falling back to another
+// loader (normally the embedded templates) for any template the directory doesn't have.
+// Overrides are matched by the same relative path as the embedded templates, so
+// <dir>/templates/Makefile.tmpl replaces the built-in Makefile template.
+type DirTemplateLoader struct {
+	dir      string
+	fallback TemplateLoader
+}
+
+func NewDirTemplateLoader(dir string, fallback TemplateLoader) *DirTemplateLoader {
+	return &DirTemplateLoader{dir: dir, fallback: fallback}
+}
+
+func (l *DirTemplateLoader) LoadTemplate(path string) (*template.Template, error) {
+	overridePath := filepath.Join(l.dir, filepath.FromSlash(path))
+	data, err := os.ReadFile(overridePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l.fallback.LoadTemplate(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template override %s: %w", overridePath, err)
+	}
+
+	tmpl, err := template.New(path).Funcs(templateFuncs).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template override %s: %w", overridePath, err)
+	}
+
+	return tmpl, nil
+}
+
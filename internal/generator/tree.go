@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// treeNode is a directory or file in a rendered tree
+type treeNode struct {
+	name     string
+	dir      bool
+	children map[string]*treeNode
+}
+
+// FormatTree renders files and dirs (slash-separated paths relative to root) as an
+// indented tree like the tree command. Directories are suffixed with "/" and appear
+// even when they contain no files.
+func FormatTree(root string, files, dirs []string) string {
+	top := &treeNode{name: root, dir: true, children: make(map[string]*treeNode)}
+	for _, dir := range dirs {
+		top.add(dir, true)
+	}
+	for _, file := range files {
+		top.add(file, false)
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.TrimSuffix(root, "/") + "/\n")
+	top.write(&b, "")
+	return b.String()
+}
+
+// add inserts p below n, creating any missing parent directories
+func (n *treeNode) add(p string, dir bool) {
+	p = path.Clean(p)
+	if p == "." || p == "" {
+		return
+	}
+
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		child, ok := n.children[part]
+		if !ok {
+			child = &treeNode{name: part, children: make(map[string]*treeNode)}
+			n.children[part] = child
+		}
+		if dir || i < len(parts)-1 {
+			child.dir = true
+		}
+		n = child
+	}
+}
+
+// write renders the children of n in name order
+func (n *treeNode) write(b *strings.Builder, prefix string) {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, name := range names {
+		child := n.children[name]
+		connector, indent := "├── ", "│   "
+		if i == len(names)-1 {
+			connector, indent = "└── ", "    "
+		}
+
+		b.WriteString(prefix + connector + child.name)
+		if child.dir {
+			b.WriteString("/")
+		}
+		b.WriteString("\n")
+		child.write(b, prefix+indent)
+	}
+}
+
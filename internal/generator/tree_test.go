@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatTree(t *testing.T) {
+	t.Parallel()
+
+	files := []string{"go.mod", "cmd/api/main.go", "internal/posts/post.go", "internal/posts/service.go", ".env"}
+	dirs := []string{"cmd/api", "internal/posts", "internal/metrics", "migrations"}
+
+	expected := `demo/
+├── .env
+├── cmd/
+│   └── api/
+│       └── main.go
+├── go.mod
+├── internal/
+│   ├── metrics/
+│   └── posts/
+│       ├── post.go
+│       └── service.go
+└── migrations/
+`
+	assert.Equal(t, expected, FormatTree("demo", files, dirs))
+}
+
+func TestGenerator_DryRunDirs(t *testing.T) {
+	t.Parallel()
+
+	gen := NewGenerator(testProjectConfig(), WithDryRun())
+	require.NoError(t, gen.Generate())
+
+	dirs := gen.DryRunDirs()
+	// Created by createDirectoryStructure even though no files are generated into them
+	assert.Contains(t, dirs, "internal/metrics")
+	assert.Contains(t, dirs, "migrations")
+	assert.NotContains(t, dirs, ".")
+
+	tree := FormatTree("out", nil, dirs)
+	assert.Contains(t, tree, "├── migrations/\n")
+}
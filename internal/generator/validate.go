@@ -0,0 +1,204 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var projectNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// isValidProjectName reports whether name is usable as a directory name and module path
+// element: lowercase letters, digits, '-' and '_', starting with a letter
+func isValidProjectName(name string) bool {
+	return projectNamePattern.MatchString(name)
+}
+
+// ValidateProjectName checks that name is a valid project name (see isValidProjectName)
+func ValidateProjectName(name string) error {
+	if name == "" {
+		return errors.New("project name is required")
+	}
+	if !isValidProjectName(name) {
+		return fmt.Errorf("invalid project name %q: use lowercase, no spaces (letters, digits, '-' or '_', starting with a letter)", name)
+	}
+	return nil
+}
+
+// ValidateOutputDir checks that files can be created in dir, or in its nearest existing
+// parent when dir doesn't exist yet, by creating and removing a temporary file there
+func ValidateOutputDir(dir string) error {
+	if dir == "" {
+		return errors.New("output directory is required")
+	}
+
+	existing := filepath.Clean(dir)
+	for {
+		info, err := os.Stat(existing)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("invalid output directory %s: %s is not a directory", dir, existing)
+			}
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("invalid output directory %s: %w", dir, err)
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return fmt.Errorf("invalid output directory %s: %w", dir, err)
+		}
+		existing = parent
+	}
+
+	file, err := os.CreateTemp(existing, writeCheckFile+"-*")
+	if err != nil {
+		return fmt.Errorf("output directory %s is not writable: %w", dir, err)
+	}
+	_ = file.Close()
+	return os.Remove(file.Name())
+}
+
+// ValidateModulePath checks that path can be used as the module path in go.mod
+// (e.g. github.com/user/service). It rejects whitespace, empty or dot-only path
+// elements, a host without a path, and characters not allowed in Go module paths.
+func ValidateModulePath(path string) error {
+	if path == "" {
+		return errors.New("module path is required")
+	}
+	if strings.ContainsAny(path, " \t\r\n") {
+		return fmt.Errorf("invalid module path %q: must not contain spaces", path)
+	}
+
+	elements := strings.Split(path, "/")
+	for i, elem := range elements {
+		if elem == "" {
+			return fmt.Errorf("invalid module path %q: missing path element (check for leading, trailing or repeated slashes)", path)
+		}
+		if strings.HasPrefix(elem, ".") || strings.HasSuffix(elem, ".") {
+			return fmt.Errorf("invalid module path %q: path element %q must not start or end with a dot", path, elem)
+		}
+		host := i == 0 && strings.Contains(elem, ".")
+		for _, r := range elem {
+			if !isModulePathChar(r, host) {
+				return fmt.Errorf("invalid module path %q: invalid character %q in %q", path, r, elem)
+			}
+		}
+	}
+
+	// A host such as github.com must be followed by a path
+	if strings.Contains(elements[0], ".") && len(elements) == 1 {
+		return fmt.Errorf("invalid module path %q: missing path after host %s", path, elements[0])
+	}
+
+	return nil
+}
+
+var apiPrefixPattern = regexp.MustCompile(`^(/[A-Za-z0-9._~-]+)+$`)
+
+// ValidateAPIPrefix checks that prefix can be used as the path prefix for the API routes
+// (e.g. /api/v1): it must start with a slash, have no trailing or repeated slashes, and
+// contain only unreserved URL characters. An empty prefix (no prefix) is valid.
+func ValidateAPIPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if !apiPrefixPattern.MatchString(prefix) {
+		return fmt.Errorf("invalid API prefix %q: must look like /api/v1 (leading slash, no trailing slash, letters, digits, '-', '_', '.' or '~')", prefix)
+	}
+	for _, segment := range strings.Split(prefix, "/")[1:] {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("invalid API prefix %q: must not contain . or .. segments", prefix)
+		}
+	}
+	return nil
+}
+
+// ValidateGoVersion checks that goVersion is a Go release version such as 1.25 or 1.25.4,
+// no older than MinGoVersion. An empty version (the generator's toolchain) is valid.
+func ValidateGoVersion(goVersion string) error {
+	if goVersion == "" {
+		return nil
+	}
+	if !goVersionPattern.MatchString(goVersion) {
+		return fmt.Errorf("invalid Go version %q: must look like 1.25 or 1.25.4", goVersion)
+	}
+	if !goVersionAtLeast(goVersion, MinGoVersion) {
+		return fmt.Errorf("invalid Go version %q: generated projects require Go %s or later", goVersion, MinGoVersion)
+	}
+	return nil
+}
+
+// ValidateFlyRegion checks that region is a known Fly.io region code (see FlyRegions).
+// An empty region (inferred from the AWS region, or iad) is valid.
+func ValidateFlyRegion(region string) error {
+	if region == "" || slices.Contains(FlyRegions, region) {
+		return nil
+	}
+	return fmt.Errorf("invalid Fly.io region %q (must be one of: %s)", region, strings.Join(FlyRegions, ", "))
+}
+
+// ValidatePort checks that port is a TCP port number the server can listen on (1-65535).
+// An empty port (DefaultPort) is valid.
+func ValidatePort(port string) error {
+	if port == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 || strconv.Itoa(n) != port {
+		return fmt.Errorf("invalid port %q: must be a number from 1 to 65535", port)
+	}
+	return nil
+}
+
+// validateAPIPrefix checks the configured API prefix. ConnectRPC procedures are already
+// versioned by their proto package (posts.v1), and gRPC clients can't use a path prefix.
+func (g *Generator) validateAPIPrefix() error {
+	if err := ValidateAPIPrefix(g.config.APIPrefix); err != nil {
+		return err
+	}
+	if g.config.APIPrefix != "" && g.config.Framework == FrameworkTypeConnectRPC {
+		return fmt.Errorf("an API prefix requires a REST framework (chi, gin, echo); ConnectRPC procedures are versioned by their proto package")
+	}
+	return nil
+}
+
+// validateGRPC checks that dual-mode REST and ConnectRPC serving is only requested for chi,
+// whose router the Connect handlers are mounted on
+func (g *Generator) validateGRPC() error {
+	if g.config.GRPC && g.config.Framework != FrameworkTypeChi {
+		return fmt.Errorf("serving gRPC alongside REST requires the chi framework (ConnectRPC projects already serve gRPC)")
+	}
+	return nil
+}
+
+// validateMinimal checks that a minimal project isn't also asked for deployment files,
+// which --minimal leaves out along with CI
+func (g *Generator) validateMinimal() error {
+	if g.config.Minimal && g.config.Deploy {
+		return fmt.Errorf("a minimal project has no deployment files; drop --minimal or --deploy")
+	}
+	return nil
+}
+
+// isModulePathChar reports whether r may appear in a module path element.
+// Hosts (e.g. github.com) are restricted to lowercase letters, digits, '-' and '.'.
+func isModulePathChar(r rune, host bool) bool {
+	switch {
+	case 'a' <= r && r <= 'z', '0' <= r && r <= '9', r == '-', r == '.':
+		return true
+	case host:
+		return false
+	case 'A' <= r && r <= 'Z', r == '_', r == '~':
+		return true
+	default:
+		return false
+	}
+}
+
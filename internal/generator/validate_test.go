@@ -0,0 +1,218 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateModulePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		path        string
+		expectedErr string
+	}{
+		{name: "github module", path: "github.com/user/postservice"},
+		{name: "nested module", path: "gitlab.com/org/team/post-service_v2"},
+		{name: "local module name", path: "postservice"},
+		{name: "empty", path: "", expectedErr: "module path is required"},
+		{name: "spaces", path: "my project", expectedErr: "must not contain spaces"},
+		{name: "trailing slash", path: "github.com/user/", expectedErr: "missing path element"},
+		{name: "leading slash", path: "/github.com/user/service", expectedErr: "missing path element"},
+		{name: "repeated slash", path: "github.com//service", expectedErr: "missing path element"},
+		{name: "host only", path: "github.com", expectedErr: "missing path after host"},
+		{name: "dot element", path: "github.com/user/../service", expectedErr: "must not start or end with a dot"},
+		{name: "uppercase host", path: "GitHub.com/user/service", expectedErr: "invalid character"},
+		{name: "invalid character", path: "github.com/user/serv!ce", expectedErr: "invalid character"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateModulePath(tt.path)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateAPIPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		prefix      string
+		expectedErr string
+	}{
+		{name: "no prefix", prefix: ""},
+		{name: "versioned prefix", prefix: "/api/v1"},
+		{name: "single segment", prefix: "/v2"},
+		{name: "missing leading slash", prefix: "api/v1", expectedErr: "must look like /api/v1"},
+		{name: "trailing slash", prefix: "/api/v1/", expectedErr: "must look like /api/v1"},
+		{name: "root only", prefix: "/", expectedErr: "must look like /api/v1"},
+		{name: "repeated slash", prefix: "/api//v1", expectedErr: "must look like /api/v1"},
+		{name: "route parameter", prefix: "/api/{version}", expectedErr: "must look like /api/v1"},
+		{name: "query string", prefix: "/api?v=1", expectedErr: "must look like /api/v1"},
+		{name: "dot segment", prefix: "/api/../v1", expectedErr: "must not contain . or .. segments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateAPIPrefix(tt.prefix)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatePort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		port        string
+		expectedErr string
+	}{
+		{name: "default", port: ""},
+		{name: "common port", port: "8080"},
+		{name: "lowest port", port: "1"},
+		{name: "highest port", port: "65535"},
+		{name: "zero", port: "0", expectedErr: "invalid port"},
+		{name: "out of range", port: "65536", expectedErr: "invalid port"},
+		{name: "leading zero", port: "08080", expectedErr: "invalid port"},
+		{name: "negative", port: "-1", expectedErr: "invalid port"},
+		{name: "service name", port: "http", expectedErr: "invalid port"},
+		{name: "address", port: ":8080", expectedErr: "invalid port"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidatePort(tt.port)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateGoVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		version     string
+		expectedErr string
+	}{
+		{name: "default", version: ""},
+		{name: "minor release", version: "1.25"},
+		{name: "patch release", version: "1.25.4"},
+		{name: "newer release", version: "1.26.1"},
+		{name: "go prefix", version: "go1.25", expectedErr: "must look like 1.25"},
+		{name: "pre-release", version: "1.26rc1", expectedErr: "must look like 1.25"},
+		{name: "major only", version: "1", expectedErr: "must look like 1.25"},
+		{name: "too old", version: "1.24.3", expectedErr: "require Go " + MinGoVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateGoVersion(tt.version)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIsValidProjectName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{name: "my-api", expected: true},
+		{name: "postservice", expected: true},
+		{name: "post_service2", expected: true},
+		{name: "MyAPI", expected: false},
+		{name: "1service", expected: false},
+		{name: "post service", expected: false},
+		{name: "-api", expected: false},
+		{name: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, isValidProjectName(tt.name))
+		})
+	}
+}
+
+func TestValidateOutputDir(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "file.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("data"), 0644))
+	readOnlyDir := filepath.Join(tempDir, "readonly")
+	require.NoError(t, os.Mkdir(readOnlyDir, 0555))
+
+	tests := []struct {
+		name        string
+		dir         string
+		expectedErr string
+		skipAsRoot  bool
+	}{
+		{name: "existing directory", dir: tempDir},
+		{name: "missing directory under a writable parent", dir: filepath.Join(tempDir, "missing", "service")},
+		{name: "empty", dir: "", expectedErr: "output directory is required"},
+		{name: "file", dir: filePath, expectedErr: "is not a directory"},
+		{name: "under a file", dir: filepath.Join(filePath, "service"), expectedErr: "not a directory"},
+		{name: "read-only directory", dir: filepath.Join(readOnlyDir, "service"), expectedErr: "is not writable", skipAsRoot: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if tt.skipAsRoot && os.Geteuid() == 0 {
+				t.Skip("root ignores directory permissions")
+			}
+
+			err := ValidateOutputDir(tt.dir)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			entries, err := os.ReadDir(tempDir)
+			require.NoError(t, err)
+			for _, entry := range entries {
+				assert.NotContains(t, entry.Name(), writeCheckFile, "temporary file left behind")
+			}
+		})
+	}
+}
+
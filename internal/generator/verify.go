@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Verify compiles the generated project to catch template errors at generation time
+// instead of at the user's first build. ConnectRPC projects require buf to generate
+// the protobuf code before building.
+func (g *Generator) Verify(ctx context.Context) error {
+	if g.memFS != nil {
+		return fmt.Errorf("cannot verify a dry-run generation")
+	}
+
+	if _, err := exec.LookPath("go"); err != nil {
+		return fmt.Errorf("go toolchain not found in PATH: %w", err)
+	}
+
+	if g.hasConnectRPC() {
+		if _, err := exec.LookPath("buf"); err != nil {
+			return fmt.Errorf("buf is required to verify ConnectRPC projects. Install from https://buf.build/docs/installation")
+		}
+		if err := g.runInOutputDir(ctx, "buf", "generate"); err != nil {
+			return fmt.Errorf("verification failed: %w", err)
+		}
+	}
+
+	// -mod=mod resolves dependencies and writes go.sum without requiring a prior go mod tidy
+	if err := g.runInOutputDir(ctx, "go", "build", "-mod=mod", "./..."); err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+	return nil
+}
+
+// runInOutputDir runs a command in the generated project directory
+func (g *Generator) runInOutputDir(ctx context.Context, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = g.config.OutputDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s: %w\nOutput: %s", name, strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
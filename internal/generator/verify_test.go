@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_Verify(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generated project build in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not found in PATH")
+	}
+
+	for _, db := range []DatabaseType{DatabaseTypePostgres, DatabaseTypeDynamoDB} {
+		for _, fw := range []FrameworkType{FrameworkTypeChi, FrameworkTypeGin, FrameworkTypeEcho, FrameworkTypeConnectRPC} {
+			t.Run(fmt.Sprintf("%s_%s", db, fw), func(t *testing.T) {
+				if fw == FrameworkTypeConnectRPC {
+					if _, err := exec.LookPath("buf"); err != nil {
+						t.Skip("buf not found in PATH")
+					}
+				}
+
+				cfg := testProjectConfig()
+				cfg.OutputDir = filepath.Join(t.TempDir(), cfg.ProjectName)
+				cfg.Database.Type = db
+				cfg.Framework = fw
+				cfg.Deploy = true
+
+				gen := NewGenerator(cfg)
+				require.NoError(t, gen.Generate())
+				require.NoError(t, gen.Verify(context.Background()))
+			})
+		}
+	}
+
+	for _, fw := range []FrameworkType{FrameworkTypeChi, FrameworkTypeGin, FrameworkTypeEcho} {
+		t.Run(fmt.Sprintf("flat_%s", fw), func(t *testing.T) {
+			cfg := testProjectConfig()
+			cfg.OutputDir = filepath.Join(t.TempDir(), cfg.ProjectName)
+			cfg.Database.Type = DatabaseTypeDynamoDB
+			cfg.Framework = fw
+			cfg.Layout = LayoutTypeFlat
+
+			gen := NewGenerator(cfg)
+			require.NoError(t, gen.Generate())
+			require.NoError(t, gen.Verify(context.Background()))
+		})
+	}
+}
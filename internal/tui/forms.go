@@ -24,7 +24,9 @@ type textInputModel struct {
 	label     string
 	value     string
 	focused   bool
-	sensitive bool // If true, redact the value in display
+	sensitive bool               // If true, redact the value in display
+	validate  func(string) error // Optional, checked by Valid before advancing
+	err       error              // Validation error shown below the input
 }
 
 func newTextInput(label, placeholder string) textInputModel {
@@ -51,16 +53,35 @@ func newTextInputWithSensitivity(label, placeholder string, sensitive bool) text
 	}
 }
 
+// withValidation sets a validator that must pass before the input is accepted
+func (m textInputModel) withValidation(validate func(string) error) textInputModel {
+	m.validate = validate
+	return m
+}
+
 func (m textInputModel) Update(msg tea.Msg) (textInputModel, tea.Cmd) {
 	var cmd tea.Cmd
 	m.textinput, cmd = m.textinput.Update(msg)
-	m.value = m.textinput.Value()
+	if value := m.textinput.Value(); value != m.value {
+		m.err = nil // Clear a stale error once the user edits the value
+		m.value = value
+	}
 	return m, cmd
 }
 
+// Valid runs the validator (if any) against the current value, recording any error for display
+func (m *textInputModel) Valid() bool {
+	m.err = nil
+	if m.validate != nil {
+		m.err = m.validate(m.value)
+	}
+	return m.err == nil
+}
+
 func (m *textInputModel) SetValue(value string) {
 	m.textinput.SetValue(value)
 	m.value = value
+	m.err = nil
 }
 
 // maskString masks a string for display (redacts all but last 4 characters)
@@ -98,6 +119,16 @@ func (m textInputModel) View() string {
 		inputView = m.textinput.View()
 	}
 	
+	if m.err != nil {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			labelStyle.Render(m.label),
+			"",
+			inputView,
+			"",
+			errorStyle.Render("✗ "+m.err.Error()),
+		)
+	}
+
 	return lipgloss.JoinVertical(lipgloss.Left,
 		labelStyle.Render(m.label),
 		"",
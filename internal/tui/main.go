@@ -1,10 +1,15 @@
 package tui
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/anmho/create-go-api/internal/generator"
@@ -19,9 +24,22 @@ type App struct {
 	model *Model
 }
 
-func NewApp() *App {
+// Options configures optional TUI behavior set from command-line flags
+type Options struct {
+	Verify  bool // Build the generated project after generation
+	GitInit bool   // Preselect initializing a git repository with an initial commit
+	Port    string // Server port to suggest, from --port
+}
+
+func NewApp(opts Options) *App {
+	model := NewModel()
+	model.opts = opts
+	model.gitInit = newConfirmWithDefault(model.gitInit.label, opts.GitInit)
+	if opts.Port != "" {
+		model.port.SetValue(opts.Port)
+	}
 	return &App{
-		model: NewModel(),
+		model: model,
 	}
 }
 
@@ -46,12 +64,25 @@ type Model struct {
 	awsRegion       textInputModel
 	awsProfileName  string
 	frameworkSelect singleSelectModel
+	port            textInputModel
 	deployConfirm   confirmModel
+	saveConfig      confirmModel // Whether to save the selections to a config file
+	gitInit         confirmModel // Whether to make the project a git repository with an initial commit
+	flyRegion       textInputModel
+	overwriteConfirm confirmModel
 	spinner       spinner.Model
 	err           error
 	generating    bool
 	deploying     bool
-	deployEnabled bool
+	deployedURL   string // Public URL of the app after a successful deploy
+	warning       string // Non-fatal problem after generation, e.g. git not being installed
+	deployMsgs    <-chan tea.Msg // Log lines and the final result of a running deploy
+	deployLog     []string
+	opts          Options
+	// cancelGeneration stops a running generation; canceling is set once the user asked to
+	// quit during it, so the TUI exits when the generation has rolled back
+	cancelGeneration context.CancelFunc
+	canceling        bool
 }
 
 type Step int
@@ -67,8 +98,13 @@ const (
 	StepAWSSecretKey
 	StepAWSRegion
 	StepFrameworkSelection
+	StepPort
 	StepDeploySelection
+	StepFlyRegion
 	StepReview
+	StepSaveConfig
+	StepGitInit
+	StepOverwriteConfirm
 	StepGenerating
 	StepComplete
 )
@@ -82,6 +118,8 @@ func NewModel() *Model {
 	frameworkOptions := []list.Item{
 		listItem{title: "ConnectRPC", description: "gRPC-compatible framework"},
 		listItem{title: "Chi", description: "Lightweight HTTP router"},
+		listItem{title: "Gin", description: "High-performance HTTP web framework"},
+		listItem{title: "Echo", description: "Minimalist HTTP web framework"},
 	}
 
 	s := spinner.New()
@@ -99,18 +137,25 @@ func NewModel() *Model {
 		}
 	}
 
+	port := newTextInput("Server port:", generator.DefaultPort).withValidation(generator.ValidatePort)
+	port.SetValue(generator.DefaultPort)
+
 	return &Model{
 		step:            StepWelcome,
-		projectName:     newTextInput("Project name:", "postservice"),
-		modulePath:      newTextInput("Go module path:", "github.com/user/postservice"),
-		outputDir:       newTextInput("Output directory:", "./postservice"),
+		projectName:     newTextInput("Project name:", "postservice").withValidation(generator.ValidateProjectName),
+		modulePath:      newTextInput("Go module path:", "github.com/user/postservice").withValidation(generator.ValidateModulePath),
+		outputDir:       newTextInput("Output directory:", "./postservice").withValidation(generator.ValidateOutputDir),
 		databaseSelect:  newSingleSelect("Select database:", databaseOptions),
 		awsProfileSelect: newSingleSelect("Select AWS profile:", awsProfileOptions),
 		awsAccessKeyID:  newTextInputWithSensitivity("AWS Access Key ID:", "", true),
 		awsSecretKey:    newTextInputWithSensitivity("AWS Secret Access Key:", "", true),
 		awsRegion:       newTextInput("AWS Region:", "us-east-1"),
 		frameworkSelect: newSingleSelect("Select framework:", frameworkOptions),
+		port:            port,
 		deployConfirm:   newConfirmWithDefault("Deploy to Fly.io immediately after generation?", false),
+		saveConfig:      newConfirmWithDefault("Save these settings to "+generator.ConfigFileName+" in the project to reuse with create --config?", false),
+		gitInit:         newConfirmWithDefault("Initialize a git repository with an initial commit?", false),
+		flyRegion:       newTextInput("Fly.io region:", "iad").withValidation(generator.ValidateFlyRegion),
 		spinner:         s,
 	}
 }
@@ -212,16 +257,45 @@ func loadAWSCredentialsFromProfile(profileName string) (accessKeyID, secretKey,
 	return creds.AccessKeyID, creds.SecretAccessKey, region
 }
 
+// outputDirHasFiles reports whether the output directory already exists with files in it
+func (m *Model) outputDirHasFiles() bool {
+	entries, err := os.ReadDir(m.outputDir.value)
+	return err == nil && len(entries) > 0
+}
+
+// dynamoDBSelected reports whether DynamoDB is the selected database, which adds the AWS steps
+func (m *Model) dynamoDBSelected() bool {
+	return strings.Contains(m.databaseSelect.GetSelected(), "DynamoDB")
+}
+
+// previousStep returns the step esc navigates back to, mirroring the forward branching
+// so the AWS steps are skipped unless DynamoDB is selected
+func (m *Model) previousStep() Step {
+	switch {
+	case m.step <= StepWelcome:
+		return StepWelcome
+	case m.step == StepFrameworkSelection && !m.dynamoDBSelected():
+		return StepDatabaseSelection
+	case m.step == StepReview && !m.deployConfirm.GetChoice():
+		return StepDeploySelection
+	default:
+		return m.step - 1
+	}
+}
+
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
+			if m.generating && !m.deploying && m.cancelGeneration != nil {
+				m.cancelGeneration()
+				m.canceling = true
+				return m, nil
+			}
 			return m, tea.Quit
 		case "esc":
-			if m.step > StepWelcome {
-				m.step--
-			}
+			m.step = m.previousStep()
 		}
 
 		switch m.step {
@@ -233,7 +307,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case StepProjectName:
 			var cmd tea.Cmd
 			m.projectName, cmd = m.projectName.Update(msg)
-			if msg.String() == "enter" && m.projectName.value != "" {
+			if msg.String() == "enter" && m.projectName.Valid() {
 				// Set default output dir if empty
 				if m.outputDir.value == "" || m.outputDir.value == "./postservice" {
 					m.outputDir.SetValue("./" + m.projectName.value)
@@ -248,14 +322,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case StepModulePath:
 			var cmd tea.Cmd
 			m.modulePath, cmd = m.modulePath.Update(msg)
-			if msg.String() == "enter" && m.modulePath.value != "" {
+			if msg.String() == "enter" && m.modulePath.Valid() {
 				m.step = StepOutputDir
 			}
 			return m, cmd
 		case StepOutputDir:
 			var cmd tea.Cmd
 			m.outputDir, cmd = m.outputDir.Update(msg)
-			if msg.String() == "enter" && m.outputDir.value != "" {
+			if msg.String() == "enter" && m.outputDir.value != "" && m.outputDir.Valid() {
 				m.step = StepDatabaseSelection
 			}
 			return m, cmd
@@ -264,7 +338,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.databaseSelect, cmd = m.databaseSelect.Update(msg)
 			if msg.String() == "enter" && m.databaseSelect.GetSelected() != "" {
 				// If DynamoDB is selected, show AWS profile selection
-				if strings.Contains(m.databaseSelect.GetSelected(), "DynamoDB") {
+				if m.dynamoDBSelected() {
 					m.step = StepAWSProfileSelection
 				} else {
 					// Skip AWS credentials for PostgreSQL
@@ -317,6 +391,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			var cmd tea.Cmd
 			m.frameworkSelect, cmd = m.frameworkSelect.Update(msg)
 			if msg.String() == "enter" && m.frameworkSelect.GetSelected() != "" {
+				m.step = StepPort
+			}
+			return m, cmd
+		case StepPort:
+			var cmd tea.Cmd
+			m.port, cmd = m.port.Update(msg)
+			if msg.String() == "enter" && m.port.Valid() {
 				m.step = StepDeploySelection
 			}
 			return m, cmd
@@ -324,15 +405,64 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			var cmd tea.Cmd
 			m.deployConfirm, cmd = m.deployConfirm.Update(msg)
 			if msg.String() == "enter" {
+				if m.deployConfirm.GetChoice() {
+					// Suggest the region inferred from the rest of the configuration
+					if m.flyRegion.value == "" {
+						m.flyRegion.SetValue(generator.NewGenerator(m.projectConfig()).FlyRegion())
+					}
+					m.step = StepFlyRegion
+				} else {
+					m.step = StepReview
+				}
+			}
+			return m, cmd
+		case StepFlyRegion:
+			var cmd tea.Cmd
+			m.flyRegion, cmd = m.flyRegion.Update(msg)
+			if msg.String() == "enter" && m.flyRegion.Valid() {
 				m.step = StepReview
 			}
 			return m, cmd
 		case StepReview:
 			if msg.String() == "enter" {
+				m.step = StepSaveConfig
+			}
+		case StepSaveConfig:
+			var cmd tea.Cmd
+			m.saveConfig, cmd = m.saveConfig.Update(msg)
+			if msg.String() == "enter" {
+				m.step = StepGitInit
+			}
+			return m, cmd
+		case StepGitInit:
+			var cmd tea.Cmd
+			m.gitInit, cmd = m.gitInit.Update(msg)
+			if msg.String() == "enter" {
+				// Confirm before writing on top of existing files
+				if m.outputDirHasFiles() {
+					m.overwriteConfirm = newConfirmWithDefault(fmt.Sprintf("%s is not empty. Existing files may be overwritten. Generate anyway?", m.outputDir.value), false)
+					m.step = StepOverwriteConfirm
+					return m, nil
+				}
 				m.step = StepGenerating
 				m.generating = true
 				return m, tea.Batch(m.spinner.Tick, m.generate())
 			}
+			return m, cmd
+		case StepOverwriteConfirm:
+			var cmd tea.Cmd
+			m.overwriteConfirm, cmd = m.overwriteConfirm.Update(msg)
+			if msg.String() == "enter" {
+				if !m.overwriteConfirm.GetChoice() {
+					// Let the user pick a different output directory
+					m.step = StepOutputDir
+					return m, cmd
+				}
+				m.step = StepGenerating
+				m.generating = true
+				return m, tea.Batch(m.spinner.Tick, m.generate())
+			}
+			return m, cmd
 		case StepComplete:
 			if msg.String() == "enter" {
 				return m, tea.Quit
@@ -345,6 +475,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 
 	case GenerationCompleteMsg:
+		m.cancelGeneration = nil
+		if m.canceling {
+			// Generation finished before it noticed the cancellation
+			return m, tea.Quit
+		}
+		m.warning = msg.Warning
 		if msg.ShouldDeploy {
 			m.step = StepGenerating
 			m.generating = true
@@ -354,6 +490,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.step = StepComplete
 		m.generating = false
 		return m, nil
+	case DeployLogMsg:
+		m.deployLog = append(m.deployLog, msg.Line)
+		return m, waitForDeployMsg(m.deployMsgs)
 	case DeploymentCompleteMsg:
 		m.step = StepComplete
 		m.generating = false
@@ -361,8 +500,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Error != nil {
 			m.err = msg.Error
 		}
+		if msg.Success {
+			m.deployedURL = msg.URL
+		}
 		return m, nil
 	case GenerationErrorMsg:
+		m.cancelGeneration = nil
+		if m.canceling {
+			return m, tea.Quit
+		}
 		m.err = msg.Err
 		m.generating = false
 		return m, nil
@@ -371,60 +517,111 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// projectConfig builds the generator configuration from the user's selections
+func (m *Model) projectConfig() generator.ProjectConfig {
+	// Map database selection
+	var dbType generator.DatabaseType
+	selectedDB := m.databaseSelect.GetSelected()
+	if strings.Contains(selectedDB, "PostgreSQL") {
+		dbType = generator.DatabaseTypePostgres
+	} else if strings.Contains(selectedDB, "DynamoDB") {
+		dbType = generator.DatabaseTypeDynamoDB
+	}
+
+	// Map framework selection
+	var frameworkType generator.FrameworkType
+	selectedFramework := m.frameworkSelect.GetSelected()
+	if strings.Contains(selectedFramework, "Chi") {
+		frameworkType = generator.FrameworkTypeChi
+	} else if strings.Contains(selectedFramework, "Gin") {
+		frameworkType = generator.FrameworkTypeGin
+	} else if strings.Contains(selectedFramework, "Echo") {
+		frameworkType = generator.FrameworkTypeEcho
+	} else if strings.Contains(selectedFramework, "ConnectRPC") {
+		frameworkType = generator.FrameworkTypeConnectRPC
+	}
+
+	return generator.ProjectConfig{
+		ProjectName: m.projectName.value,
+		ModulePath:  m.modulePath.value,
+		OutputDir:   m.outputDir.value,
+		Database: generator.DatabaseConfig{
+			Type:           dbType,
+			AWSAccessKeyID: m.awsAccessKeyID.value,
+			AWSSecretKey:   m.awsSecretKey.value,
+			AWSRegion:      m.awsRegion.value,
+			AWSProfile:     m.awsProfileName,
+		},
+		Framework: frameworkType,
+		Deploy:    true, // Always generate deployment files
+		Client:    true, // Always generate the typed client (or document the Connect one)
+		FlyRegion: m.flyRegion.value,
+		Port:      m.port.value,
+	}
+}
+
+// generate captures everything the generation needs from the model, then returns a command
+// that runs it. The command runs outside Update, so it must not read or write the model.
 func (m *Model) generate() tea.Cmd {
+	cfg := m.projectConfig()
+
+	// Existing files that differ are only replaced once the user confirmed it
+	var opts []generator.GeneratorOption
+	if m.overwriteConfirm.GetChoice() {
+		opts = append(opts, generator.WithOverwritePolicy(generator.OverwritePolicyOverwrite))
+	}
+	verify := m.opts.Verify
+	saveConfig := m.saveConfig.GetChoice()
+	gitInit := m.gitInit.GetChoice()
+	// Whether to deploy right after generating, carried to Update in the completion message
+	shouldDeploy := m.deployConfirm.GetChoice()
+	// Quitting cancels ctx, which rolls back a generation in progress or stops verification
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelGeneration = cancel
+
 	return func() tea.Msg {
-		// Map database selection
-		var dbType generator.DatabaseType
-		selectedDB := m.databaseSelect.GetSelected()
-		if strings.Contains(selectedDB, "PostgreSQL") {
-			dbType = generator.DatabaseTypePostgres
-		} else if strings.Contains(selectedDB, "DynamoDB") {
-			dbType = generator.DatabaseTypeDynamoDB
+		defer cancel()
+		gen := generator.NewGenerator(cfg, opts...)
+		
+		// Generate synchronously
+		if err := gen.GenerateContext(ctx); err != nil {
+			return GenerationErrorMsg{Err: err}
 		}
 
-		// Map framework selection
-		var frameworkType generator.FrameworkType
-		selectedFramework := m.frameworkSelect.GetSelected()
-		if strings.Contains(selectedFramework, "Chi") {
-			frameworkType = generator.FrameworkTypeChi
-		} else if strings.Contains(selectedFramework, "ConnectRPC") {
-			frameworkType = generator.FrameworkTypeConnectRPC
+		if verify {
+			if err := gen.Verify(ctx); err != nil {
+				return GenerationErrorMsg{Err: err}
+			}
 		}
 
-		cfg := generator.ProjectConfig{
-			ProjectName: m.projectName.value,
-			ModulePath:  m.modulePath.value,
-			OutputDir:   m.outputDir.value,
-			Database: generator.DatabaseConfig{
-				Type:           dbType,
-				AWSAccessKeyID: m.awsAccessKeyID.value,
-				AWSSecretKey:   m.awsSecretKey.value,
-				AWSRegion:      m.awsRegion.value,
-			},
-			Framework: frameworkType,
-			Deploy:    true, // Always generate deployment files
+		if saveConfig {
+			if err := generator.SaveConfigFile(filepath.Join(cfg.OutputDir, generator.ConfigFileName), cfg); err != nil {
+				return GenerationErrorMsg{Err: err}
+			}
 		}
 
-		gen := generator.NewGenerator(cfg)
-		
-		// Generate synchronously
-		if err := gen.Generate(); err != nil {
-			return GenerationErrorMsg{Err: err}
+		// Without git the project is still usable, so that is only a warning
+		var warning string
+		if gitInit {
+			if err := gen.GitInit(ctx); err != nil {
+				if !errors.Is(err, generator.ErrGitNotFound) && !errors.Is(err, generator.ErrGitRepoExists) {
+					return GenerationErrorMsg{Err: err}
+				}
+				warning = err.Error()
+			}
 		}
 
-		// Store deploy flag for completion message (whether to deploy now)
-		m.deployEnabled = m.deployConfirm.GetChoice()
-
 		// If user chose to deploy immediately, trigger deployment
-		if m.deployEnabled {
+		if shouldDeploy {
 			return GenerationCompleteMsg{
 				ShouldDeploy: true,
 				OutputDir:    cfg.OutputDir,
 				ProjectName:  cfg.ProjectName,
+				Warning:      warning,
 			}
 		}
 
-		return GenerationCompleteMsg{ShouldDeploy: false}
+		return GenerationCompleteMsg{ShouldDeploy: false, Warning: warning}
 	}
 }
 
@@ -432,12 +629,18 @@ type GenerationCompleteMsg struct {
 	ShouldDeploy bool
 	OutputDir    string
 	ProjectName  string
+	Warning      string // Set when an optional step such as git init was skipped
 }
 type GenerationErrorMsg struct {
 	Err error
 }
+// DeployLogMsg carries one line of deploy command output while the deploy runs
+type DeployLogMsg struct {
+	Line string
+}
 type DeploymentCompleteMsg struct {
 	Success bool
+	URL     string // Public URL of the deployed app, set on success
 	Error   error
 }
 
@@ -471,10 +674,20 @@ func (m *Model) View() string {
 			return m.renderAWSRegion()
 		case StepFrameworkSelection:
 			return m.renderFrameworkSelection()
+	case StepPort:
+		return m.renderPort()
 	case StepDeploySelection:
 		return m.renderDeploySelection()
+	case StepFlyRegion:
+		return m.renderFlyRegion()
 	case StepReview:
 		return m.renderReview()
+	case StepSaveConfig:
+		return m.renderSaveConfig()
+	case StepGitInit:
+		return m.renderGitInit()
+	case StepOverwriteConfirm:
+		return m.renderOverwriteConfirm()
 	case StepGenerating:
 		return m.renderGenerating()
 	case StepComplete:
@@ -495,7 +708,7 @@ func (m *Model) renderWelcome() string {
 		Render(`Welcome! This tool will help you create a production-ready
 Go API service with:
 
-  • REST API (Chi) or gRPC (ConnectRPC)
+  • REST API (Chi, Gin or Echo) or gRPC (ConnectRPC)
   • Database integration (PostgreSQL or DynamoDB)
   • Atlas migrations for PostgreSQL
   • One-click deployment (Fly.io)
@@ -635,6 +848,32 @@ func (m *Model) renderDeploySelection() string {
 	return lipgloss.JoinVertical(lipgloss.Left, title, "", note, form, help)
 }
 
+func (m *Model) renderPort() string {
+	title := titleStyle.Render("🔌 Server Port")
+	note := lipgloss.NewStyle().
+		Foreground(whiteColor).
+		MarginTop(1).
+		MarginBottom(1).
+		Render("Enter the port the server listens on, so services scaffolded side by side don't collide (leave empty for " + generator.DefaultPort + ")")
+	form := m.port.View()
+	help := helpStyle.Render("\nEnter: Continue  Esc: Back  Ctrl+C: Quit")
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, "", note, form, help)
+}
+
+func (m *Model) renderFlyRegion() string {
+	title := titleStyle.Render("🌍 Fly.io Region")
+	note := lipgloss.NewStyle().
+		Foreground(whiteColor).
+		MarginTop(1).
+		MarginBottom(1).
+		Render("Enter the Fly.io region to deploy to (e.g., iad, fra, sin). Run `fly platform regions` for the full list")
+	form := m.flyRegion.View()
+	help := helpStyle.Render("\nEnter: Continue  Esc: Back  Ctrl+C: Quit")
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, "", note, form, help)
+}
+
 func (m *Model) renderReview() string {
 	title := titleStyle.Render("📋 Review Configuration")
 	
@@ -664,11 +903,27 @@ func (m *Model) renderReview() string {
 			labelStyle.Render("AWS Secret Key:")+" "+valueStyle.Render(maskString(m.awsSecretKey.value)),
 			labelStyle.Render("AWS Region:")+" "+valueStyle.Render(m.awsRegion.value),
 		)
+		reviewItems = append(reviewItems,
+			helpStyle.Render("AWS keys aren't written to .env.local; it references AWS_PROFILE instead"),
+		)
 	}
 
 	reviewItems = append(reviewItems,
 		labelStyle.Render("Framework:")+" "+valueStyle.Render(m.frameworkSelect.GetSelected()),
+		labelStyle.Render("Port:")+" "+valueStyle.Render(generator.NewGenerator(m.projectConfig()).Port()),
 		labelStyle.Render("Deploy Now:")+" "+deployText,
+	)
+	if m.deployConfirm.GetChoice() {
+		reviewItems = append(reviewItems,
+			labelStyle.Render("Fly.io Region:")+" "+valueStyle.Render(generator.NewGenerator(m.projectConfig()).FlyRegion()),
+		)
+	}
+
+	for _, warning := range generator.NewGenerator(m.projectConfig()).Warnings() {
+		reviewItems = append(reviewItems, "", warningStyle.Render("⚠️  "+warning))
+	}
+
+	reviewItems = append(reviewItems,
 		"",
 		helpStyle.Render("Press Enter to generate, Esc to go back, Ctrl+C to quit"),
 	)
@@ -678,57 +933,179 @@ func (m *Model) renderReview() string {
 	return lipgloss.JoinVertical(lipgloss.Left, title, "", content)
 }
 
+func (m *Model) renderSaveConfig() string {
+	title := titleStyle.Render("💾 Save Configuration")
+	form := m.saveConfig.View()
+	note := helpStyle.Render("AWS access keys are never saved; the AWS profile name is stored instead.")
+	help := helpStyle.Render("\nY/N: Toggle  Enter: Continue  Esc: Back  Ctrl+C: Quit")
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, "", form, "", note, help)
+}
+
+func (m *Model) renderGitInit() string {
+	title := titleStyle.Render("🌱 Git Repository")
+	form := m.gitInit.View()
+	note := helpStyle.Render("Runs git init and commits the generated files; skipped with a warning if git isn't installed.")
+	help := helpStyle.Render("\nY/N: Toggle  Enter: Continue  Esc: Back  Ctrl+C: Quit")
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, "", form, "", note, help)
+}
+
+func (m *Model) renderOverwriteConfirm() string {
+	title := titleStyle.Render("⚠️  Output Directory Not Empty")
+	form := m.overwriteConfirm.View()
+	help := helpStyle.Render("\nY/N: Toggle  Enter: Continue (No picks another directory)  Esc: Back  Ctrl+C: Quit")
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, "", form, help)
+}
+
 func (m *Model) renderGenerating() string {
 	var title, message string
 	if m.deploying {
 		title = titleStyle.Render("🚀 Deploying to Fly.io...")
 		message = "Deploying application..."
+	} else if m.canceling {
+		title = titleStyle.Render("⚙️  Canceling...")
+		message = "Removing generated files..."
 	} else {
 		title = titleStyle.Render("⚙️  Generating Project...")
 		message = "Generating project files..."
 	}
 	spinner := m.spinner.View()
 	
-	content := lipgloss.JoinVertical(lipgloss.Left,
-		spinner+" "+message,
-		"",
-		helpStyle.Render("Please wait..."),
-	)
+	items := []string{spinner + " " + message, ""}
+	if m.deploying && len(m.deployLog) > 0 {
+		items = append(items, deployLogStyle.Render(strings.Join(tail(m.deployLog, deployLogLines), "\n")), "")
+	}
+	items = append(items, helpStyle.Render("Please wait..."))
+	content := lipgloss.JoinVertical(lipgloss.Left, items...)
 
 	return lipgloss.JoinVertical(lipgloss.Left, title, "", content)
 }
 
-// deploy attempts to deploy the project to Fly.io
+// deployLogLines is how many of the latest deploy output lines are shown while deploying
+const deployLogLines = 12
+
+// deploy attempts to deploy the project to Fly.io, streaming the command output to the
+// view as DeployLogMsg updates followed by a DeploymentCompleteMsg
 func (m *Model) deploy(outputDir, projectName string) tea.Cmd {
+	msgs := make(chan tea.Msg)
+	m.deployMsgs = msgs
+	m.deployLog = nil
 	return func() tea.Msg {
-		// Check if flyctl or fly command exists in PATH
-		var flyCmd string
-		if path, err := exec.LookPath("flyctl"); err == nil && path != "" {
-			flyCmd = "flyctl"
-		} else if path, err := exec.LookPath("fly"); err == nil && path != "" {
-			flyCmd = "fly"
+		go runDeploy(outputDir, projectName, msgs)
+		return <-msgs
+	}
+}
+
+// waitForDeployMsg returns a command that waits for the next message from a running deploy
+func waitForDeployMsg(msgs <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-msgs
+		if !ok {
+			return nil
 		}
+		return msg
+	}
+}
 
-		if flyCmd == "" {
-			return DeploymentCompleteMsg{
-				Success: false,
-				Error: fmt.Errorf("flyctl or fly command not found. Please install from https://fly.io/docs/getting-started/installing-flyctl/"),
-			}
+// runDeploy runs fly launch in outputDir, sending each output line to msgs and finally
+// the deployment result, then closes msgs
+func runDeploy(outputDir, projectName string, msgs chan<- tea.Msg) {
+	defer close(msgs)
+
+	// Check if flyctl or fly command exists in PATH
+	var flyCmd string
+	if path, err := exec.LookPath("flyctl"); err == nil && path != "" {
+		flyCmd = "flyctl"
+	} else if path, err := exec.LookPath("fly"); err == nil && path != "" {
+		flyCmd = "fly"
+	}
+
+	if flyCmd == "" {
+		msgs <- DeploymentCompleteMsg{
+			Success: false,
+			Error: fmt.Errorf("flyctl or fly command not found. Please install from https://fly.io/docs/getting-started/installing-flyctl/"),
 		}
+		return
+	}
 
-		// Use fly launch to create and deploy the app (non-interactive, reuse fly.toml)
-		cmd := exec.Command(flyCmd, "launch", "--name", projectName, "--copy-config", "--yes")
-		cmd.Dir = outputDir
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			return DeploymentCompleteMsg{
-				Success: false,
-				Error: fmt.Errorf("deployment failed: %w\nOutput: %s", err, string(output)),
-			}
+	// Use fly launch to create and deploy the app (non-interactive, reuse fly.toml)
+	cmd := exec.Command(flyCmd, "launch", "--name", projectName, "--copy-config", "--yes")
+	cmd.Dir = outputDir
+	output, err := streamOutput(cmd, msgs)
+	if err != nil {
+		msgs <- DeploymentCompleteMsg{
+			Success: false,
+			Error: fmt.Errorf("deployment failed: %w\nOutput: %s", err, output),
+		}
+		return
+	}
+
+	msgs <- DeploymentCompleteMsg{Success: true, URL: parseDeployURL(output, projectName)}
+}
+
+// streamOutput runs cmd, sending each line of its combined stdout and stderr to msgs as a
+// DeployLogMsg as soon as it is written. It returns the full output once cmd exits.
+func streamOutput(cmd *exec.Cmd, msgs chan<- tea.Msg) (string, error) {
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+
+	waitErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		writer.Close()
+		waitErr <- err
+	}()
+
+	var output strings.Builder
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		output.WriteString(line + "\n")
+		// Progress output redraws a line with carriage returns; show the latest state
+		if i := strings.LastIndex(line, "\r"); i >= 0 {
+			line = line[i+1:]
 		}
+		msgs <- DeployLogMsg{Line: line}
+	}
+	// Keep draining after a scan error (e.g. an overlong line) so the command can exit
+	io.Copy(io.Discard, reader)
+
+	return output.String(), <-waitErr
+}
+
+// tail returns the last n lines
+func tail(lines []string, n int) []string {
+	if len(lines) <= n {
+		return lines
+	}
+	return lines[len(lines)-n:]
+}
 
-		return DeploymentCompleteMsg{Success: true}
+var (
+	// flyURLPattern matches the app URL fly prints after deploying
+	// (e.g. "Visit your newly deployed app at https://postservice.fly.dev/")
+	flyURLPattern = regexp.MustCompile(`https://[a-z0-9-]+\.fly\.dev`)
+	// flyHostnamePattern matches a bare app hostname (e.g. "Hostname: postservice.fly.dev")
+	flyHostnamePattern = regexp.MustCompile(`\b[a-z0-9-]+\.fly\.dev\b`)
+)
+
+// parseDeployURL extracts the app URL from fly launch/deploy output, falling back to
+// the default https://<app>.fly.dev URL for the app named after the project
+func parseDeployURL(output, projectName string) string {
+	if url := flyURLPattern.FindString(output); url != "" {
+		return url
+	}
+	if hostname := flyHostnamePattern.FindString(output); hostname != "" {
+		return "https://" + hostname
 	}
+	return "https://" + projectName + ".fly.dev"
 }
 
 func (m *Model) renderComplete() string {
@@ -766,21 +1143,35 @@ func (m *Model) renderComplete() string {
 		"  cd " + m.outputDir.value,
 		"  make deps",
 		"  make build",
-		"  make deploy",
+	}
+	if m.deployedURL == "" {
+		nextSteps = append(nextSteps, "  make deploy")
 	}
 	
 	nextSteps = append(nextSteps, "")
 	
-	content := lipgloss.JoinVertical(lipgloss.Left,
+	items := []string{
 		"",
-		valueStyle.Render("Project:")+" "+m.projectName.value,
-		valueStyle.Render("Module:")+" "+m.modulePath.value,
-		valueStyle.Render("Output:")+" "+m.outputDir.value,
+		valueStyle.Render("Project:") + " " + m.projectName.value,
+		valueStyle.Render("Module:") + " " + m.modulePath.value,
+		valueStyle.Render("Output:") + " " + m.outputDir.value,
 		"",
+	}
+	if m.warning != "" {
+		items = append(items, warningStyle.Render("⚠️  "+m.warning), "")
+	}
+	if m.deployedURL != "" {
+		items = append(items,
+			successStyle.Render("🚀 Deployed at")+" "+urlStyle.Render(m.deployedURL),
+			"",
+		)
+	}
+	items = append(items,
 		subtitleStyle.Render("Next steps:"),
 		strings.Join(nextSteps, "\n"),
 		helpStyle.Render("Press Enter to exit"),
 	)
+	content := lipgloss.JoinVertical(lipgloss.Left, items...)
 
 	return lipgloss.JoinVertical(lipgloss.Left, title, content)
 }
@@ -0,0 +1,513 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anmho/create-go-api/internal/generator"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModel_StepTransitions(t *testing.T) {
+	t.Parallel()
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+
+	tests := []struct {
+		name     string
+		database int // Index into the database options
+		start    Step
+		key      tea.KeyMsg
+		expected Step
+	}{
+		{name: "postgres: database selection skips AWS steps", database: 1, start: StepDatabaseSelection, key: enter, expected: StepFrameworkSelection},
+		{name: "postgres: back from framework skips AWS steps", database: 1, start: StepFrameworkSelection, key: esc, expected: StepDatabaseSelection},
+		{name: "postgres: back from deploy", database: 1, start: StepDeploySelection, key: esc, expected: StepPort},
+		{name: "back from port goes to framework", database: 1, start: StepPort, key: esc, expected: StepFrameworkSelection},
+		{name: "port defaults to 8080", database: 1, start: StepPort, key: enter, expected: StepDeploySelection},
+		{name: "dynamodb: database selection goes to AWS profile", database: 0, start: StepDatabaseSelection, key: enter, expected: StepAWSProfileSelection},
+		{name: "dynamodb: back from framework goes to AWS region", database: 0, start: StepFrameworkSelection, key: esc, expected: StepAWSRegion},
+		{name: "dynamodb: back from AWS profile", database: 0, start: StepAWSProfileSelection, key: esc, expected: StepDatabaseSelection},
+		{name: "back from welcome stays on welcome", database: 0, start: StepWelcome, key: esc, expected: StepWelcome},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewModel()
+			m.databaseSelect.cursor = tt.database
+			m.databaseSelect.selected = tt.database
+			m.step = tt.start
+
+			m.Update(tt.key)
+			assert.Equal(t, tt.expected, m.step)
+		})
+	}
+}
+
+func TestModel_ModulePathValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		modulePath string
+		expected   Step
+		expectErr  bool
+	}{
+		{name: "valid module path advances", modulePath: "github.com/user/postservice", expected: StepOutputDir},
+		{name: "trailing slash", modulePath: "github.com/user/", expected: StepModulePath, expectErr: true},
+		{name: "spaces", modulePath: "my project", expected: StepModulePath, expectErr: true},
+		{name: "empty", modulePath: "", expected: StepModulePath, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewModel()
+			m.step = StepModulePath
+			m.modulePath.SetValue(tt.modulePath)
+
+			m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			assert.Equal(t, tt.expected, m.step)
+			if tt.expectErr {
+				assert.Error(t, m.modulePath.err)
+				assert.Contains(t, m.modulePath.View(), m.modulePath.err.Error())
+			} else {
+				assert.NoError(t, m.modulePath.err)
+			}
+		})
+	}
+}
+
+func TestModel_OutputDirValidation(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "file.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("data"), 0644))
+
+	tests := []struct {
+		name      string
+		outputDir string
+		expected  Step
+		expectErr bool
+	}{
+		{name: "writable directory advances", outputDir: filepath.Join(tempDir, "service"), expected: StepDatabaseSelection},
+		{name: "file", outputDir: filePath, expected: StepOutputDir, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewModel()
+			m.step = StepOutputDir
+			m.outputDir.SetValue(tt.outputDir)
+
+			m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			assert.Equal(t, tt.expected, m.step)
+			if tt.expectErr {
+				assert.Error(t, m.outputDir.err)
+				assert.Contains(t, m.outputDir.View(), m.outputDir.err.Error())
+			} else {
+				assert.NoError(t, m.outputDir.err)
+			}
+		})
+	}
+}
+
+func TestModel_ProjectNameValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		projectName string
+		expected    Step
+	}{
+		{name: "valid name advances", projectName: "my-api", expected: StepModulePath},
+		{name: "uppercase", projectName: "MyAPI", expected: StepProjectName},
+		{name: "spaces", projectName: "post service", expected: StepProjectName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewModel()
+			m.step = StepProjectName
+			m.projectName.SetValue(tt.projectName)
+
+			m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			assert.Equal(t, tt.expected, m.step)
+			if tt.expected == StepProjectName {
+				assert.Contains(t, m.projectName.View(), "use lowercase, no spaces")
+			}
+		})
+	}
+}
+func TestModel_FlyRegion(t *testing.T) {
+	t.Parallel()
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	t.Run("deploy now asks for the region, prefilled with the inferred one", func(t *testing.T) {
+		t.Parallel()
+
+		m := NewModel()
+		m.databaseSelect.cursor = 1 // PostgreSQL
+		m.databaseSelect.selected = 1
+		m.deployConfirm = newConfirmWithDefault("", true)
+		m.step = StepDeploySelection
+
+		m.Update(enter)
+		assert.Equal(t, StepFlyRegion, m.step)
+		assert.Equal(t, "iad", m.flyRegion.value)
+
+		m.flyRegion.SetValue("fra")
+		m.Update(enter)
+		assert.Equal(t, StepReview, m.step)
+		assert.Equal(t, "fra", m.projectConfig().FlyRegion)
+		assert.Contains(t, m.renderReview(), "fra")
+	})
+
+	t.Run("no deploy skips the region", func(t *testing.T) {
+		t.Parallel()
+
+		m := NewModel()
+		m.step = StepDeploySelection
+
+		m.Update(enter)
+		assert.Equal(t, StepReview, m.step)
+
+		m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		assert.Equal(t, StepDeploySelection, m.step)
+	})
+
+	t.Run("unknown region", func(t *testing.T) {
+		t.Parallel()
+
+		m := NewModel()
+		m.step = StepFlyRegion
+		m.flyRegion.SetValue("xyz")
+
+		m.Update(enter)
+		assert.Equal(t, StepFlyRegion, m.step)
+		assert.Error(t, m.flyRegion.err)
+	})
+}
+
+func TestModel_OverwriteConfirm(t *testing.T) {
+	t.Parallel()
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	yes := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}
+
+	t.Run("empty output dir skips confirmation", func(t *testing.T) {
+		t.Parallel()
+
+		m := NewModel()
+		m.outputDir.SetValue(t.TempDir())
+		assert.False(t, m.outputDirHasFiles())
+
+		m.outputDir.SetValue(filepath.Join(t.TempDir(), "missing"))
+		assert.False(t, m.outputDirHasFiles())
+	})
+
+	t.Run("non-empty output dir asks to confirm", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), 0644))
+
+		m := NewModel()
+		m.outputDir.SetValue(dir)
+		m.step = StepGitInit
+
+		m.Update(enter)
+		assert.Equal(t, StepOverwriteConfirm, m.step)
+		assert.False(t, m.generating)
+		assert.False(t, m.overwriteConfirm.GetChoice(), "overwriting should default to no")
+
+		// Declining returns to the output directory step without generating
+		m.Update(enter)
+		assert.Equal(t, StepOutputDir, m.step)
+		assert.False(t, m.generating)
+	})
+
+	t.Run("confirming generates", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), 0644))
+
+		m := NewModel()
+		m.outputDir.SetValue(dir)
+		m.step = StepOverwriteConfirm
+		m.overwriteConfirm = newConfirmWithDefault("overwrite?", false)
+
+		m.Update(yes)
+		_, cmd := m.Update(enter)
+		assert.Equal(t, StepGenerating, m.step)
+		assert.True(t, m.generating)
+		assert.NotNil(t, cmd)
+	})
+}
+
+func TestModel_SaveConfig(t *testing.T) {
+	t.Parallel()
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	yes := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+
+	t.Run("review asks whether to save", func(t *testing.T) {
+		t.Parallel()
+
+		m := NewModel()
+		m.outputDir.SetValue(filepath.Join(t.TempDir(), "svc"))
+		m.step = StepReview
+
+		m.Update(enter)
+		assert.Equal(t, StepSaveConfig, m.step)
+		assert.False(t, m.saveConfig.GetChoice(), "saving should default to no")
+
+		m.Update(esc)
+		assert.Equal(t, StepReview, m.step)
+	})
+
+	t.Run("saved config round-trips", func(t *testing.T) {
+		t.Parallel()
+
+		m := NewModel()
+		m.projectName.SetValue("svc")
+		m.modulePath.SetValue("github.com/acme/svc")
+		m.outputDir.SetValue(filepath.Join(t.TempDir(), "svc"))
+		m.databaseSelect.selected = 0  // DynamoDB
+		m.frameworkSelect.selected = 1 // Chi
+		m.awsProfileName = "dev"
+		m.awsAccessKeyID.SetValue("AKIAEXAMPLEKEYID")
+		m.awsSecretKey.SetValue("example-secret-key")
+		m.awsRegion.SetValue("eu-west-1")
+		m.step = StepSaveConfig
+
+		m.Update(yes)
+		m.Update(enter)
+		assert.Equal(t, StepGitInit, m.step)
+		_, cmd := m.Update(enter)
+		assert.Equal(t, StepGenerating, m.step)
+		require.NotNil(t, cmd)
+
+		msg := m.generate()()
+		require.IsType(t, GenerationCompleteMsg{}, msg)
+
+		path := filepath.Join(m.outputDir.value, generator.ConfigFileName)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "AKIAEXAMPLEKEYID")
+		assert.NotContains(t, string(data), "example-secret-key")
+
+		loaded, err := generator.LoadConfigFile(path)
+		require.NoError(t, err)
+		expected := m.projectConfig()
+		expected.Database.AWSAccessKeyID = ""
+		expected.Database.AWSSecretKey = ""
+		assert.Equal(t, expected, loaded)
+	})
+}
+
+func TestModel_GitInit(t *testing.T) {
+	t.Parallel()
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	yes := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}
+
+	t.Run("defaults to the --git-init flag", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, NewApp(Options{}).model.gitInit.GetChoice())
+		assert.True(t, NewApp(Options{GitInit: true}).model.gitInit.GetChoice())
+	})
+
+	t.Run("confirming commits the generated project", func(t *testing.T) {
+		t.Parallel()
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not found in PATH")
+		}
+
+		m := NewModel()
+		m.projectName.SetValue("svc")
+		m.modulePath.SetValue("github.com/acme/svc")
+		m.outputDir.SetValue(filepath.Join(t.TempDir(), "svc"))
+		m.databaseSelect.selected = 1  // PostgreSQL
+		m.frameworkSelect.selected = 1 // Chi
+		m.step = StepGitInit
+
+		m.Update(yes)
+		_, cmd := m.Update(enter)
+		assert.Equal(t, StepGenerating, m.step)
+		require.NotNil(t, cmd)
+
+		msg := m.generate()()
+		require.IsType(t, GenerationCompleteMsg{}, msg)
+		assert.Empty(t, msg.(GenerationCompleteMsg).Warning)
+
+		log := exec.Command("git", "rev-list", "--count", "HEAD")
+		log.Dir = m.outputDir.value
+		output, err := log.Output()
+		require.NoError(t, err)
+		assert.Equal(t, "1", strings.TrimSpace(string(output)))
+	})
+}
+
+func TestModel_GenerateCapturesState(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	m.projectName.SetValue("svc")
+	m.modulePath.SetValue("github.com/acme/svc")
+	outputDir := filepath.Join(t.TempDir(), "svc")
+	m.outputDir.SetValue(outputDir)
+	m.deployConfirm = newConfirmWithDefault("", true)
+
+	cmd := m.generate()
+
+	// The command runs concurrently with Update, so later model changes must not affect it
+	m.outputDir.SetValue(filepath.Join(t.TempDir(), "other"))
+	m.deployConfirm = newConfirmWithDefault("", false)
+
+	msg := cmd()
+	require.IsType(t, GenerationCompleteMsg{}, msg)
+	complete := msg.(GenerationCompleteMsg)
+	assert.True(t, complete.ShouldDeploy)
+	assert.Equal(t, outputDir, complete.OutputDir)
+	assert.FileExists(t, filepath.Join(outputDir, "go.mod"))
+}
+
+func TestModel_QuitCancelsGeneration(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	m.projectName.SetValue("svc")
+	m.modulePath.SetValue("github.com/acme/svc")
+	outputDir := filepath.Join(t.TempDir(), "svc")
+	m.outputDir.SetValue(outputDir)
+	m.step = StepGenerating
+	m.generating = true
+	cmd := m.generate()
+
+	// Quitting cancels the generation instead of exiting while it still writes files
+	_, quit := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	assert.Nil(t, quit)
+	assert.True(t, m.canceling)
+
+	msg := cmd()
+	require.IsType(t, GenerationErrorMsg{}, msg)
+	assert.ErrorIs(t, msg.(GenerationErrorMsg).Err, context.Canceled)
+	assert.NoDirExists(t, outputDir)
+
+	// The TUI exits once the generation has stopped
+	_, quit = m.Update(msg)
+	require.NotNil(t, quit)
+	assert.IsType(t, tea.QuitMsg{}, quit())
+}
+
+func TestParseDeployURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		output   string
+		expected string
+	}{
+		{
+			name:     "visit line",
+			output:   "==> Monitoring deployment\n\nVisit your newly deployed app at https://my-api-7f3a.fly.dev/\n",
+			expected: "https://my-api-7f3a.fly.dev",
+		},
+		{
+			name:     "hostname line",
+			output:   "Hostname: my-api.fly.dev\n",
+			expected: "https://my-api.fly.dev",
+		},
+		{
+			name:     "no URL in output",
+			output:   "Deployed successfully\n",
+			expected: "https://postservice.fly.dev",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, parseDeployURL(tt.output, "postservice"))
+		})
+	}
+}
+
+func TestModel_DeploymentComplete(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	m.step = StepGenerating
+	m.generating = true
+	m.deploying = true
+
+	m.Update(DeploymentCompleteMsg{Success: true, URL: "https://postservice.fly.dev"})
+	assert.Equal(t, StepComplete, m.step)
+	assert.Equal(t, "https://postservice.fly.dev", m.deployedURL)
+	view := m.View()
+	assert.Contains(t, view, "Deployed at")
+	assert.Contains(t, view, "https://postservice.fly.dev")
+	assert.NotContains(t, view, "make deploy")
+}
+
+func TestStreamOutput(t *testing.T) {
+	t.Parallel()
+
+	msgs := make(chan tea.Msg, 10)
+	cmd := exec.Command("sh", "-c", "echo building; echo warning >&2; printf 'step 1\\rstep 2\\n'")
+	output, err := streamOutput(cmd, msgs)
+	require.NoError(t, err)
+	close(msgs)
+
+	var lines []string
+	for msg := range msgs {
+		lines = append(lines, msg.(DeployLogMsg).Line)
+	}
+	assert.Equal(t, []string{"building", "warning", "step 2"}, lines)
+	assert.Equal(t, "building\nwarning\nstep 1\rstep 2\n", output)
+
+	_, err = streamOutput(exec.Command("sh", "-c", "exit 3"), make(chan tea.Msg))
+	assert.Error(t, err)
+}
+
+func TestModel_DeployLog(t *testing.T) {
+	t.Parallel()
+
+	msgs := make(chan tea.Msg, 1)
+	m := NewModel()
+	m.step = StepGenerating
+	m.generating = true
+	m.deploying = true
+	m.deployMsgs = msgs
+
+	_, cmd := m.Update(DeployLogMsg{Line: "==> Building image"})
+	assert.Equal(t, []string{"==> Building image"}, m.deployLog)
+	assert.Contains(t, m.View(), "==> Building image")
+
+	// The returned command waits for the next message from the deploy
+	msgs <- DeploymentCompleteMsg{Success: true}
+	require.NotNil(t, cmd)
+	assert.Equal(t, DeploymentCompleteMsg{Success: true}, cmd())
+}
+
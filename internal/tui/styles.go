@@ -41,6 +41,9 @@ var (
 			Foreground(errorColor).
 			Bold(true)
 
+	warningStyle = lipgloss.NewStyle().
+			Foreground(warningColor)
+
 	successStyle = lipgloss.NewStyle().
 			Foreground(successColor).
 			Bold(true)
@@ -55,5 +58,15 @@ var (
 
 	valueStyle = lipgloss.NewStyle().
 			Foreground(secondaryColor)
+
+	deployLogStyle = lipgloss.NewStyle().
+			Foreground(grayColor).
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(grayColor).
+			PaddingLeft(1)
+
+	urlStyle = lipgloss.NewStyle().
+			Foreground(primaryColor).
+			Underline(true)
 )
 
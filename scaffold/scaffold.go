@@ -0,0 +1,188 @@
+// Package scaffold generates create-go-api projects from Go code, so other tools can embed
+// project scaffolding without shelling out to the create-go-api CLI.
+//
+//	result, err := scaffold.Generate(ctx, scaffold.ProjectConfig{
+//		ProjectName: "my-api",
+//		ModulePath:  "github.com/me/my-api",
+//		OutputDir:   "./my-api",
+//		Database:    scaffold.DatabaseConfig{Type: scaffold.DatabaseTypePostgres},
+//		Framework:   scaffold.FrameworkTypeChi,
+//	})
+//
+// The configuration types are the ones the CLI uses, so every option create-go-api supports
+// is available here.
+package scaffold
+
+import (
+	"context"
+
+	"github.com/anmho/create-go-api/internal/generator"
+)
+
+// ProjectConfig holds all project configuration (see the create-go-api create flags)
+type ProjectConfig = generator.ProjectConfig
+
+// DatabaseConfig holds database-related configuration
+type DatabaseConfig = generator.DatabaseConfig
+
+// DatabaseType represents the database type
+type DatabaseType = generator.DatabaseType
+
+const (
+	DatabaseTypePostgres = generator.DatabaseTypePostgres
+	DatabaseTypeDynamoDB = generator.DatabaseTypeDynamoDB
+)
+
+// FrameworkType represents the API framework type
+type FrameworkType = generator.FrameworkType
+
+const (
+	FrameworkTypeChi        = generator.FrameworkTypeChi
+	FrameworkTypeConnectRPC = generator.FrameworkTypeConnectRPC
+	FrameworkTypeGin        = generator.FrameworkTypeGin
+	FrameworkTypeEcho       = generator.FrameworkTypeEcho
+)
+
+// LayoutType represents the package layout of the generated project
+type LayoutType = generator.LayoutType
+
+const (
+	LayoutTypeStandard = generator.LayoutTypeStandard
+	LayoutTypeFlat     = generator.LayoutTypeFlat
+)
+
+// DeployTarget represents a deployment platform
+type DeployTarget = generator.DeployTarget
+
+const (
+	DeployTargetFly        = generator.DeployTargetFly
+	DeployTargetKubernetes = generator.DeployTargetKubernetes
+	DeployTargetECS        = generator.DeployTargetECS
+	DeployTargetRailway    = generator.DeployTargetRailway
+	DeployTargetRender     = generator.DeployTargetRender
+)
+
+// Arch is the CPU architecture container images are built for
+type Arch = generator.Arch
+
+const (
+	ArchAMD64 = generator.ArchAMD64
+	ArchARM64 = generator.ArchARM64
+	ArchBoth  = generator.ArchBoth
+)
+
+// TableProvisioning controls how the DynamoDB table is created
+type TableProvisioning = generator.TableProvisioning
+
+const (
+	TableProvisioningTerraform = generator.TableProvisioningTerraform
+	TableProvisioningRuntime   = generator.TableProvisioningRuntime
+)
+
+// AWSCredentials controls how the generated .env.local supplies AWS credentials
+type AWSCredentials = generator.AWSCredentials
+
+const (
+	AWSCredentialsProfile = generator.AWSCredentialsProfile
+	AWSCredentialsStatic  = generator.AWSCredentialsStatic
+)
+
+// OverwritePolicy controls how generation handles files that already exist
+type OverwritePolicy = generator.OverwritePolicy
+
+const (
+	OverwritePolicySkip      = generator.OverwritePolicySkip
+	OverwritePolicyOverwrite = generator.OverwritePolicyOverwrite
+	OverwritePolicyBackup    = generator.OverwritePolicyBackup
+)
+
+// FileSystem is where generated files are written
+type FileSystem = generator.FileSystem
+
+// MemFileSystem is an in-memory FileSystem, for rendering a project without touching disk
+type MemFileSystem = generator.MemFileSystem
+
+// NewMemFileSystem creates an empty in-memory filesystem
+func NewMemFileSystem() *MemFileSystem {
+	return generator.NewMemFileSystem()
+}
+
+// Result describes a generated project
+type Result struct {
+	// Files are the paths generation produced, relative to OutputDir and sorted
+	Files []string
+	// Skipped are existing files left untouched because they differ from the generated
+	// output (only with OverwritePolicySkip)
+	Skipped []string
+	// BackedUp are existing files renamed to .bak before being replaced
+	// (only with OverwritePolicyBackup)
+	BackedUp []string
+	// Unchanged are existing files that already matched the generated output
+	Unchanged []string
+	// Warnings are problems with the configuration that didn't prevent generation
+	Warnings []string
+}
+
+// Option configures optional generation behavior
+type Option func(*[]generator.GeneratorOption)
+
+// WithOverwritePolicy sets how existing files that differ from the generated output are
+// handled (defaults to OverwritePolicySkip)
+func WithOverwritePolicy(policy OverwritePolicy) Option {
+	return func(opts *[]generator.GeneratorOption) {
+		*opts = append(*opts, generator.WithOverwritePolicy(policy))
+	}
+}
+
+// WithOnly limits generation to the files at or under paths, relative to OutputDir
+// (e.g. "Makefile" or ".github"), to re-scaffold part of an existing project
+func WithOnly(paths ...string) Option {
+	return func(opts *[]generator.GeneratorOption) {
+		*opts = append(*opts, generator.WithOnly(paths...))
+	}
+}
+
+// WithTemplateDir prefers templates found in dir over the embedded ones, matched by
+// relative path (e.g. dir/templates/Makefile.tmpl overrides the Makefile template)
+func WithTemplateDir(dir string) Option {
+	return func(opts *[]generator.GeneratorOption) {
+		*opts = append(*opts, generator.WithTemplateDir(dir))
+	}
+}
+
+// WithFileSystem writes the project to fs instead of the OS filesystem
+// (e.g. a MemFileSystem to capture output in memory)
+func WithFileSystem(fs FileSystem) Option {
+	return func(opts *[]generator.GeneratorOption) {
+		*opts = append(*opts, generator.WithFileSystem(fs))
+	}
+}
+
+// Generate validates cfg and generates the project into cfg.OutputDir. Generation stops once
+// ctx is done, and on failure or cancellation nothing the run wrote is left behind.
+func Generate(ctx context.Context, cfg ProjectConfig, opts ...Option) (Result, error) {
+	var genOpts []generator.GeneratorOption
+	for _, opt := range opts {
+		opt(&genOpts)
+	}
+	gen := generator.NewGenerator(cfg, genOpts...)
+
+	planned, err := gen.Plan()
+	if err != nil {
+		return Result{}, err
+	}
+	if err := gen.GenerateContext(ctx); err != nil {
+		return Result{}, err
+	}
+
+	result := Result{
+		Skipped:   gen.SkippedFiles(),
+		BackedUp:  gen.BackedUpFiles(),
+		Unchanged: gen.UnchangedFiles(),
+		Warnings:  gen.Warnings(),
+	}
+	for _, file := range planned {
+		result.Files = append(result.Files, file.Path)
+	}
+	return result, nil
+}
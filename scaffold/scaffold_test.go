@@ -0,0 +1,78 @@
+package scaffold
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProjectConfig() ProjectConfig {
+	return ProjectConfig{
+		ProjectName: "testservice",
+		ModulePath:  "github.com/test/testservice",
+		OutputDir:   "out",
+		Database:    DatabaseConfig{Type: DatabaseTypePostgres},
+		Framework:   FrameworkTypeChi,
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	memFS := NewMemFileSystem()
+	result, err := Generate(ctx, testProjectConfig(), WithFileSystem(memFS))
+	require.NoError(t, err)
+
+	assert.Contains(t, result.Files, "go.mod")
+	assert.Contains(t, result.Files, "cmd/api/main.go")
+	assert.IsNonDecreasing(t, result.Files)
+	for _, file := range result.Files {
+		_, err := memFS.ReadFile(path.Join("out", file))
+		assert.NoError(t, err, "%s should be written", file)
+	}
+	assert.Empty(t, result.Skipped)
+	assert.Empty(t, result.Unchanged)
+
+	// Generating again over the same output finds every file up to date
+	again, err := Generate(ctx, testProjectConfig(), WithFileSystem(memFS))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, result.Files, again.Unchanged)
+
+	// Re-scaffolding selected files backs up the ones that were edited
+	require.NoError(t, memFS.WriteFile(path.Join("out", "Makefile"), []byte("edited"), 0644))
+	update, err := Generate(ctx, testProjectConfig(), WithFileSystem(memFS), WithOnly("Makefile", ".github"), WithOverwritePolicy(OverwritePolicyBackup))
+	require.NoError(t, err)
+	assert.Equal(t, []string{".github/workflows/ci.yml", "Makefile"}, update.Files)
+	assert.Equal(t, []string{"Makefile"}, update.BackedUp)
+	assert.Equal(t, []string{".github/workflows/ci.yml"}, update.Unchanged)
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid config", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := testProjectConfig()
+		cfg.ModulePath = "not a module path"
+		memFS := NewMemFileSystem()
+		_, err := Generate(context.Background(), cfg, WithFileSystem(memFS))
+		assert.Error(t, err)
+		assert.Empty(t, memFS.Paths())
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		memFS := NewMemFileSystem()
+		_, err := Generate(ctx, testProjectConfig(), WithFileSystem(memFS))
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, memFS.Paths())
+	})
+}